@@ -2,28 +2,37 @@ package bundle
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/cluster"
 	"github.com/fission/fission-workflows/pkg/controller"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
 	wfictr "github.com/fission/fission-workflows/pkg/controller/invocation"
+	schedulectr "github.com/fission/fission-workflows/pkg/controller/schedule"
 	wfctr "github.com/fission/fission-workflows/pkg/controller/workflow"
 	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/bolt"
 	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
 	"github.com/fission/fission-workflows/pkg/fes/backend/nats"
+	fessql "github.com/fission/fission-workflows/pkg/fes/backend/sql"
 	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/fnenv/fission"
 	"github.com/fission/fission-workflows/pkg/fnenv/native"
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/fnenv/workflows"
 	"github.com/fission/fission-workflows/pkg/scheduler"
+	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/util"
 	"github.com/fission/fission-workflows/pkg/util/labels"
 	"github.com/fission/fission-workflows/pkg/util/pubsub"
@@ -39,22 +48,73 @@ import (
 )
 
 const (
-	gRPCAddress         = ":5555"
-	apiGatewayAddress   = ":8080"
-	fissionProxyAddress = ":8888"
+	gRPCAddress          = ":5555"
+	apiGatewayAddress    = ":8080"
+	fissionProxyAddress  = ":8888"
+	exportPath           = "/export"
+	invocationEventsPath = "/invocation/events"
 )
 
 type Options struct {
 	Nats                 *nats.Config
+	Bolt                 *bolt.Config
+	SQL                  *fessql.Config
+	SnapshotInterval     int
+	Cache                *CacheOptions
+	Retention            *RetentionOptions
+	Cluster              *ClusterOptions
 	Fission              *FissionOptions
 	InternalRuntime      bool
 	InvocationController bool
 	WorkflowController   bool
+	ScheduleController   bool
 	AdminAPI             bool
 	WorkflowAPI          bool
 	HTTPGateway          bool
 	InvocationAPI        bool
+	ScheduleAPI          bool
 	Metrics              bool
+
+	// InvocationControllerConfig tunes the invocation controller. Zero values are replaced by the defaults.
+	InvocationControllerConfig wfictr.Config
+
+	// IdempotencyWindow is the duration during which an idempotency key refers to the invocation that was created with
+	// it. If not set, the api.DefaultIdempotencyWindow is used.
+	IdempotencyWindow time.Duration
+}
+
+// CacheOptions bounds the workflow and invocation caches. Without these options the caches are unbounded.
+type CacheOptions struct {
+	// Size is the maximum number of entities in each cache.
+	Size int
+
+	// TTL is the duration after which finished entities that have not been accessed are evicted.
+	TTL time.Duration
+}
+
+// RetentionOptions configures the archival and removal of finished invocations.
+type RetentionOptions struct {
+	// Age is the duration after which finished invocations are archived.
+	Age time.Duration
+
+	// FailedAge overrides the Age for failed and aborted invocations. If not set, the Age is used.
+	FailedAge time.Duration
+
+	// ArchiveDir is the directory in which the events of the invocations are archived. If not set, the events are
+	// removed without archiving them.
+	ArchiveDir string
+}
+
+// ClusterOptions configures the coordination of the controllers of multiple bundles that share the event store. Each
+// bundle only evaluates the workflows and invocations in its shard, which is rebalanced when a bundle joins, leaves or
+// disappears.
+type ClusterOptions struct {
+	// ReplicaID identifies the bundle among the bundles that share the event store.
+	ReplicaID string
+
+	// LeaseDuration is the duration after which a bundle that stopped sending heartbeats is considered to have
+	// disappeared. If not set, the cluster.DefaultLeaseDuration is used.
+	LeaseDuration time.Duration
 }
 
 type FissionOptions struct {
@@ -72,6 +132,10 @@ func Run(ctx context.Context, opts *Options) error {
 
 	var es fes.Backend
 	var esPub pubsub.Publisher
+	// Persistent event stores do not replay the persisted events to the subscribers themselves.
+	var esPersistent bool
+	var natsEs *nats.EventStore
+	var sqlEs *fessql.Backend
 
 	grpcServer := grpc.NewServer(
 		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
@@ -87,9 +151,46 @@ func Run(ctx context.Context, opts *Options) error {
 			"cluster": opts.Nats.Cluster,
 			"client":  opts.Nats.Client,
 		}).Infof("Using event store: NATS")
-		natsEs := setupNatsEventStoreClient(opts.Nats.URL, opts.Nats.Cluster, opts.Nats.Client)
+		natsEs = setupNatsEventStoreClient(*opts.Nats)
 		es = natsEs
 		esPub = natsEs
+		go natsEs.Run(ctx)
+		defer func() {
+			err := natsEs.Close()
+			if err != nil {
+				log.Errorf("Failed to close NATS event store: %v", err)
+			} else {
+				log.Info("Closed NATS event store")
+			}
+		}()
+	} else if opts.Bolt != nil {
+		log.WithField("path", opts.Bolt.Path).Infof("Using event store: BoltDB")
+		boltEs := setupBoltEventStore(*opts.Bolt)
+		es = boltEs
+		esPub = boltEs
+		esPersistent = true
+		defer func() {
+			err := boltEs.Close()
+			if err != nil {
+				log.Errorf("Failed to close BoltDB event store: %v", err)
+			} else {
+				log.Info("Closed BoltDB event store")
+			}
+		}()
+	} else if opts.SQL != nil {
+		log.WithField("driver", opts.SQL.Driver).Infof("Using event store: SQL")
+		sqlEs = setupSQLEventStore(*opts.SQL)
+		es = sqlEs
+		esPub = sqlEs
+		esPersistent = true
+		defer func() {
+			err := sqlEs.Close()
+			if err != nil {
+				log.Errorf("Failed to close SQL event store: %v", err)
+			} else {
+				log.Info("Closed SQL event store")
+			}
+		}()
 	} else {
 		log.Warn("No event store provided; using the development, in-memory event store")
 		backend := mem.NewBackend()
@@ -97,20 +198,56 @@ func Run(ctx context.Context, opts *Options) error {
 		esPub = backend
 	}
 
+	if _, ok := es.(fes.Deleter); opts.Retention != nil && !ok {
+		// Without removing the events, the event store would keep growing while the archived invocations would only
+		// disappear from the API.
+		return fmt.Errorf("retention is not supported by event store %T: it does not support the removal of events", es)
+	}
+
+	invocationAPI := api.NewInvocationAPI(es)
+	if opts.IdempotencyWindow > 0 {
+		invocationAPI.IdempotencyWindow = opts.IdempotencyWindow
+	}
+
 	// Caches
-	wfiCache := getWorkflowInvocationCache(ctx, esPub)
-	wfCache := getWorkflowCache(ctx, esPub)
+	wfiCache := getWorkflowInvocationCache(ctx, es, esPub, opts.Cache)
+	wfCache := getWorkflowCache(ctx, es, esPub, opts.Cache)
+	scheduleCache := getScheduleCache(ctx, esPub)
+	if sqlEs != nil {
+		// The tail of the SQL event store is only started once the caches have caught up, to ensure that the caches
+		// neither miss nor apply twice the events that are appended in the meantime.
+		catchUpSQLEventStore(sqlEs, wfiCache(), wfCache(), scheduleCache())
+		go sqlEs.Run(ctx)
+	} else if esPersistent {
+		rehydrateCaches(es, wfiCache(), wfCache(), scheduleCache())
+	}
+	if natsEs != nil {
+		watchNatsEventStore(natsEs, wfiCache(), wfCache(), scheduleCache())
+	}
+	// Only the BoltDB and SQL event stores support snapshots; the NATS event store always replays all events.
+	if store, ok := es.(fes.SnapshotStore); ok && esPersistent && opts.SnapshotInterval > 0 {
+		log.Infof("Snapshotting aggregates every %d events", opts.SnapshotInterval)
+		setupSnapshotter(ctx, es, esPub, store, opts.SnapshotInterval)
+	}
+	if opts.Cache != nil && opts.Retention == nil {
+		// The bounded caches evict finished invocations, but the indexes only drop the invocations removed by retention.
+		log.Warn("Retention is disabled: the invocation indexes keep growing with the number of invocations.")
+	}
+	if opts.Retention != nil {
+		log.Infof("Archiving finished invocations after %v", opts.Retention.Age)
+		setupRetention(ctx, es, esPub, *opts.Retention, esPersistent, wfiCache(), invocationAPI.IdempotencyWindow)
+	}
 
 	//
 	// Function Runtimes
 	//
-	invocationAPI := api.NewInvocationAPI(es)
 	resolvers := map[string]fnenv.RuntimeResolver{}
 	runtimes := map[string]fnenv.Runtime{}
 
 	if opts.InternalRuntime || opts.Fission != nil {
 		log.Infof("Using Task Runtime: Workflow")
-		reflectiveRuntime := workflows.NewRuntime(invocationAPI, wfiCache())
+		reflectiveRuntime := workflows.NewRuntime(invocationAPI, wfiCache(), wfCache(),
+			opts.InvocationControllerConfig.Timeout)
 		runtimes[workflows.Name] = reflectiveRuntime
 	} else {
 		log.Info("No function runtimes specified.")
@@ -135,22 +272,56 @@ func Run(ctx context.Context, opts *Options) error {
 	//
 	// Controllers
 	//
-	if opts.InvocationController || opts.WorkflowController {
+	var metaCtrl *controller.MetaController
+	if opts.InvocationController || opts.WorkflowController || opts.ScheduleController {
+		var shard controller.Shard
+		if opts.Cluster != nil {
+			log.WithField("replica", opts.Cluster.ReplicaID).Info("Sharding controllers across the cluster")
+			membership := setupMembership(ctx, es, esPub, natsEs, *opts.Cluster)
+			defer func() {
+				err := membership.Leave()
+				if err != nil {
+					log.Errorf("Failed to leave the cluster: %v", err)
+				} else {
+					log.Info("Left the cluster")
+				}
+			}()
+			shard = membership
+		}
+
 		var ctrls []controller.Controller
 		if opts.WorkflowController {
 			log.Info("Using controller: workflow")
-			ctrls = append(ctrls, setupWorkflowController(wfCache(), es, resolvers))
+			ctrl := setupWorkflowController(wfCache(), es, resolvers)
+			if shard != nil {
+				ctrl.SetShard(shard)
+			}
+			ctrls = append(ctrls, ctrl)
 		}
 
 		if opts.InvocationController {
 			log.Info("Using controller: invocation")
-			ctrls = append(ctrls, setupInvocationController(wfiCache(), wfCache(), es, runtimes, resolvers))
+			ctrl := setupInvocationController(wfiCache(), wfCache(), es, invocationAPI, runtimes, resolvers,
+				opts.InvocationControllerConfig)
+			if shard != nil {
+				ctrl.SetShard(shard)
+			}
+			ctrls = append(ctrls, ctrl)
 		}
 
-		ctrl := controller.NewMetaController(ctrls...)
-		go ctrl.Run(ctx)
+		if opts.ScheduleController {
+			log.Info("Using controller: schedule")
+			ctrl := setupScheduleController(scheduleCache(), wfiCache(), es, invocationAPI)
+			if shard != nil {
+				ctrl.SetShard(shard)
+			}
+			ctrls = append(ctrls, ctrl)
+		}
+
+		metaCtrl = controller.NewMetaController(ctrls...)
+		go metaCtrl.Run(ctx)
 		defer func() {
-			err := ctrl.Close()
+			err := metaCtrl.Close()
 			if err != nil {
 				log.Errorf("Failed to stop controllers: %v", err)
 			} else {
@@ -166,7 +337,8 @@ func Run(ctx context.Context, opts *Options) error {
 	//
 	if opts.Fission != nil {
 		proxyMux := http.NewServeMux()
-		runFissionEnvironmentProxy(proxyMux, es, wfiCache(), wfCache(), resolvers)
+		runFissionEnvironmentProxy(proxyMux, es, invocationAPI, wfiCache(), wfCache(), resolvers,
+			opts.InvocationControllerConfig.Timeout)
 		fissionProxySrv := &http.Server{Addr: fissionProxyAddress}
 		fissionProxySrv.Handler = handlers.LoggingHandler(os.Stdout, proxyMux)
 
@@ -193,7 +365,7 @@ func Run(ctx context.Context, opts *Options) error {
 	// gRPC API
 	//
 	if opts.AdminAPI {
-		serveAdminAPI(grpcServer)
+		serveAdminAPI(grpcServer, metaCtrl)
 	}
 
 	if opts.WorkflowAPI {
@@ -201,10 +373,15 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 
 	if opts.InvocationAPI {
-		serveInvocationAPI(grpcServer, es, wfiCache())
+		serveInvocationAPI(grpcServer, es, invocationAPI, wfiCache(), wfCache(),
+			opts.InvocationControllerConfig.Timeout)
 	}
 
-	if opts.AdminAPI || opts.WorkflowAPI || opts.InvocationAPI {
+	if opts.ScheduleAPI {
+		serveScheduleAPI(grpcServer, es, scheduleCache())
+	}
+
+	if opts.AdminAPI || opts.WorkflowAPI || opts.InvocationAPI || opts.ScheduleAPI {
 		if opts.Metrics {
 			log.Debug("Instrumenting gRPC server with Prometheus metrics")
 			grpc_prometheus.Register(grpcServer)
@@ -227,12 +404,12 @@ func Run(ctx context.Context, opts *Options) error {
 	// HTTP API
 	//
 	if opts.HTTPGateway || opts.Metrics {
-		grpcMux := grpcruntime.NewServeMux()
+		grpcMux := grpcruntime.NewServeMux(grpcruntime.WithIncomingHeaderMatcher(matchIncomingHeader))
 		httpMux := http.NewServeMux()
 
 		if opts.HTTPGateway {
 
-			var admin, wf, wfi string
+			var admin, wf, wfi, sc string
 			if opts.AdminAPI {
 				admin = gRPCAddress
 			}
@@ -242,7 +419,10 @@ func Run(ctx context.Context, opts *Options) error {
 			if opts.InvocationAPI {
 				wfi = gRPCAddress
 			}
-			serveHTTPGateway(ctx, grpcMux, admin, wf, wfi)
+			if opts.ScheduleAPI {
+				sc = gRPCAddress
+			}
+			serveHTTPGateway(ctx, grpcMux, admin, wf, wfi, sc)
 		}
 
 		if opts.Metrics {
@@ -250,6 +430,16 @@ func Run(ctx context.Context, opts *Options) error {
 			log.Infof("Set up prometheus collector: %v/metrics", apiGatewayAddress)
 		}
 
+		if opts.HTTPGateway && opts.InvocationAPI {
+			httpMux.Handle(invocationEventsPath, apiserver.NewInvocationEventsHandler(wfiCache()))
+			log.Infof("Serving invocation events at: %v%v", apiGatewayAddress, invocationEventsPath)
+		}
+
+		if opts.HTTPGateway && opts.AdminAPI {
+			setupExportEndpoint(httpMux, es)
+			log.Infof("Set up event store export: %v%v", apiGatewayAddress, exportPath)
+		}
+
 		httpApiSrv := &http.Server{Addr: apiGatewayAddress}
 		httpMux.Handle("/", grpcMux)
 		httpApiSrv.Handler = handlers.LoggingHandler(os.Stdout, httpMux)
@@ -276,30 +466,44 @@ func Run(ctx context.Context, opts *Options) error {
 	return nil
 }
 
-func getWorkflowCache(ctx context.Context, eventPub pubsub.Publisher) func() fes.CacheReaderWriter {
+func getWorkflowCache(ctx context.Context, es fes.Backend, eventPub pubsub.Publisher,
+	cacheOpts *CacheOptions) func() fes.CacheReaderWriter {
 	var wfCache fes.CacheReaderWriter
 	return func() fes.CacheReaderWriter {
 		if wfCache != nil {
 			return wfCache
 		}
 
-		wfCache = setupWorkflowCache(ctx, eventPub)
+		wfCache = setupWorkflowCache(ctx, es, eventPub, cacheOpts)
 		return wfCache
 	}
 }
 
-func getWorkflowInvocationCache(ctx context.Context, eventPub pubsub.Publisher) func() fes.CacheReaderWriter {
+func getWorkflowInvocationCache(ctx context.Context, es fes.Backend, eventPub pubsub.Publisher,
+	cacheOpts *CacheOptions) func() fes.CacheReaderWriter {
 	var wfiCache fes.CacheReaderWriter
 	return func() fes.CacheReaderWriter {
 		if wfiCache != nil {
 			return wfiCache
 		}
 
-		wfiCache = setupWorkflowInvocationCache(ctx, eventPub)
+		wfiCache = setupWorkflowInvocationCache(ctx, es, eventPub, cacheOpts)
 		return wfiCache
 	}
 }
 
+func getScheduleCache(ctx context.Context, eventPub pubsub.Publisher) func() fes.CacheReaderWriter {
+	var scheduleCache fes.CacheReaderWriter
+	return func() fes.CacheReaderWriter {
+		if scheduleCache != nil {
+			return scheduleCache
+		}
+
+		scheduleCache = setupScheduleCache(ctx, eventPub)
+		return scheduleCache
+	}
+}
+
 func setupInternalFunctionRuntime() *native.FunctionEnv {
 	return native.NewFunctionEnv(builtin.DefaultBuiltinFunctions)
 }
@@ -314,32 +518,294 @@ func setupFissionFunctionResolver(controllerAddr string) *fission.Resolver {
 	return fission.NewResolver(controllerClient)
 }
 
-func setupNatsEventStoreClient(url string, cluster string, clientID string) *nats.EventStore {
-	if clientID == "" {
-		clientID = util.UID()
+func setupNatsEventStoreClient(cfg nats.Config) *nats.EventStore {
+	if cfg.Client == "" {
+		cfg.Client = util.UID()
 	}
 
-	es, err := nats.Connect(nats.Config{
-		Cluster: cluster,
-		URL:     url,
-		Client:  clientID,
-	})
+	es, err := nats.Connect(cfg)
 	if err != nil {
 		panic(err)
 	}
+	return es
+}
+
+// watchNatsEventStore starts watching the NATS event store for the events of workflows, invocations and schedules.
+//
+// Before the subjects are watched, the caches catch up on the events that have been processed prior to a restart,
+// because the watches resume after the checkpoints of the subjects. As the events are applied to the caches directly,
+// the caches have been backfilled by the time that the controllers start evaluating.
+func watchNatsEventStore(es *nats.EventStore, wfiCache fes.CacheReaderWriter, wfCache fes.CacheReaderWriter,
+	scheduleCache fes.CacheReaderWriter) {
+	targets := []struct {
+		aggregateType string
+		cache         fes.CacheReaderWriter
+	}{
+		{aggregates.TypeWorkflow, wfCache},
+		{aggregates.TypeWorkflowInvocation, wfiCache},
+		{aggregates.TypeSchedule, scheduleCache},
+	}
+	for _, t := range targets {
+		cache, ok := t.cache.(*fes.SubscribedCache)
+		if !ok {
+			panic(fmt.Sprintf("cannot catch up on %s events: unexpected cache %T", t.aggregateType, t.cache))
+		}
+		count, err := es.CatchUp(t.aggregateType, func(event *fes.Event) error {
+			err := cache.ApplyEvent(event)
+			if err != nil {
+				log.WithField("event.id", event.GetId()).Warnf("Failed to apply event while catching up: %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+		if count > 0 {
+			log.Infof("Caught up on %d %s events from the event store.", count, t.aggregateType)
+		}
 
-	err = es.Watch(fes.Aggregate{Type: "invocation"})
+		err = es.Watch(fes.Aggregate{Type: t.aggregateType})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// The claims on idempotency keys are not cached, but they are watched to publish them for their retention.
+	err := es.Watch(fes.Aggregate{Type: api.TypeIdempotencyKey})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func setupBoltEventStore(cfg bolt.Config) *bolt.Backend {
+	es, err := bolt.Open(cfg)
 	if err != nil {
 		panic(err)
 	}
-	err = es.Watch(fes.Aggregate{Type: "workflow"})
+	return es
+}
+
+// catchUpSQLEventStore fills the caches with the events persisted in the SQL event store.
+//
+// Other clients of the database might append events while the caches are filled. Therefore, the events are replayed
+// from the same point at which the tail of the event store resumes, rather than rehydrating the entities individually.
+func catchUpSQLEventStore(es *fessql.Backend, wfiCache fes.CacheReaderWriter, wfCache fes.CacheReaderWriter,
+	scheduleCache fes.CacheReaderWriter) {
+	caches := map[string]*fes.SubscribedCache{}
+	for aggregateType, cache := range map[string]fes.CacheReaderWriter{
+		aggregates.TypeWorkflow:           wfCache,
+		aggregates.TypeWorkflowInvocation: wfiCache,
+		aggregates.TypeSchedule:           scheduleCache,
+	} {
+		subscribedCache, ok := cache.(*fes.SubscribedCache)
+		if !ok {
+			panic(fmt.Sprintf("cannot catch up on %s events: unexpected cache %T", aggregateType, cache))
+		}
+		caches[aggregateType] = subscribedCache
+	}
+	count, err := es.CatchUp(func(event *fes.Event) error {
+		aggregateType := event.GetAggregate().GetType()
+		if event.GetParent() != nil {
+			aggregateType = event.GetParent().GetType()
+		}
+		cache, ok := caches[aggregateType]
+		if !ok {
+			return nil
+		}
+		err := cache.ApplyEvent(event)
+		if err != nil {
+			log.WithField("event.id", event.GetId()).Warnf("Failed to apply event while catching up: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	log.Infof("Caught up on %d events from the event store.", count)
+}
+
+func setupSQLEventStore(cfg fessql.Config) *fessql.Backend {
+	es, err := fessql.Open(cfg)
 	if err != nil {
 		panic(err)
 	}
 	return es
 }
 
-func setupWorkflowInvocationCache(ctx context.Context, invocationEventPub pubsub.Publisher) *fes.SubscribedCache {
+// rehydrateCaches fills the caches with the entities persisted in the event store.
+//
+// Contrary to the NATS event store, which replays the events when watching a subject, the entities persisted in the
+// BoltDB event store need to be loaded into the caches explicitly. The entities are rehydrated from their
+// latest snapshot (if available), avoiding a full replay of their events.
+func rehydrateCaches(es fes.Backend, wfiCache fes.CacheReaderWriter, wfCache fes.CacheReaderWriter,
+	scheduleCache fes.CacheReaderWriter) {
+	targets := []struct {
+		aggregateType string
+		cache         fes.CacheReaderWriter
+		target        func(id string) fes.Entity
+	}{
+		{aggregates.TypeWorkflow, wfCache, func(id string) fes.Entity {
+			return aggregates.NewWorkflow(id)
+		}},
+		{aggregates.TypeWorkflowInvocation, wfiCache, func(id string) fes.Entity {
+			return aggregates.NewWorkflowInvocation(id)
+		}},
+		{aggregates.TypeSchedule, scheduleCache, func(id string) fes.Entity {
+			return aggregates.NewSchedule(id)
+		}},
+	}
+	for _, t := range targets {
+		aggregateType := t.aggregateType
+		keys, err := es.List(func(s string) bool {
+			return strings.HasPrefix(s, aggregateType)
+		})
+		if err != nil {
+			panic(err)
+		}
+		var count int
+		for _, key := range keys {
+			if key.Type != aggregateType {
+				continue
+			}
+			entity := t.target(key.Id)
+			err := fes.Rehydrate(es, key, entity)
+			if err != nil {
+				log.WithField("aggregate", key.Format()).Warnf("Failed to rehydrate entity: %v", err)
+				continue
+			}
+			err = t.cache.Put(entity)
+			if err != nil {
+				log.WithField("aggregate", key.Format()).Warnf("Failed to cache rehydrated entity: %v", err)
+				continue
+			}
+			count++
+		}
+		log.Infof("Loaded %d %s entities from the event store.", count, aggregateType)
+	}
+}
+
+func setupSnapshotter(ctx context.Context, es fes.Backend, esPub pubsub.Publisher, store fes.SnapshotStore,
+	interval int) {
+	sub := esPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer: 50,
+		LabelMatcher: labels.Or(
+			labels.In(fes.PubSubLabelAggregateType, aggregates.TypeWorkflow, aggregates.TypeWorkflowInvocation,
+				aggregates.TypeSchedule),
+			labels.In("parent.type", aggregates.TypeWorkflowInvocation)),
+	})
+	snapshotter := fes.NewSnapshotter(es, store, interval, map[string]func(id string) fes.Snapshottable{
+		aggregates.TypeWorkflow: func(id string) fes.Snapshottable {
+			return aggregates.NewWorkflow(id)
+		},
+		aggregates.TypeWorkflowInvocation: func(id string) fes.Snapshottable {
+			return aggregates.NewWorkflowInvocation(id)
+		},
+		aggregates.TypeSchedule: func(id string) fes.Snapshottable {
+			return aggregates.NewSchedule(id)
+		},
+	})
+	go snapshotter.Run(ctx, sub)
+}
+
+// setupMembership joins the cluster of the bundles that share the event store.
+//
+// The leases of the bundles do not fence the controllers: while the shards are rebalanced, two bundles can briefly
+// both evaluate the same invocation. The controllers append their events conditionally on the version of the entity
+// that they evaluated, so the event store rejects the events of the bundle that acted on an outdated version. The
+// cluster therefore requires an event store that supports conditional appends.
+func setupMembership(ctx context.Context, es fes.Backend, esPub pubsub.Publisher, natsEs *nats.EventStore,
+	opts ClusterOptions) *cluster.Membership {
+	if _, ok := es.(fes.ConditionalAppender); !ok {
+		panic(fmt.Sprintf("cannot join the cluster: event store %T does not support conditional appends", es))
+	}
+	var broadcaster *nats.Broadcaster
+	if natsEs != nil {
+		// NATS Streaming cannot remove the previous heartbeats, so the heartbeats are broadcasted over plain NATS
+		// instead of persisted in the event store.
+		broadcaster = natsEs.Broadcaster()
+		es = broadcaster
+		esPub = broadcaster
+	}
+	membership := cluster.NewMembership(es, cluster.Config{
+		ReplicaID:     opts.ReplicaID,
+		LeaseDuration: opts.LeaseDuration,
+	})
+	sub := esPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       50,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, cluster.TypeReplica),
+	})
+	err := membership.Load()
+	if err != nil {
+		log.Errorf("Failed to load the replicas from the event store: %v", err)
+	}
+	if broadcaster != nil {
+		err = broadcaster.Watch(cluster.TypeReplica)
+		if err != nil {
+			panic(err)
+		}
+		go func() {
+			<-ctx.Done()
+			err := broadcaster.Close()
+			if err != nil {
+				log.Errorf("Failed to stop watching the replicas: %v", err)
+			}
+		}()
+	}
+	go membership.Run(ctx, sub)
+	return membership
+}
+
+func setupRetention(ctx context.Context, es fes.Backend, esPub pubsub.Publisher, opts RetentionOptions,
+	esPersistent bool, wfiCache fes.CacheWriter, idempotencyWindow time.Duration) {
+	var archiver fes.Archiver
+	if len(opts.ArchiveDir) > 0 {
+		fileArchiver, err := fes.NewFileArchiver(opts.ArchiveDir)
+		if err != nil {
+			panic(err)
+		}
+		archiver = fileArchiver
+	}
+	failedAge := opts.FailedAge
+	if failedAge == 0 {
+		failedAge = opts.Age
+	}
+	retention := fes.NewRetention(es, archiver, []fes.RetentionPolicy{
+		{
+			AggregateType: aggregates.TypeWorkflowInvocation,
+			EventType:     events.TypeOf(&events.InvocationFailed{}),
+			MaxAge:        failedAge,
+		},
+		{
+			AggregateType: aggregates.TypeWorkflowInvocation,
+			EventType:     events.TypeOf(&events.InvocationCanceled{}),
+			MaxAge:        failedAge,
+		},
+		{
+			AggregateType: aggregates.TypeWorkflowInvocation,
+			MaxAge:        opts.Age,
+		},
+		{
+			// Claims on idempotency keys are of no use after the idempotency window.
+			AggregateType: api.TypeIdempotencyKey,
+			MaxAge:        idempotencyWindow,
+		},
+	}, wfiCache)
+	sub := esPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer: 50,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, aggregates.TypeWorkflowInvocation,
+			api.TypeIdempotencyKey),
+	})
+	if esPersistent {
+		err := retention.Load()
+		if err != nil {
+			log.Errorf("Failed to load the finished invocations from the event store: %v", err)
+		}
+	}
+	go retention.Run(ctx, sub, fes.DefaultRetentionInterval)
+}
+
+func setupWorkflowInvocationCache(ctx context.Context, es fes.Backend, invocationEventPub pubsub.Publisher,
+	cacheOpts *CacheOptions) *fes.SubscribedCache {
 	invokeSub := invocationEventPub.Subscribe(pubsub.SubscriptionOptions{
 		Buffer: 50,
 		LabelMatcher: labels.Or(
@@ -350,10 +816,28 @@ func setupWorkflowInvocationCache(ctx context.Context, invocationEventPub pubsub
 		return aggregates.NewWorkflowInvocation("")
 	}
 
-	return fes.NewSubscribedCache(ctx, fes.NewNamedMapCache("invocation"), wi, invokeSub)
+	var cache fes.CacheReaderWriter = fes.NewNamedMapCache("invocation")
+	if cacheOpts != nil {
+		cache = fes.NewLRUCache(es, func(id string) fes.Entity {
+			return aggregates.NewWorkflowInvocation(id)
+		}, fes.LRUCacheConfig{
+			Name:    "invocation",
+			MaxSize: cacheOpts.Size,
+			TTL:     cacheOpts.TTL,
+			Evictable: func(entity fes.Entity) bool {
+				wfi, ok := entity.(*aggregates.WorkflowInvocation)
+				// Failed invocations are reopened when they are retried, so they are kept until they are removed by
+				// the retention.
+				return ok && wfi.GetStatus() != nil && wfi.GetStatus().Finished() &&
+					wfi.GetStatus().GetStatus() != types.WorkflowInvocationStatus_FAILED
+			},
+		})
+	}
+	return fes.NewSubscribedCache(ctx, fes.NewIndexedCache(cache, aggregates.InvocationIndexes), wi, invokeSub)
 }
 
-func setupWorkflowCache(ctx context.Context, workflowEventPub pubsub.Publisher) *fes.SubscribedCache {
+func setupWorkflowCache(ctx context.Context, es fes.Backend, workflowEventPub pubsub.Publisher,
+	cacheOpts *CacheOptions) *fes.SubscribedCache {
 	wfSub := workflowEventPub.Subscribe(pubsub.SubscriptionOptions{
 		Buffer:       10,
 		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, "workflow"),
@@ -361,11 +845,38 @@ func setupWorkflowCache(ctx context.Context, workflowEventPub pubsub.Publisher)
 	wb := func() fes.Entity {
 		return aggregates.NewWorkflow("")
 	}
-	return fes.NewSubscribedCache(ctx, fes.NewNamedMapCache("workflow"), wb, wfSub)
+	var cache fes.CacheReaderWriter = fes.NewNamedMapCache("workflow")
+	if cacheOpts != nil {
+		cache = fes.NewLRUCache(es, func(id string) fes.Entity {
+			return aggregates.NewWorkflow(id)
+		}, fes.LRUCacheConfig{
+			Name:    "workflow",
+			MaxSize: cacheOpts.Size,
+			TTL:     cacheOpts.TTL,
+			Evictable: func(entity fes.Entity) bool {
+				wf, ok := entity.(*aggregates.Workflow)
+				// Apart from deletions, workflows do not change once they have been parsed.
+				return ok && wf.GetStatus() != nil && wf.GetStatus().GetStatus() != types.WorkflowStatus_PENDING
+			},
+		})
+	}
+	return fes.NewSubscribedCache(ctx, cache, wb, wfSub)
+}
+
+func setupScheduleCache(ctx context.Context, scheduleEventPub pubsub.Publisher) *fes.SubscribedCache {
+	scheduleSub := scheduleEventPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       10,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, aggregates.TypeSchedule),
+	})
+	sb := func() fes.Entity {
+		return aggregates.NewSchedule("")
+	}
+	// Schedules are few and long-lived, so the cache is not bounded.
+	return fes.NewSubscribedCache(ctx, fes.NewNamedMapCache(aggregates.TypeSchedule), sb, scheduleSub)
 }
 
-func serveAdminAPI(s *grpc.Server) {
-	adminServer := &apiserver.Admin{}
+func serveAdminAPI(s *grpc.Server, metaCtrl *controller.MetaController) {
+	adminServer := apiserver.NewAdmin(metaCtrl)
 	apiserver.RegisterAdminAPIServer(s, adminServer)
 	log.Infof("Serving admin gRPC API at %s.", gRPCAddress)
 }
@@ -379,15 +890,23 @@ func serveWorkflowAPI(s *grpc.Server, es fes.Backend, resolvers map[string]fnenv
 	log.Infof("Serving workflow gRPC API at %s.", gRPCAddress)
 }
 
-func serveInvocationAPI(s *grpc.Server, es fes.Backend, wfiCache fes.CacheReader) {
-	invocationAPI := api.NewInvocationAPI(es)
-	invocationServer := apiserver.NewInvocation(invocationAPI, wfiCache)
+func serveInvocationAPI(s *grpc.Server, es fes.Backend, invocationAPI *api.Invocation, wfiCache fes.CacheReader,
+	wfCache fes.CacheReader, timeout time.Duration) {
+	runtime := workflows.NewRuntime(invocationAPI, wfiCache, wfCache, timeout)
+	invocationServer := apiserver.NewInvocation(invocationAPI, wfiCache, es, runtime)
 	apiserver.RegisterWorkflowInvocationAPIServer(s, invocationServer)
 	log.Infof("Serving workflow invocation gRPC API at %s.", gRPCAddress)
 }
 
+func serveScheduleAPI(s *grpc.Server, es fes.Backend, scheduleCache fes.CacheReader) {
+	scheduleAPI := api.NewScheduleAPI(es)
+	scheduleServer := apiserver.NewSchedule(scheduleAPI, scheduleCache)
+	apiserver.RegisterScheduleAPIServer(s, scheduleServer)
+	log.Infof("Serving schedule gRPC API at %s.", gRPCAddress)
+}
+
 func serveHTTPGateway(ctx context.Context, mux *grpcruntime.ServeMux, adminAPIAddr string, workflowAPIAddr string,
-	invocationAPIAddr string) {
+	invocationAPIAddr string, scheduleAPIAddr string) {
 	opts := []grpc.DialOption{grpc.WithInsecure()}
 	if adminAPIAddr != "" {
 		err := apiserver.RegisterAdminAPIHandlerFromEndpoint(ctx, mux, adminAPIAddr, opts)
@@ -412,29 +931,46 @@ func serveHTTPGateway(ctx context.Context, mux *grpcruntime.ServeMux, adminAPIAd
 		}
 		log.Info("Registered Workflow Invocation API HTTP Endpoint")
 	}
+
+	if scheduleAPIAddr != "" {
+		err := apiserver.RegisterScheduleAPIHandlerFromEndpoint(ctx, mux, scheduleAPIAddr, opts)
+		if err != nil {
+			panic(err)
+		}
+		log.Info("Registered Schedule API HTTP Endpoint")
+	}
+}
+
+// matchIncomingHeader forwards the idempotency key header to the gRPC API, in addition to the headers that the HTTP
+// gateway forwards by default.
+func matchIncomingHeader(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == apiserver.HeaderIdempotencyKey {
+		return key, true
+	}
+	return grpcruntime.DefaultHeaderMatcher(key)
 }
 
-func runFissionEnvironmentProxy(proxyMux *http.ServeMux, es fes.Backend, wfiCache fes.CacheReader,
-	wfCache fes.CacheReader, resolvers map[string]fnenv.RuntimeResolver) {
+func runFissionEnvironmentProxy(proxyMux *http.ServeMux, es fes.Backend, wfiAPI *api.Invocation,
+	wfiCache fes.CacheReader, wfCache fes.CacheReader, resolvers map[string]fnenv.RuntimeResolver,
+	timeout time.Duration) {
 
 	workflowParser := fnenv.NewMetaResolver(resolvers)
 	workflowAPI := api.NewWorkflowAPI(es, workflowParser)
 	wfServer := apiserver.NewWorkflow(workflowAPI, wfCache)
-	wfiAPI := api.NewInvocationAPI(es)
-	wfiServer := apiserver.NewInvocation(wfiAPI, wfiCache)
+	wfiServer := apiserver.NewInvocation(wfiAPI, wfiCache, es, workflows.NewRuntime(wfiAPI, wfiCache, wfCache, timeout))
 	fissionProxyServer := fission.NewFissionProxyServer(wfiServer, wfServer)
 	fissionProxyServer.RegisterServer(proxyMux)
 }
 
 func setupInvocationController(invocationCache fes.CacheReader, wfCache fes.CacheReader, es fes.Backend,
-	fnRuntimes map[string]fnenv.Runtime, fnResolvers map[string]fnenv.RuntimeResolver) *wfictr.Controller {
+	invocationAPI *api.Invocation, fnRuntimes map[string]fnenv.Runtime, fnResolvers map[string]fnenv.RuntimeResolver,
+	config wfictr.Config) *wfictr.Controller {
 	workflowAPI := api.NewWorkflowAPI(es, fnenv.NewMetaResolver(fnResolvers))
-	invocationAPI := api.NewInvocationAPI(es)
 	dynamicAPI := api.NewDynamicApi(workflowAPI, invocationAPI)
 	taskAPI := api.NewTaskAPI(fnRuntimes, es, dynamicAPI)
 	s := &scheduler.WorkflowScheduler{}
 	stateStore := expr.NewStore()
-	return wfictr.NewController(invocationCache, wfCache, s, taskAPI, invocationAPI, stateStore)
+	return wfictr.NewController(invocationCache, wfCache, s, taskAPI, invocationAPI, stateStore, config)
 }
 
 func setupWorkflowController(wfCache fes.CacheReader, es fes.Backend,
@@ -443,6 +979,88 @@ func setupWorkflowController(wfCache fes.CacheReader, es fes.Backend,
 	return wfctr.NewController(wfCache, workflowAPI)
 }
 
+func setupScheduleController(scheduleCache fes.CacheReader, wfiCache fes.CacheReader, es fes.Backend,
+	invocationAPI *api.Invocation) *schedulectr.Controller {
+	return schedulectr.NewController(scheduleCache, wfiCache, api.NewScheduleAPI(es), invocationAPI,
+		schedulectr.Config{})
+}
+
 func setupMetricsEndpoint(apiMux *http.ServeMux) {
 	apiMux.Handle("/metrics", promhttp.Handler())
 }
+
+// setupExportEndpoint serves the events of the event store in the format of fes.Export, which allows the events of
+// event stores that cannot be opened by another process (such as the in-memory event store) to be exported.
+func setupExportEndpoint(apiMux *http.ServeMux, es fes.Backend) {
+	apiMux.HandleFunc(exportPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		n, err := fes.Export(es, w)
+		if err != nil {
+			// The response has already been (partially) written; the client detects the truncated stream instead.
+			log.Errorf("Failed to export event store after %d events: %v", n, err)
+			return
+		}
+		log.Infof("Exported %d events", n)
+	})
+}
+
+// Export writes all events of the event store configured in the options to w, returning the number of exported events.
+func Export(opts *Options, w io.Writer) (int, error) {
+	es, closeFn, err := openEventStore(opts)
+	if err != nil {
+		return 0, err
+	}
+	defer closeFn()
+	return fes.Export(es, w)
+}
+
+// Import appends all events read from r, as written by Export, to the event store configured in the options. Unless
+// force is set, the import is refused if the event store already contains events, as the imported events would
+// otherwise be interleaved with the existing events.
+func Import(opts *Options, r io.Reader, force bool) (int, error) {
+	es, closeFn, err := openEventStore(opts)
+	if err != nil {
+		return 0, err
+	}
+	defer closeFn()
+	if !force {
+		aggregates, err := es.List(func(s string) bool { return true })
+		if err != nil {
+			return 0, err
+		}
+		if len(aggregates) > 0 {
+			return 0, fmt.Errorf("event store is not empty (%d aggregates)", len(aggregates))
+		}
+	}
+	return fes.Import(es, r)
+}
+
+// openEventStore opens the persistent event store configured in the options without watching or serving it.
+func openEventStore(opts *Options) (es fes.Backend, closeFn func() error, err error) {
+	switch {
+	case opts.Nats != nil:
+		natsEs, err := nats.Connect(*opts.Nats)
+		if err != nil {
+			return nil, nil, err
+		}
+		return natsEs, natsEs.Close, nil
+	case opts.Bolt != nil:
+		boltEs, err := bolt.Open(*opts.Bolt)
+		if err != nil {
+			return nil, nil, err
+		}
+		return boltEs, boltEs.Close, nil
+	case opts.SQL != nil:
+		sqlEs, err := fessql.Open(*opts.SQL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sqlEs, sqlEs.Close, nil
+	default:
+		return nil, nil, errors.New("no persistent event store provided")
+	}
+}
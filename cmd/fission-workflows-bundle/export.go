@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// The export and import commands operate on the event store configured by the global flags, which allows events to
+// be migrated between event stores, for example:
+//
+//	fission-workflows-bundle --bolt export -o events.gz
+//	fission-workflows-bundle --nats import events.gz
+var cmdExport = cli.Command{
+	Name:  "export",
+	Usage: "Export all events of the event store to a file",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "o, output",
+			Usage: "File to write the events to (- for stdout).",
+			Value: "-",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		setupLogging(c.Parent())
+		var w io.Writer = os.Stdout
+		if path := c.String("output"); path != "-" {
+			f, err := os.Create(path)
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+			defer f.Close()
+			w = f
+		}
+		n, err := bundle.Export(parseEventStoreOptions(c.Parent()), w)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		logrus.Infof("Exported %d events.", n)
+		return nil
+	},
+}
+
+var cmdImport = cli.Command{
+	Name:      "import",
+	Usage:     "Import the events of an export into the event store",
+	ArgsUsage: "<file> (- for stdin)",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "Import the events even if the event store already contains events.",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		setupLogging(c.Parent())
+		if !c.Args().Present() {
+			return cli.NewExitError("no file to import provided", 1)
+		}
+		var r io.Reader = os.Stdin
+		if path := c.Args().First(); path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+			defer f.Close()
+			r = f
+		}
+		n, err := bundle.Import(parseEventStoreOptions(c.Parent()), r, c.Bool("force"))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		logrus.Infof("Imported %d events.", n)
+		return nil
+	},
+}
+
+func parseEventStoreOptions(c *cli.Context) *bundle.Options {
+	return &bundle.Options{
+		Nats: parseNatsOptions(c),
+		Bolt: parseBoltOptions(c),
+		SQL:  parseSQLOptions(c),
+	}
+}
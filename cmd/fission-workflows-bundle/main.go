@@ -9,8 +9,16 @@ import (
 	"time"
 
 	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/cluster"
+	"github.com/fission/fission-workflows/pkg/controller/invocation"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/bolt"
 	"github.com/fission/fission-workflows/pkg/fes/backend/nats"
+	fessql "github.com/fission/fission-workflows/pkg/fes/backend/sql"
 	"github.com/fission/fission-workflows/pkg/util"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	natsio "github.com/nats-io/go-nats"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
@@ -34,20 +42,38 @@ func main() {
 	}()
 
 	cliApp := createCli()
+	cliApp.Commands = []cli.Command{
+		cmdExport,
+		cmdImport,
+	}
 	cliApp.Action = func(c *cli.Context) error {
 		setupLogging(c)
 
 		return bundle.Run(ctx, &bundle.Options{
 			Nats:                 parseNatsOptions(c),
+			Bolt:                 parseBoltOptions(c),
+			SQL:                  parseSQLOptions(c),
+			SnapshotInterval:     c.Int("snapshot-interval"),
+			Cache:                parseCacheOptions(c),
+			Retention:            parseRetentionOptions(c),
+			IdempotencyWindow:    c.Duration("idempotency-window"),
+			Cluster:              parseClusterOptions(c),
 			Fission:              parseFissionOptions(c),
 			InternalRuntime:      c.Bool("internal"),
 			InvocationController: c.Bool("controller") || c.Bool("invocation-controller"),
 			WorkflowController:   c.Bool("controller") || c.Bool("workflow-controller"),
+			ScheduleController:   c.Bool("controller") || c.Bool("schedule-controller"),
 			AdminAPI:             c.Bool("api") || c.Bool("api-admin"),
 			WorkflowAPI:          c.Bool("api") || c.Bool("api-workflow"),
 			InvocationAPI:        c.Bool("api") || c.Bool("api-workflow-invocation"),
+			ScheduleAPI:          c.Bool("api") || c.Bool("api-schedule"),
 			HTTPGateway:          c.Bool("api") || c.Bool("api-http"),
 			Metrics:              c.Bool("metrics") || c.Bool("metrics"),
+			InvocationControllerConfig: invocation.Config{
+				Workers:     c.Int("invocation-controller-workers"),
+				Timeout:     c.Duration("invocation-timeout"),
+				TaskWorkers: c.Int("invocation-controller-task-workers"),
+			},
 		})
 	}
 	cliApp.Run(os.Args)
@@ -84,9 +110,70 @@ func parseNatsOptions(c *cli.Context) *nats.Config {
 	}
 
 	return &nats.Config{
-		URL:     c.String("nats-url"),
-		Cluster: c.String("nats-cluster"),
-		Client:  client,
+		URL:            c.String("nats-url"),
+		Cluster:        c.String("nats-cluster"),
+		Client:         client,
+		CheckpointPath: c.String("nats-checkpoint-path"),
+	}
+}
+
+func parseBoltOptions(c *cli.Context) *bolt.Config {
+	if !c.Bool("bolt") {
+		return nil
+	}
+
+	return &bolt.Config{
+		Path: c.String("bolt-path"),
+	}
+}
+
+func parseSQLOptions(c *cli.Context) *fessql.Config {
+	if !c.Bool("sql") {
+		return nil
+	}
+
+	return &fessql.Config{
+		Driver:       c.String("sql-driver"),
+		DSN:          c.String("sql-dsn"),
+		PollInterval: c.Duration("sql-poll-interval"),
+	}
+}
+
+func parseCacheOptions(c *cli.Context) *bundle.CacheOptions {
+	if c.Int("cache-size") <= 0 {
+		return nil
+	}
+
+	return &bundle.CacheOptions{
+		Size: c.Int("cache-size"),
+		TTL:  c.Duration("cache-ttl"),
+	}
+}
+
+func parseRetentionOptions(c *cli.Context) *bundle.RetentionOptions {
+	if !c.Bool("retention") {
+		return nil
+	}
+
+	return &bundle.RetentionOptions{
+		Age:        c.Duration("retention-age"),
+		FailedAge:  c.Duration("retention-failed-age"),
+		ArchiveDir: c.String("retention-archive-dir"),
+	}
+}
+
+func parseClusterOptions(c *cli.Context) *bundle.ClusterOptions {
+	if !c.Bool("cluster") {
+		return nil
+	}
+
+	replicaID := c.String("cluster-replica")
+	if len(replicaID) == 0 {
+		replicaID = util.UID()
+	}
+	return &bundle.ClusterOptions{
+		ReplicaID:     replicaID,
+		LeaseDuration: c.Duration("cluster-lease"),
 	}
 }
 
@@ -119,11 +206,122 @@ func createCli() *cli.App {
 			Usage:  "Client name used for the NATS event store. By default it will generate a unique clientID.",
 			EnvVar: "ES_NATS_CLIENT",
 		},
+		cli.StringFlag{
+			Name: "nats-checkpoint-path",
+			Usage: "Path to the file in which the last processed sequence of each NATS subject is stored. " +
+				"If set, the event store resumes from these checkpoints after a restart.",
+			EnvVar: "ES_NATS_CHECKPOINT_PATH",
+		},
 		cli.BoolFlag{
 			Name:  "nats",
 			Usage: "Use NATS as the event store",
 		},
 
+		// BoltDB
+		cli.StringFlag{
+			Name:   "bolt-path",
+			Usage:  "Path to the database file used by the BoltDB event store.",
+			Value:  "workflows.db",
+			EnvVar: "ES_BOLT_PATH",
+		},
+		cli.BoolFlag{
+			Name:  "bolt",
+			Usage: "Use BoltDB as the (single-node, file-based) event store",
+		},
+
+		// SQL
+		cli.StringFlag{
+			Name:   "sql-driver",
+			Usage:  "Database driver used by the SQL event store (sqlite3 or postgres).",
+			Value:  fessql.DriverSQLite,
+			EnvVar: "ES_SQL_DRIVER",
+		},
+		cli.StringFlag{
+			Name:   "sql-dsn",
+			Usage:  "Data source name of the database used by the SQL event store.",
+			Value:  "workflows.sqlite",
+			EnvVar: "ES_SQL_DSN",
+		},
+		cli.DurationFlag{
+			Name:   "sql-poll-interval",
+			Usage:  "Interval at which the SQL event store checks for events appended by other clients.",
+			Value:  time.Second,
+			EnvVar: "ES_SQL_POLL_INTERVAL",
+		},
+		cli.BoolFlag{
+			Name:  "sql",
+			Usage: "Use a SQL database as the event store",
+		},
+
+		cli.IntFlag{
+			Name:   "snapshot-interval",
+			Usage:  "Number of events after which a snapshot of an aggregate is stored (supported by the BoltDB and SQL event stores, not by NATS). Set to 0 to disable snapshots.",
+			Value:  fes.DefaultSnapshotInterval,
+			EnvVar: "ES_SNAPSHOT_INTERVAL",
+		},
+
+		// Caches
+		cli.IntFlag{
+			Name:   "cache-size",
+			Usage:  "Maximum number of workflows and invocations kept in the caches. By default (0), the caches are unbounded and keep all entities cached.",
+			EnvVar: "WORKFLOW_CACHE_SIZE",
+		},
+		cli.DurationFlag{
+			Name:   "cache-ttl",
+			Usage:  "Duration after which parsed workflows and succeeded or canceled invocations that have not been accessed are evicted from the caches. Only applies if cache-size is set.",
+			Value:  fes.DefaultCacheTTL,
+			EnvVar: "WORKFLOW_CACHE_TTL",
+		},
+
+		// Retention
+		cli.BoolFlag{
+			Name:   "retention",
+			Usage:  "Archive and remove finished invocations from the event store and caches. Not supported by the NATS event store, which cannot remove events; the bundle fails to start if both are enabled.",
+			EnvVar: "WORKFLOW_RETENTION",
+		},
+		cli.DurationFlag{
+			Name:   "retention-age",
+			Usage:  "Duration after which finished invocations are archived.",
+			Value:  24 * time.Hour,
+			EnvVar: "WORKFLOW_RETENTION_AGE",
+		},
+		cli.DurationFlag{
+			Name:   "retention-failed-age",
+			Usage:  "Duration after which failed and aborted invocations are archived (defaults to the retention-age).",
+			EnvVar: "WORKFLOW_RETENTION_FAILED_AGE",
+		},
+		cli.StringFlag{
+			Name:   "retention-archive-dir",
+			Usage:  "Directory to which the events of archived invocations are written. If not set, the events are discarded.",
+			EnvVar: "WORKFLOW_RETENTION_ARCHIVE_DIR",
+		},
+
+		// Idempotency
+		cli.DurationFlag{
+			Name:   "idempotency-window",
+			Usage:  "Duration during which invoking a workflow with the idempotency key of an earlier invocation returns that invocation. After the window, the key creates a new invocation. With retention enabled, the keys are removed after the window.",
+			Value:  api.DefaultIdempotencyWindow,
+			EnvVar: "WORKFLOW_IDEMPOTENCY_WINDOW",
+		},
+
+		// Cluster
+		cli.BoolFlag{
+			Name:   "cluster",
+			Usage:  "Divide the workflows and invocations over the controllers of the bundles that share the event store.",
+			EnvVar: "WORKFLOW_CLUSTER",
+		},
+		cli.StringFlag{
+			Name:   "cluster-replica",
+			Usage:  "Unique id of this bundle in the cluster (defaults to a random id).",
+			EnvVar: "WORKFLOW_CLUSTER_REPLICA",
+		},
+		cli.DurationFlag{
+			Name:   "cluster-lease",
+			Usage:  "Duration after which a bundle that stopped sending heartbeats is considered to have disappeared.",
+			Value:  cluster.DefaultLeaseDuration,
+			EnvVar: "WORKFLOW_CLUSTER_LEASE",
+		},
+
 		// Fission
 		cli.BoolFlag{
 			Name:  "fission",
@@ -165,6 +363,28 @@ func createCli() *cli.App {
 			Name:  "invocation-controller",
 			Usage: "Run the invocation controller",
 		},
+		cli.BoolFlag{
+			Name:  "schedule-controller",
+			Usage: "Run the schedule controller, which invokes the workflows of schedules",
+		},
+		cli.IntFlag{
+			Name:   "invocation-controller-workers",
+			Usage:  "Maximum number of invocations that the invocation controller evaluates concurrently.",
+			Value:  invocation.DefaultConfig.Workers,
+			EnvVar: "WORKFLOW_CONTROLLER_WORKERS",
+		},
+		cli.IntFlag{
+			Name:   "invocation-controller-task-workers",
+			Usage:  "Maximum number of task functions that the invocation controller executes concurrently.",
+			Value:  invocation.DefaultConfig.TaskWorkers,
+			EnvVar: "WORKFLOW_CONTROLLER_TASK_WORKERS",
+		},
+		cli.DurationFlag{
+			Name:   "invocation-timeout",
+			Usage:  "Timeout of invocations of which neither the workflow nor the invocation specify a timeout.",
+			Value:  invocation.DefaultConfig.Timeout,
+			EnvVar: "WORKFLOW_INVOCATION_TIMEOUT",
+		},
 		cli.BoolFlag{
 			Name:  "api-http",
 			Usage: "Serve the http apis of the apis",
@@ -177,6 +397,10 @@ func createCli() *cli.App {
 			Name:  "api-workflow",
 			Usage: "Serve the workflow gRPC api",
 		},
+		cli.BoolFlag{
+			Name:  "api-schedule",
+			Usage: "Serve the schedule gRPC api",
+		},
 		cli.BoolFlag{
 			Name:  "api-admin",
 			Usage: "Serve the admin gRPC api",
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var cmdExport = cli.Command{
+	Name:  "export",
+	Usage: "Export all events of the event store, which can be imported with 'fission-workflows-bundle import'",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "o, output",
+			Usage: "File to write the events to (- for stdout).",
+			Value: "-",
+		},
+	},
+	Action: commandContext(func(ctx Context) error {
+		client := getClient(ctx)
+		var w io.Writer = os.Stdout
+		if path := ctx.String("output"); path != "-" {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		err := client.Admin.Export(ctx, w)
+		if err != nil {
+			return err
+		}
+		logrus.Debug("Exported event store.")
+		return nil
+	}),
+}
@@ -6,8 +6,10 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"time"
 
+	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/apiserver/httpclient"
 	"github.com/fission/fission-workflows/pkg/parse/yaml"
 	"github.com/fission/fission-workflows/pkg/types"
@@ -29,6 +31,11 @@ var cmdInvocation = cli.Command{
 					Usage: "Amount history (non-active invocations) to show.",
 					Value: time.Duration(1) * time.Hour,
 				},
+				cli.StringFlag{
+					Name: "at",
+					Usage: "Show the invocation as it was at a point in its history, either a timestamp (RFC 3339) " +
+						"or the number of events.",
+				},
 			},
 			Action: commandContext(func(ctx Context) error {
 				client := getClient(ctx)
@@ -39,7 +46,7 @@ var cmdInvocation = cli.Command{
 				case 1:
 					// Get Workflow Invocation
 					wfiID := ctx.Args().Get(0)
-					wfi, err := client.Invocation.Get(ctx, wfiID)
+					wfi, err := getInvocation(ctx, client.Invocation, wfiID, ctx.String("at"))
 					if err != nil {
 						panic(err)
 					}
@@ -53,7 +60,7 @@ var cmdInvocation = cli.Command{
 				default:
 					wfiID := ctx.Args().Get(0)
 					taskID := ctx.Args().Get(1)
-					wfi, err := client.Invocation.Get(ctx, wfiID)
+					wfi, err := getInvocation(ctx, client.Invocation, wfiID, ctx.String("at"))
 					if err != nil {
 						panic(err)
 					}
@@ -85,6 +92,45 @@ var cmdInvocation = cli.Command{
 				return nil
 			}),
 		},
+		{
+			Name:  "pause",
+			Usage: "pause <Workflow-Invocation-id>",
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				wfiID := ctx.Args().Get(0)
+				err := client.Invocation.Pause(ctx, wfiID)
+				if err != nil {
+					panic(err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "resume",
+			Usage: "resume <Workflow-Invocation-id>",
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				wfiID := ctx.Args().Get(0)
+				err := client.Invocation.Resume(ctx, wfiID)
+				if err != nil {
+					panic(err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "retry",
+			Usage: "retry <Workflow-Invocation-id>",
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				wfiID := ctx.Args().Get(0)
+				err := client.Invocation.Retry(ctx, wfiID)
+				if err != nil {
+					panic(err)
+				}
+				return nil
+			}),
+		},
 		{
 			// TODO support input
 			Name:  "invoke",
@@ -98,13 +144,39 @@ var cmdInvocation = cli.Command{
 					Name:  "sync, s",
 					Usage: "Invoke synchronously",
 				},
+				cli.DurationFlag{
+					Name:  "delay",
+					Usage: "Defer the start of the invocation for the duration",
+				},
+				cli.StringFlag{
+					Name:  "start-at",
+					Usage: "Defer the start of the invocation until the time (RFC3339)",
+				},
+				cli.StringFlag{
+					Name:  "idempotency-key",
+					Usage: "Return the existing invocation if one was created with the same key",
+				},
 			},
 			Action: commandContext(func(ctx Context) error {
 				client := getClient(ctx)
 				wfID := ctx.Args().Get(0)
 				spec := &types.WorkflowInvocationSpec{
-					WorkflowId: wfID,
-					Inputs:     map[string]*types.TypedValue{},
+					WorkflowId:     wfID,
+					Inputs:         map[string]*types.TypedValue{},
+					IdempotencyKey: ctx.String("idempotency-key"),
+				}
+				if ctx.IsSet("delay") {
+					spec.Delay = ptypes.DurationProto(ctx.Duration("delay"))
+				}
+				if startAt := ctx.String("start-at"); len(startAt) > 0 {
+					t, err := time.Parse(time.RFC3339, startAt)
+					if err != nil {
+						fail(fmt.Sprintf("Invalid start time '%s': %v", startAt, err))
+					}
+					spec.StartAt, err = ptypes.TimestampProto(t)
+					if err != nil {
+						panic(err)
+					}
 				}
 				if ctx.Bool("sync") {
 					resp, err := client.Invocation.InvokeSync(ctx, spec)
@@ -173,6 +245,28 @@ var cmdInvocation = cli.Command{
 	},
 }
 
+// getInvocation fetches the invocation, or if at is provided, the invocation as it was at that point in its history.
+func getInvocation(ctx context.Context, wfiAPI *httpclient.InvocationAPI, wfiID string,
+	at string) (*types.WorkflowInvocation, error) {
+	if len(at) == 0 {
+		return wfiAPI.Get(ctx, wfiID)
+	}
+	query := &apiserver.InvocationGetAtQuery{Id: wfiID}
+	if seq, err := strconv.ParseUint(at, 10, 64); err == nil {
+		query.Seq = seq
+	} else {
+		ts, err := time.Parse(time.RFC3339Nano, at)
+		if err != nil {
+			return nil, fmt.Errorf("invalid point in history '%s': expected a timestamp or number of events", at)
+		}
+		query.Timestamp, err = ptypes.TimestampProto(ts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return wfiAPI.GetAt(ctx, query)
+}
+
 func invocationsList(out io.Writer, wfiAPI *httpclient.InvocationAPI, since time.Time) {
 	// List workflows invocations
 	ctx := context.TODO()
@@ -47,6 +47,7 @@ func main() {
 		cmdParse,
 		cmdWorkflow,
 		cmdInvocation,
+		cmdSchedule,
 		cmdValidate,
 		cmdAdmin,
 		cmdVersion,
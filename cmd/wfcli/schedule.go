@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fission/fission-workflows/pkg/parse/yaml"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/urfave/cli"
+)
+
+var scheduleSpecFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "cron, c",
+		Usage: "Cron expression of the runs, such as '*/5 * * * *' or '@hourly'",
+	},
+	cli.StringFlag{
+		Name:  "timezone, tz",
+		Usage: "IANA timezone in which the cron expression is interpreted (default: UTC)",
+	},
+	cli.StringFlag{
+		Name:  "concurrency",
+		Usage: "What to do if the previous invocation has not finished: ALLOW, FORBID or REPLACE",
+		Value: types.ScheduleSpec_ALLOW.String(),
+	},
+	cli.StringSliceFlag{
+		Name:  "input, i",
+		Usage: "Input of the invocations as key=value",
+	},
+}
+
+var cmdSchedule = cli.Command{
+	Name:    "schedule",
+	Aliases: []string{"sc", "schedules"},
+	Usage:   "Schedule-related commands",
+	Subcommands: []cli.Command{
+		{
+			Name:  "get",
+			Usage: "get <Schedule-id>",
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				if ctx.NArg() == 0 {
+					// List schedules
+					resp, err := client.Schedule.List(ctx)
+					if err != nil {
+						panic(err)
+					}
+					scs := resp.Schedules
+					sort.Strings(scs)
+					var rows [][]string
+					for _, scID := range scs {
+						sc, err := client.Schedule.Get(ctx, scID)
+						if err != nil {
+							panic(err)
+						}
+						rows = append(rows, []string{scID, sc.Spec.WorkflowId, sc.Spec.Cron,
+							sc.Status.Status.String(), sc.Status.LastInvocationId,
+							strconv.FormatInt(sc.Status.MissedRuns, 10)})
+					}
+					table(os.Stdout, []string{"ID", "WORKFLOW", "CRON", "STATUS", "LAST INVOCATION", "MISSED"}, rows)
+					return nil
+				}
+
+				// Get schedule
+				sc, err := client.Schedule.Get(ctx, ctx.Args().Get(0))
+				if err != nil {
+					panic(err)
+				}
+				b, err := yaml.Marshal(sc)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Printf("%v\n", string(b))
+				return nil
+			}),
+		},
+		{
+			Name:  "create",
+			Usage: "create <Workflow-id>",
+			Flags: scheduleSpecFlags,
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				spec, err := parseScheduleSpec(ctx, ctx.Args().Get(0))
+				if err != nil {
+					fail(err)
+				}
+				resp, err := client.Schedule.Create(ctx, spec)
+				if err != nil {
+					panic(err)
+				}
+				fmt.Println(resp.Id)
+				return nil
+			}),
+		},
+		{
+			Name:  "update",
+			Usage: "update <Schedule-id> <Workflow-id>",
+			Flags: scheduleSpecFlags,
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				spec, err := parseScheduleSpec(ctx, ctx.Args().Get(1))
+				if err != nil {
+					fail(err)
+				}
+				err = client.Schedule.Update(ctx, ctx.Args().Get(0), spec)
+				if err != nil {
+					panic(err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete <Schedule-id>",
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				err := client.Schedule.Delete(ctx, ctx.Args().Get(0))
+				if err != nil {
+					panic(err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "status",
+			Usage: "status <Schedule-id>",
+			Action: commandContext(func(ctx Context) error {
+				if ctx.NArg() < 1 {
+					fmt.Println("Need Schedule id")
+					return nil
+				}
+				client := getClient(ctx)
+				sc, err := client.Schedule.Get(ctx, ctx.Args().Get(0))
+				if err != nil {
+					panic(err)
+				}
+				rows := [][]string{
+					{"ID", sc.Metadata.Id},
+					{"WORKFLOW_ID", sc.Spec.WorkflowId},
+					{"CRON", sc.Spec.Cron},
+					{"STATUS", sc.Status.Status.String()},
+					{"SCHEDULED_UNTIL", ptypes.TimestampString(sc.Status.ScheduledUntil)},
+					{"LAST_INVOCATION", sc.Status.LastInvocationId},
+					{"MISSED_RUNS", strconv.FormatInt(sc.Status.MissedRuns, 10)},
+				}
+				if sc.Status.LastMissedAt != nil {
+					rows = append(rows, []string{"LAST_MISSED", ptypes.TimestampString(sc.Status.LastMissedAt)},
+						[]string{"LAST_MISSED_REASON", sc.Status.LastMissedReason})
+				}
+				table(os.Stdout, nil, rows)
+				return nil
+			}),
+		},
+	},
+}
+
+func parseScheduleSpec(ctx Context, wfID string) (*types.ScheduleSpec, error) {
+	policy, ok := types.ScheduleSpec_ConcurrencyPolicy_value[strings.ToUpper(ctx.String("concurrency"))]
+	if !ok {
+		return nil, fmt.Errorf("unknown concurrency policy: %v", ctx.String("concurrency"))
+	}
+	spec := &types.ScheduleSpec{
+		WorkflowId:        wfID,
+		Cron:              ctx.String("cron"),
+		Timezone:          ctx.String("timezone"),
+		ConcurrencyPolicy: types.ScheduleSpec_ConcurrencyPolicy(policy),
+		Inputs:            map[string]*types.TypedValue{},
+	}
+	for _, input := range ctx.StringSlice("input") {
+		parts := strings.SplitN(input, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("input '%s' should be formatted as key=value", input)
+		}
+		tv, err := typedvalues.Parse(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		spec.Inputs[parts[0]] = tv
+	}
+	return spec, nil
+}
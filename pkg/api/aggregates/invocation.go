@@ -2,18 +2,66 @@ package aggregates
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
 	TypeWorkflowInvocation = "invocation"
+
+	// Secondary indexes of the workflow invocations
+	IndexInvocationWorkflow  = "workflow"
+	IndexInvocationStatus    = "status"
+	IndexInvocationCreatedAt = "createdAt"
+	IndexInvocationParent    = "parent"
 )
 
+// InvocationIndexes contains the index functions of the secondary indexes of workflow invocations.
+var InvocationIndexes = map[string]fes.IndexFunc{
+	IndexInvocationWorkflow: indexInvocation(func(wfi *types.WorkflowInvocation) string {
+		return wfi.GetSpec().GetWorkflowId()
+	}),
+	IndexInvocationStatus: indexInvocation(func(wfi *types.WorkflowInvocation) string {
+		return wfi.GetStatus().GetStatus().String()
+	}),
+	IndexInvocationCreatedAt: indexInvocation(func(wfi *types.WorkflowInvocation) string {
+		createdAt, err := ptypes.Timestamp(wfi.GetMetadata().GetCreatedAt())
+		if err != nil {
+			return ""
+		}
+		return FormatIndexTime(createdAt)
+	}),
+	IndexInvocationParent: indexInvocation(func(wfi *types.WorkflowInvocation) string {
+		return wfi.GetSpec().GetParentId()
+	}),
+}
+
+// FormatIndexTime formats the time as an index key, which preserves the chronological order of times when sorted.
+func FormatIndexTime(t time.Time) string {
+	return fmt.Sprintf("%020d", t.UnixNano())
+}
+
+func indexInvocation(keyFn func(wfi *types.WorkflowInvocation) string) fes.IndexFunc {
+	return func(entity fes.Entity) []string {
+		wfi, ok := entity.(*WorkflowInvocation)
+		if !ok || wfi.WorkflowInvocation == nil {
+			return nil
+		}
+		key := keyFn(wfi.WorkflowInvocation)
+		if len(key) == 0 {
+			return nil
+		}
+		return []string{key}
+	}
+}
+
 type WorkflowInvocation struct {
 	*fes.BaseEntity
 	*types.WorkflowInvocation
@@ -63,6 +111,10 @@ func (wi *WorkflowInvocation) ApplyEvent(event *fes.Event) error {
 				DynamicTasks: map[string]*types.Task{},
 			},
 		}
+		// An invocation with a deferred start remains scheduled until the controller starts it.
+		if wi.Deferred() {
+			wi.Status.Status = types.WorkflowInvocationStatus_SCHEDULED
+		}
 	case *events.InvocationCanceled:
 		wi.Status.Status = types.WorkflowInvocationStatus_ABORTED
 		wi.Status.UpdatedAt = event.GetTimestamp()
@@ -89,6 +141,47 @@ func (wi *WorkflowInvocation) ApplyEvent(event *fes.Event) error {
 	case *events.InvocationFailed:
 		wi.Status.Error = m.GetError()
 		wi.Status.Status = types.WorkflowInvocationStatus_FAILED
+	case *events.InvocationPaused:
+		wi.Status.Status = types.WorkflowInvocationStatus_PAUSED
+		wi.Status.UpdatedAt = event.GetTimestamp()
+		wi.Status.PausedAt = event.GetTimestamp()
+	case *events.InvocationResumed:
+		wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+		wi.Status.UpdatedAt = event.GetTimestamp()
+		// The timeout of the invocation is extended by the duration of the pause.
+		resumedAt, err := ptypes.Timestamp(event.GetTimestamp())
+		if err != nil {
+			return err
+		}
+		pausedFor, err := wi.GetStatus().PausedDuration(resumedAt)
+		if err != nil {
+			return err
+		}
+		wi.Status.PausedFor = ptypes.DurationProto(pausedFor)
+		wi.Status.PausedAt = nil
+	case *events.InvocationStarted:
+		wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+		wi.Status.UpdatedAt = event.GetTimestamp()
+	case *events.InvocationRetried:
+		wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+		wi.Status.UpdatedAt = event.GetTimestamp()
+		wi.Status.RetriedAt = event.GetTimestamp()
+		wi.Status.Error = nil
+		// The timeout applies from the retry, so earlier pauses no longer extend it.
+		wi.Status.PausedAt = nil
+		wi.Status.PausedFor = nil
+		// Reset the unsuccessful tasks, so that they are scheduled again. Retry fails the attempts of the tasks that are
+		// still in progress beforehand, but tasks in progress are reset as well for invocations retried without doing so.
+		// The attempts are preserved, as they determine the version of the task.
+		for _, task := range wi.Status.Tasks {
+			switch task.GetStatus().GetStatus() {
+			case types.TaskInvocationStatus_FAILED, types.TaskInvocationStatus_ABORTED,
+				types.TaskInvocationStatus_IN_PROGRESS:
+				task.Status.Status = types.TaskInvocationStatus_UNKNOWN
+				task.Status.Error = nil
+				task.Status.UpdatedAt = event.GetTimestamp()
+			}
+		}
 	default:
 		log.WithFields(log.Fields{
 			"aggregate": wi.Aggregate(),
@@ -128,6 +221,20 @@ func (wi *WorkflowInvocation) GenericCopy() fes.Entity {
 	return n
 }
 
+func (wi *WorkflowInvocation) SnapshotState() proto.Message {
+	return wi.Copy()
+}
+
+func (wi *WorkflowInvocation) RestoreSnapshotState(state proto.Message) error {
+	wfi, ok := state.(*types.WorkflowInvocation)
+	if !ok {
+		return fmt.Errorf("invalid snapshot state for invocation: %T", state)
+	}
+	wi.WorkflowInvocation = wfi
+	wi.BaseEntity = fes.NewBaseEntity(wi, *NewWorkflowInvocationAggregate(wfi.ID()))
+	return nil
+}
+
 func (wi *WorkflowInvocation) Copy() *types.WorkflowInvocation {
 	return proto.Clone(wi.WorkflowInvocation).(*types.WorkflowInvocation)
 }
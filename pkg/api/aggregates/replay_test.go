@@ -0,0 +1,72 @@
+package aggregates
+
+import (
+	"bufio"
+	"os"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+)
+
+// The fixtures in testdata contain the events, one JSON-encoded event per line, as written by earlier versions of the
+// event schema. Replaying them guards against changes to the events (or the types contained in them) that would break
+// the replay of existing event logs without an upcaster.
+
+func readFixture(t *testing.T, path string) []*fes.Event {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var events []*fes.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		event := &fes.Event{}
+		err := jsonpb.UnmarshalString(scanner.Text(), event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestReplay_WorkflowV0(t *testing.T) {
+	events := readFixture(t, "testdata/workflow.v0.jsonl")
+	wf := NewWorkflow("wf-fixture")
+	err := fes.Project(wf, events...)
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, len(events), wf.Version())
+	assert.Equal(t, types.WorkflowStatus_READY, wf.GetStatus().GetStatus())
+	assert.Equal(t, "second", wf.GetSpec().GetOutputTask())
+	assert.Len(t, wf.GetStatus().GetTasks(), 2)
+	assert.Equal(t, "noop", wf.GetStatus().GetTasks()["first"].GetFnRef().GetID())
+}
+
+func TestReplay_InvocationV0(t *testing.T) {
+	events := readFixture(t, "testdata/invocation.v0.jsonl")
+	wfi := NewWorkflowInvocation("wi-fixture")
+	err := fes.Project(wfi, events...)
+	assert.NoError(t, err)
+
+	// Only the events of the invocation itself, not those of its tasks, count towards its version.
+	assert.EqualValues(t, 2, wfi.Version())
+	assert.Equal(t, "wf-fixture", wfi.GetSpec().GetWorkflowId())
+	assert.True(t, wfi.GetStatus().Successful())
+	output, err := typedvalues.Format(wfi.GetStatus().GetOutput())
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", output)
+	assert.Len(t, wfi.GetStatus().GetTasks(), 2)
+	for id, task := range wfi.GetStatus().GetTasks() {
+		assert.Equal(t, id, task.GetSpec().GetTaskId())
+		assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, task.GetStatus().GetStatus())
+	}
+}
@@ -0,0 +1,114 @@
+package aggregates
+
+import (
+	"fmt"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	TypeSchedule = "schedule"
+)
+
+type Schedule struct {
+	*fes.BaseEntity
+	*types.Schedule
+}
+
+func NewSchedule(scheduleID string, schedule ...*types.Schedule) *Schedule {
+	sa := &Schedule{}
+	if len(schedule) > 0 {
+		sa.Schedule = schedule[0]
+	}
+
+	sa.BaseEntity = fes.NewBaseEntity(sa, *NewScheduleAggregate(scheduleID))
+	return sa
+}
+
+func NewScheduleAggregate(scheduleID string) *fes.Aggregate {
+	return &fes.Aggregate{
+		Id:   scheduleID,
+		Type: TypeSchedule,
+	}
+}
+
+func (s *Schedule) ApplyEvent(event *fes.Event) error {
+	eventData, err := fes.UnmarshalEventData(event)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := eventData.(*events.ScheduleCreated); !ok && s.Schedule == nil {
+		return fmt.Errorf("cannot apply %T to schedule %s, which has not been created", eventData, s.Aggregate().Id)
+	}
+
+	switch m := eventData.(type) {
+	case *events.ScheduleCreated:
+		s.BaseEntity = fes.NewBaseEntity(s, *event.Aggregate)
+		s.Schedule = &types.Schedule{
+			Metadata: &types.ObjectMetadata{
+				Id:        s.Aggregate().Id,
+				CreatedAt: event.GetTimestamp(),
+			},
+			Spec: m.GetSpec(),
+			Status: &types.ScheduleStatus{
+				Status:         types.ScheduleStatus_ACTIVE,
+				UpdatedAt:      event.GetTimestamp(),
+				ScheduledUntil: event.GetTimestamp(),
+			},
+		}
+	case *events.ScheduleUpdated:
+		// The runs that were due before the update are not run with the new spec.
+		s.Spec = m.GetSpec()
+		s.Status.UpdatedAt = event.GetTimestamp()
+		s.Status.ScheduledUntil = event.GetTimestamp()
+	case *events.ScheduleDeleted:
+		s.Status.Status = types.ScheduleStatus_DELETED
+		s.Status.UpdatedAt = event.GetTimestamp()
+	case *events.ScheduleInvoked:
+		s.Status.UpdatedAt = event.GetTimestamp()
+		s.Status.ScheduledUntil = m.GetScheduledAt()
+		s.Status.LastInvocationId = m.GetInvocationId()
+	case *events.ScheduleMissed:
+		s.Status.UpdatedAt = event.GetTimestamp()
+		s.Status.ScheduledUntil = m.GetScheduledAt()
+		s.Status.MissedRuns += m.GetCount()
+		s.Status.LastMissedAt = m.GetScheduledAt()
+		s.Status.LastMissedReason = m.GetReason()
+	default:
+		log.WithFields(log.Fields{
+			"aggregate": s.Aggregate(),
+		}).Warnf("Skipping unimplemented event: %T", eventData)
+	}
+	return nil
+}
+
+func (s *Schedule) GenericCopy() fes.Entity {
+	n := &Schedule{
+		Schedule: s.Copy(),
+	}
+	n.BaseEntity = s.CopyBaseEntity(n)
+	return n
+}
+
+func (s *Schedule) SnapshotState() proto.Message {
+	return s.Copy()
+}
+
+func (s *Schedule) RestoreSnapshotState(state proto.Message) error {
+	schedule, ok := state.(*types.Schedule)
+	if !ok {
+		return fmt.Errorf("invalid snapshot state for schedule: %T", state)
+	}
+	s.Schedule = schedule
+	s.BaseEntity = fes.NewBaseEntity(s, *NewScheduleAggregate(schedule.ID()))
+	return nil
+}
+
+func (s *Schedule) Copy() *types.Schedule {
+	return proto.Clone(s.Schedule).(*types.Schedule)
+}
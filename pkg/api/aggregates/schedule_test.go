@@ -0,0 +1,32 @@
+package aggregates
+
+import (
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_ApplyEventNotCreated(t *testing.T) {
+	aggregate := *NewScheduleAggregate("sc-unknown")
+	updated, err := fes.NewEvent(aggregate, &events.ScheduleUpdated{
+		Spec: &types.ScheduleSpec{Cron: "@yearly"},
+	})
+	assert.NoError(t, err)
+	deleted, err := fes.NewEvent(aggregate, &events.ScheduleDeleted{})
+	assert.NoError(t, err)
+
+	// The events of a schedule that has not been created should be rejected, rather than crash the projection.
+	assert.Error(t, NewSchedule(aggregate.Id).ApplyEvent(updated))
+	assert.Error(t, NewSchedule(aggregate.Id).ApplyEvent(deleted))
+
+	created, err := fes.NewEvent(aggregate, &events.ScheduleCreated{
+		Spec: &types.ScheduleSpec{Cron: "@daily"},
+	})
+	assert.NoError(t, err)
+	schedule := NewSchedule(aggregate.Id)
+	assert.NoError(t, fes.Project(schedule, created, updated))
+	assert.Equal(t, "@yearly", schedule.GetSpec().GetCron())
+}
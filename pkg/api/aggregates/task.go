@@ -43,12 +43,15 @@ func (ti *TaskInvocation) ApplyEvent(event *fes.Event) error {
 
 	switch m := eventData.(type) {
 	case *events.TaskStarted:
+		// A task is started again when it is retried, so keep track of the previous attempts.
+		attempts := ti.GetStatus().GetAttempts()
 		ti.TaskInvocation = &types.TaskInvocation{
 			Metadata: types.NewObjectMetadata(m.GetSpec().TaskId),
 			Spec:     m.GetSpec(),
 			Status: &types.TaskInvocationStatus{
 				Status:    types.TaskInvocationStatus_IN_PROGRESS,
 				UpdatedAt: event.Timestamp,
+				Attempts:  attempts + 1,
 			},
 		}
 	case *events.TaskSucceeded:
@@ -1,6 +1,8 @@
 package aggregates
 
 import (
+	"fmt"
+
 	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
@@ -82,6 +84,20 @@ func (wf *Workflow) GenericCopy() fes.Entity {
 	return n
 }
 
+func (wf *Workflow) SnapshotState() proto.Message {
+	return wf.Copy()
+}
+
+func (wf *Workflow) RestoreSnapshotState(state proto.Message) error {
+	workflow, ok := state.(*types.Workflow)
+	if !ok {
+		return fmt.Errorf("invalid snapshot state for workflow: %T", state)
+	}
+	wf.Workflow = workflow
+	wf.BaseEntity = fes.NewBaseEntity(wf, *NewWorkflowAggregate(workflow.ID()))
+	return nil
+}
+
 func (wf *Workflow) Copy() *types.Workflow {
 	return proto.Clone(wf.Workflow).(*types.Workflow)
 }
@@ -1,3 +1,9 @@
+// Package events contains the events of the workflow, invocation and schedule aggregates, and of the replicas of the
+// controllers.
+//
+// The events are persisted in the event store, so changes to the events (or the types contained in them) need to
+// remain compatible with the existing event logs. Incompatible changes require an upcaster, registered with
+// fes.RegisterUpcaster, which transforms the events of the previous schema version into the new shape.
 package events
 
 import (
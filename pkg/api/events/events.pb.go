@@ -18,10 +18,24 @@ It has these top-level messages:
 	InvocationCanceled
 	InvocationTaskAdded
 	InvocationFailed
+	InvocationPaused
+	InvocationResumed
+	InvocationRetried
+	InvocationStarted
 	TaskStarted
 	TaskSucceeded
 	TaskSkipped
 	TaskFailed
+	ReplicaHeartbeat
+	ReplicaLeft
+	ScheduleCreated
+	ScheduleUpdated
+	ScheduleDeleted
+	ScheduleInvoked
+	ScheduleMissed
+	IdempotencyKeyClaimed
+	IdempotencyKeyReleased
+	IdempotencyKeyConfirmed
 */
 package events
 
@@ -29,6 +43,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import fission_workflows_types "github.com/fission/fission-workflows/pkg/types"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -193,6 +208,39 @@ func (m *InvocationFailed) GetError() *fission_workflows_types.Error {
 	return nil
 }
 
+type InvocationPaused struct {
+}
+
+func (m *InvocationPaused) Reset()                    { *m = InvocationPaused{} }
+func (m *InvocationPaused) String() string            { return proto.CompactTextString(m) }
+func (*InvocationPaused) ProtoMessage()               {}
+func (*InvocationPaused) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type InvocationResumed struct {
+}
+
+func (m *InvocationResumed) Reset()                    { *m = InvocationResumed{} }
+func (m *InvocationResumed) String() string            { return proto.CompactTextString(m) }
+func (*InvocationResumed) ProtoMessage()               {}
+func (*InvocationResumed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+type InvocationRetried struct {
+}
+
+func (m *InvocationRetried) Reset()                    { *m = InvocationRetried{} }
+func (m *InvocationRetried) String() string            { return proto.CompactTextString(m) }
+func (*InvocationRetried) ProtoMessage()               {}
+func (*InvocationRetried) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+// InvocationStarted indicates that the start of an invocation, which had been deferred, has arrived.
+type InvocationStarted struct {
+}
+
+func (m *InvocationStarted) Reset()                    { *m = InvocationStarted{} }
+func (m *InvocationStarted) String() string            { return proto.CompactTextString(m) }
+func (*InvocationStarted) ProtoMessage()               {}
+func (*InvocationStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
 //
 // Task
 //
@@ -204,7 +252,7 @@ type TaskStarted struct {
 func (m *TaskStarted) Reset()                    { *m = TaskStarted{} }
 func (m *TaskStarted) String() string            { return proto.CompactTextString(m) }
 func (*TaskStarted) ProtoMessage()               {}
-func (*TaskStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*TaskStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *TaskStarted) GetSpec() *fission_workflows_types.TaskInvocationSpec {
 	if m != nil {
@@ -220,7 +268,7 @@ type TaskSucceeded struct {
 func (m *TaskSucceeded) Reset()                    { *m = TaskSucceeded{} }
 func (m *TaskSucceeded) String() string            { return proto.CompactTextString(m) }
 func (*TaskSucceeded) ProtoMessage()               {}
-func (*TaskSucceeded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*TaskSucceeded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *TaskSucceeded) GetResult() *fission_workflows_types.TaskInvocationStatus {
 	if m != nil {
@@ -235,7 +283,7 @@ type TaskSkipped struct {
 func (m *TaskSkipped) Reset()                    { *m = TaskSkipped{} }
 func (m *TaskSkipped) String() string            { return proto.CompactTextString(m) }
 func (*TaskSkipped) ProtoMessage()               {}
-func (*TaskSkipped) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*TaskSkipped) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 type TaskFailed struct {
 	Error *fission_workflows_types.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
@@ -244,7 +292,7 @@ type TaskFailed struct {
 func (m *TaskFailed) Reset()                    { *m = TaskFailed{} }
 func (m *TaskFailed) String() string            { return proto.CompactTextString(m) }
 func (*TaskFailed) ProtoMessage()               {}
-func (*TaskFailed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*TaskFailed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *TaskFailed) GetError() *fission_workflows_types.Error {
 	if m != nil {
@@ -253,6 +301,169 @@ func (m *TaskFailed) GetError() *fission_workflows_types.Error {
 	return nil
 }
 
+// ReplicaHeartbeat renews the lease of a replica of the controllers.
+type ReplicaHeartbeat struct {
+	// The time after which the replica is considered to have disappeared, unless the lease has been renewed.
+	ExpiresAt *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *ReplicaHeartbeat) Reset()                    { *m = ReplicaHeartbeat{} }
+func (m *ReplicaHeartbeat) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaHeartbeat) ProtoMessage()               {}
+func (*ReplicaHeartbeat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ReplicaHeartbeat) GetExpiresAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+// ReplicaLeft releases the lease of a replica of the controllers that has stopped.
+type ReplicaLeft struct {
+}
+
+func (m *ReplicaLeft) Reset()                    { *m = ReplicaLeft{} }
+func (m *ReplicaLeft) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaLeft) ProtoMessage()               {}
+func (*ReplicaLeft) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+type ScheduleCreated struct {
+	Spec *fission_workflows_types.ScheduleSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *ScheduleCreated) Reset()                    { *m = ScheduleCreated{} }
+func (m *ScheduleCreated) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleCreated) ProtoMessage()               {}
+func (*ScheduleCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ScheduleCreated) GetSpec() *fission_workflows_types.ScheduleSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type ScheduleUpdated struct {
+	Spec *fission_workflows_types.ScheduleSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *ScheduleUpdated) Reset()                    { *m = ScheduleUpdated{} }
+func (m *ScheduleUpdated) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleUpdated) ProtoMessage()               {}
+func (*ScheduleUpdated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ScheduleUpdated) GetSpec() *fission_workflows_types.ScheduleSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type ScheduleDeleted struct {
+}
+
+func (m *ScheduleDeleted) Reset()                    { *m = ScheduleDeleted{} }
+func (m *ScheduleDeleted) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleDeleted) ProtoMessage()               {}
+func (*ScheduleDeleted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+// ScheduleInvoked records that a run of the schedule invoked the workflow.
+type ScheduleInvoked struct {
+	// The time at which the run was scheduled.
+	ScheduledAt  *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+	InvocationId string                     `protobuf:"bytes,2,opt,name=invocationId" json:"invocationId,omitempty"`
+}
+
+func (m *ScheduleInvoked) Reset()                    { *m = ScheduleInvoked{} }
+func (m *ScheduleInvoked) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleInvoked) ProtoMessage()               {}
+func (*ScheduleInvoked) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ScheduleInvoked) GetScheduledAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *ScheduleInvoked) GetInvocationId() string {
+	if m != nil {
+		return m.InvocationId
+	}
+	return ""
+}
+
+// ScheduleMissed records runs of the schedule that did not invoke the workflow.
+type ScheduleMissed struct {
+	// The time at which the last of the missed runs was scheduled.
+	ScheduledAt *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+	// The number of missed runs.
+	Count  int64  `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *ScheduleMissed) Reset()                    { *m = ScheduleMissed{} }
+func (m *ScheduleMissed) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleMissed) ProtoMessage()               {}
+func (*ScheduleMissed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ScheduleMissed) GetScheduledAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *ScheduleMissed) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ScheduleMissed) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// IdempotencyKeyClaimed records that an idempotency key was used to create the invocation.
+type IdempotencyKeyClaimed struct {
+	InvocationId string `protobuf:"bytes,1,opt,name=invocationId" json:"invocationId,omitempty"`
+}
+
+func (m *IdempotencyKeyClaimed) Reset()                    { *m = IdempotencyKeyClaimed{} }
+func (m *IdempotencyKeyClaimed) String() string            { return proto.CompactTextString(m) }
+func (*IdempotencyKeyClaimed) ProtoMessage()               {}
+func (*IdempotencyKeyClaimed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *IdempotencyKeyClaimed) GetInvocationId() string {
+	if m != nil {
+		return m.InvocationId
+	}
+	return ""
+}
+
+// IdempotencyKeyReleased releases a claim on an idempotency key of which the invocation could not be created.
+type IdempotencyKeyReleased struct {
+}
+
+func (m *IdempotencyKeyReleased) Reset()                    { *m = IdempotencyKeyReleased{} }
+func (m *IdempotencyKeyReleased) String() string            { return proto.CompactTextString(m) }
+func (*IdempotencyKeyReleased) ProtoMessage()               {}
+func (*IdempotencyKeyReleased) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+// IdempotencyKeyConfirmed confirms that the invocation of the claim on an idempotency key has been created.
+type IdempotencyKeyConfirmed struct {
+}
+
+func (m *IdempotencyKeyConfirmed) Reset()                    { *m = IdempotencyKeyConfirmed{} }
+func (m *IdempotencyKeyConfirmed) String() string            { return proto.CompactTextString(m) }
+func (*IdempotencyKeyConfirmed) ProtoMessage()               {}
+func (*IdempotencyKeyConfirmed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
 func init() {
 	proto.RegisterType((*EventWrapper)(nil), "fission.workflows.events.EventWrapper")
 	proto.RegisterType((*WorkflowCreated)(nil), "fission.workflows.events.WorkflowCreated")
@@ -264,44 +475,73 @@ func init() {
 	proto.RegisterType((*InvocationCanceled)(nil), "fission.workflows.events.InvocationCanceled")
 	proto.RegisterType((*InvocationTaskAdded)(nil), "fission.workflows.events.InvocationTaskAdded")
 	proto.RegisterType((*InvocationFailed)(nil), "fission.workflows.events.InvocationFailed")
+	proto.RegisterType((*InvocationPaused)(nil), "fission.workflows.events.InvocationPaused")
+	proto.RegisterType((*InvocationResumed)(nil), "fission.workflows.events.InvocationResumed")
+	proto.RegisterType((*InvocationRetried)(nil), "fission.workflows.events.InvocationRetried")
+	proto.RegisterType((*InvocationStarted)(nil), "fission.workflows.events.InvocationStarted")
 	proto.RegisterType((*TaskStarted)(nil), "fission.workflows.events.TaskStarted")
 	proto.RegisterType((*TaskSucceeded)(nil), "fission.workflows.events.TaskSucceeded")
 	proto.RegisterType((*TaskSkipped)(nil), "fission.workflows.events.TaskSkipped")
 	proto.RegisterType((*TaskFailed)(nil), "fission.workflows.events.TaskFailed")
+	proto.RegisterType((*ReplicaHeartbeat)(nil), "fission.workflows.events.ReplicaHeartbeat")
+	proto.RegisterType((*ReplicaLeft)(nil), "fission.workflows.events.ReplicaLeft")
+	proto.RegisterType((*ScheduleCreated)(nil), "fission.workflows.events.ScheduleCreated")
+	proto.RegisterType((*ScheduleUpdated)(nil), "fission.workflows.events.ScheduleUpdated")
+	proto.RegisterType((*ScheduleDeleted)(nil), "fission.workflows.events.ScheduleDeleted")
+	proto.RegisterType((*ScheduleInvoked)(nil), "fission.workflows.events.ScheduleInvoked")
+	proto.RegisterType((*ScheduleMissed)(nil), "fission.workflows.events.ScheduleMissed")
+	proto.RegisterType((*IdempotencyKeyClaimed)(nil), "fission.workflows.events.IdempotencyKeyClaimed")
+	proto.RegisterType((*IdempotencyKeyReleased)(nil), "fission.workflows.events.IdempotencyKeyReleased")
+	proto.RegisterType((*IdempotencyKeyConfirmed)(nil), "fission.workflows.events.IdempotencyKeyConfirmed")
 }
 
 func init() { proto.RegisterFile("pkg/api/events/events.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 475 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xe1, 0x6b, 0xd4, 0x30,
-	0x18, 0xc6, 0xe9, 0xb6, 0x3b, 0xf4, 0x3d, 0xa7, 0x5b, 0x44, 0x38, 0x26, 0xca, 0x11, 0x11, 0x06,
-	0xb2, 0x16, 0x37, 0x3f, 0xb8, 0xf9, 0x41, 0xdc, 0x3c, 0xd9, 0x44, 0x45, 0x3a, 0xd9, 0x44, 0xf0,
-	0x43, 0xd6, 0xbc, 0x3b, 0x4b, 0xbb, 0x26, 0x24, 0xe9, 0x8d, 0xfe, 0x6b, 0xfe, 0x75, 0x92, 0x26,
-	0x5d, 0x7b, 0xe8, 0x9d, 0xe2, 0x7d, 0x69, 0x4a, 0xfa, 0x3e, 0xbf, 0xbe, 0xcf, 0xf3, 0xa6, 0x85,
-	0x87, 0x32, 0x9b, 0x44, 0x4c, 0xa6, 0x11, 0x4e, 0xb1, 0x30, 0xda, 0x2f, 0xa1, 0x54, 0xc2, 0x08,
-	0x32, 0xbc, 0x4c, 0xb5, 0x4e, 0x45, 0x11, 0x5e, 0x0b, 0x95, 0x5d, 0xe6, 0xe2, 0x5a, 0x87, 0xee,
-	0xf9, 0xd6, 0xc1, 0x24, 0x35, 0x3f, 0xca, 0x8b, 0x30, 0x11, 0x57, 0x91, 0x2f, 0x6a, 0xd6, 0x9d,
-	0x9b, 0xe2, 0xc8, 0xb2, 0x4d, 0x25, 0x51, 0xbb, 0xab, 0xa3, 0xd2, 0x11, 0xdc, 0x19, 0x5b, 0xca,
-	0xb9, 0x62, 0x52, 0xa2, 0x22, 0x1b, 0xb0, 0xca, 0x8a, 0x6a, 0x18, 0x8c, 0x82, 0xed, 0xdb, 0xb1,
-	0xbd, 0xa5, 0x1f, 0xe0, 0xde, 0xb9, 0x87, 0x1c, 0x29, 0x64, 0x06, 0x39, 0xd9, 0x87, 0x35, 0x2d,
-	0x31, 0xa9, 0xab, 0x06, 0xbb, 0x4f, 0xc3, 0xdf, 0x3b, 0x73, 0xaf, 0x68, 0x74, 0xa7, 0x12, 0x93,
-	0xb8, 0x96, 0xd0, 0xcd, 0x96, 0xf6, 0x16, 0x73, 0x34, 0xc8, 0xe9, 0xcf, 0x00, 0xee, 0x36, 0x7b,
-	0x9f, 0x99, 0xd2, 0xc8, 0xc9, 0x09, 0xf4, 0x0c, 0xd3, 0x99, 0x1e, 0x06, 0xa3, 0xd5, 0xed, 0xc1,
-	0xee, 0x5e, 0x38, 0xcf, 0x7b, 0x38, 0x2b, 0x0c, 0xbf, 0x58, 0xd5, 0xb8, 0x30, 0xaa, 0x8a, 0x1d,
-	0x61, 0xeb, 0x3b, 0x40, 0xbb, 0x69, 0xed, 0x65, 0x78, 0x63, 0x2f, 0xc3, 0x8a, 0xec, 0x43, 0x6f,
-	0xca, 0xf2, 0x12, 0x87, 0x2b, 0xb5, 0x99, 0x27, 0x73, 0xcd, 0x58, 0xca, 0xa9, 0x61, 0xa6, 0xd4,
-	0xb1, 0x53, 0x1c, 0xac, 0xbc, 0x0c, 0xe8, 0x47, 0x78, 0xd0, 0x6d, 0x21, 0x2d, 0x26, 0xef, 0x58,
-	0x9a, 0x23, 0x27, 0x2f, 0xa0, 0x87, 0x4a, 0x09, 0xe5, 0x43, 0x7a, 0x3c, 0x97, 0x3b, 0xb6, 0x55,
-	0xb1, 0x2b, 0xa6, 0x5f, 0x61, 0xf3, 0xa4, 0x98, 0x8a, 0x84, 0x99, 0x54, 0x14, 0x4d, 0xdc, 0x47,
-	0x33, 0x71, 0x47, 0x7f, 0x8d, 0xbb, 0x25, 0x74, 0x82, 0x8f, 0xe1, 0x7e, 0x87, 0x2c, 0xae, 0x64,
-	0x1d, 0x3e, 0x79, 0x05, 0x7d, 0x51, 0x1a, 0x59, 0x1a, 0x4f, 0x5f, 0xe0, 0xbf, 0x92, 0xc8, 0xcf,
-	0xac, 0xf1, 0xd8, 0x4b, 0xe8, 0x7b, 0x20, 0x1d, 0x26, 0x2b, 0x12, 0xfc, 0x7f, 0xe7, 0xc7, 0xdd,
-	0xfe, 0x6c, 0xd6, 0x6f, 0x38, 0x47, 0x4e, 0x9e, 0xc3, 0x9a, 0x9d, 0xa3, 0x67, 0x3d, 0x5a, 0x38,
-	0x9d, 0xb8, 0x2e, 0xa5, 0xc7, 0xb0, 0xd1, 0x92, 0x96, 0x9a, 0xc6, 0x27, 0x18, 0xf8, 0xa9, 0x2b,
-	0x9b, 0xd5, 0xeb, 0x99, 0x39, 0x3c, 0x5b, 0xd8, 0xcb, 0x1f, 0x67, 0x70, 0x06, 0xeb, 0x35, 0xaf,
-	0x4c, 0x12, 0x44, 0xeb, 0x6e, 0x0c, 0x7d, 0x85, 0xba, 0xcc, 0x9b, 0xf4, 0x77, 0xfe, 0x95, 0xe9,
-	0xce, 0xa1, 0x17, 0xd3, 0x75, 0xdf, 0x67, 0x96, 0x4a, 0x89, 0x9c, 0x1e, 0xba, 0x23, 0xbf, 0x8c,
-	0xf5, 0xc3, 0x5b, 0xdf, 0xfa, 0xee, 0x0b, 0xbb, 0xe8, 0xd7, 0x3f, 0x8a, 0xbd, 0x5f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xf6, 0x42, 0x8f, 0x08, 0x9d, 0x04, 0x00, 0x00,
+	// 706 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0x6d, 0x4f, 0xdb, 0x48,
+	0x10, 0xc7, 0x15, 0x42, 0xa2, 0x63, 0x02, 0x1c, 0x2c, 0x07, 0x97, 0xcb, 0xe9, 0xee, 0x90, 0x4f,
+	0x95, 0x90, 0x2a, 0x1c, 0x15, 0xfa, 0x82, 0x87, 0x4a, 0x15, 0xd0, 0x54, 0xa4, 0x85, 0x0a, 0x39,
+	0x14, 0xaa, 0x4a, 0x7d, 0xb1, 0xf1, 0x4e, 0xc2, 0x2a, 0xb6, 0x77, 0xb5, 0xbb, 0x86, 0x46, 0xea,
+	0x27, 0xeb, 0xa7, 0xab, 0xd6, 0x0f, 0xc4, 0xe1, 0xb9, 0xcd, 0x9b, 0x38, 0x3b, 0x99, 0xf9, 0xcd,
+	0xcc, 0x7f, 0xd6, 0x13, 0xf8, 0x5b, 0x0e, 0xfa, 0x4d, 0x2a, 0x79, 0x13, 0x2f, 0x31, 0x32, 0x3a,
+	0x7b, 0xb8, 0x52, 0x09, 0x23, 0x48, 0xbd, 0xc7, 0xb5, 0xe6, 0x22, 0x72, 0xaf, 0x84, 0x1a, 0xf4,
+	0x02, 0x71, 0xa5, 0xdd, 0xf4, 0xf7, 0xc6, 0x4e, 0x9f, 0x9b, 0x8b, 0xb8, 0xeb, 0xfa, 0x22, 0x6c,
+	0x66, 0x4e, 0xf9, 0x73, 0xfd, 0xda, 0xb9, 0x69, 0xd9, 0x66, 0x28, 0x51, 0xa7, 0x9f, 0x29, 0xb5,
+	0xf1, 0x5f, 0x5f, 0x88, 0x7e, 0x80, 0xcd, 0xe4, 0xd4, 0x8d, 0x7b, 0x4d, 0xc3, 0x43, 0xd4, 0x86,
+	0x86, 0x32, 0x75, 0x70, 0x56, 0x61, 0xb6, 0x65, 0xd3, 0x9c, 0x2b, 0x2a, 0x25, 0x2a, 0xb2, 0x00,
+	0x65, 0x1a, 0x0d, 0xeb, 0xa5, 0xd5, 0xd2, 0xda, 0x8c, 0x67, 0xbf, 0x3a, 0x47, 0xf0, 0xfb, 0x79,
+	0x96, 0xe5, 0x40, 0x21, 0x35, 0xc8, 0xc8, 0x36, 0x4c, 0x6b, 0x89, 0x7e, 0xe2, 0x55, 0xdb, 0x78,
+	0xe6, 0xde, 0x2e, 0x3d, 0xad, 0x21, 0x8f, 0xeb, 0x48, 0xf4, 0xbd, 0x24, 0xc4, 0x59, 0x1c, 0xd1,
+	0xde, 0x60, 0x80, 0x06, 0x99, 0xf3, 0xbd, 0x04, 0xf3, 0xb9, 0xed, 0x84, 0x2a, 0x8d, 0x8c, 0xb4,
+	0xa1, 0x62, 0xa8, 0x1e, 0xe8, 0x7a, 0x69, 0xb5, 0xbc, 0x56, 0xdb, 0xd8, 0x74, 0xef, 0x13, 0xc7,
+	0x1d, 0x0f, 0x74, 0x4f, 0x6d, 0x54, 0x2b, 0x32, 0x6a, 0xe8, 0xa5, 0x84, 0xc6, 0x17, 0x80, 0x91,
+	0xd1, 0xb6, 0x37, 0xc0, 0xeb, 0xf6, 0x06, 0x38, 0x24, 0xdb, 0x50, 0xb9, 0xa4, 0x41, 0x8c, 0xf5,
+	0xa9, 0xa4, 0x99, 0xff, 0xef, 0x6d, 0xc6, 0x52, 0x3a, 0x86, 0x9a, 0x58, 0x7b, 0x69, 0xc4, 0xce,
+	0xd4, 0x56, 0xc9, 0x39, 0x86, 0xe5, 0x62, 0x09, 0x3c, 0xea, 0xbf, 0xa5, 0x3c, 0x40, 0x46, 0x5e,
+	0x42, 0x05, 0x95, 0x12, 0x2a, 0x13, 0xe9, 0xdf, 0x7b, 0xb9, 0x2d, 0xeb, 0xe5, 0xa5, 0xce, 0xce,
+	0x27, 0x58, 0x6c, 0x47, 0x97, 0xc2, 0xa7, 0x86, 0x8b, 0x28, 0x97, 0xfb, 0x60, 0x4c, 0xee, 0xe6,
+	0xa3, 0x72, 0x8f, 0x08, 0x05, 0xe1, 0x3d, 0x58, 0x2a, 0x90, 0x45, 0x28, 0x13, 0xf1, 0xc9, 0x2e,
+	0x54, 0x45, 0x6c, 0x64, 0x6c, 0xea, 0xa5, 0xc7, 0xfa, 0x1f, 0x4a, 0x64, 0x67, 0xb6, 0x71, 0x2f,
+	0x0b, 0x71, 0xde, 0x01, 0x29, 0x30, 0x69, 0xe4, 0xe3, 0xaf, 0x77, 0x7e, 0x58, 0xac, 0xcf, 0x6a,
+	0xbd, 0xc7, 0x18, 0x32, 0xf2, 0x02, 0xa6, 0xed, 0x1c, 0x33, 0xd6, 0x3f, 0x0f, 0x4e, 0xc7, 0x4b,
+	0x5c, 0x9d, 0x43, 0x58, 0x18, 0x91, 0x26, 0x9a, 0x06, 0x29, 0x92, 0x4e, 0x68, 0xac, 0x91, 0x39,
+	0x4b, 0xc5, 0x09, 0x79, 0xa8, 0xe3, 0xf0, 0xb6, 0xd1, 0x28, 0x7e, 0xd3, 0xd8, 0x31, 0x54, 0xd9,
+	0xcb, 0xfe, 0x01, 0x6a, 0xd9, 0x45, 0xb2, 0x47, 0xf2, 0x7a, 0x6c, 0xb4, 0xcf, 0x1f, 0x6c, 0xef,
+	0xce, 0xb1, 0x9e, 0xc1, 0x5c, 0xc2, 0x8b, 0x7d, 0x1f, 0xd1, 0x0a, 0xd6, 0x82, 0xaa, 0x42, 0x1d,
+	0x07, 0xf9, 0x40, 0xd7, 0x9f, 0xca, 0x4c, 0xaf, 0x76, 0x16, 0xec, 0xcc, 0x65, 0x75, 0x0e, 0xb8,
+	0x94, 0xc8, 0x9c, 0xfd, 0xf4, 0x2d, 0x9a, 0x48, 0xcd, 0x23, 0x58, 0xf0, 0x50, 0x06, 0xdc, 0xa7,
+	0x87, 0x48, 0x95, 0xe9, 0x22, 0x35, 0x64, 0x0b, 0x66, 0xf0, 0xab, 0xe4, 0x0a, 0xf5, 0x5e, 0x5e,
+	0x70, 0xc3, 0x4d, 0x77, 0x96, 0x9b, 0xef, 0x2c, 0xf7, 0x34, 0xdf, 0x59, 0xde, 0xc8, 0xd9, 0x16,
+	0x98, 0xd1, 0x8e, 0xb0, 0x67, 0xec, 0x96, 0xea, 0xf8, 0x17, 0xc8, 0xe2, 0x00, 0x7f, 0x76, 0x4b,
+	0xe5, 0x71, 0x05, 0x55, 0x0b, 0xb4, 0x8f, 0x92, 0x4d, 0x4a, 0x5b, 0x1c, 0xd1, 0xf2, 0x9d, 0xa7,
+	0x47, 0x26, 0x3b, 0x82, 0x01, 0x32, 0xf2, 0x0a, 0x6a, 0x3a, 0x33, 0xb1, 0x27, 0x89, 0x51, 0x74,
+	0x27, 0x0e, 0xcc, 0xf2, 0xeb, 0x59, 0xb6, 0x59, 0xb2, 0xcd, 0x66, 0xbc, 0x31, 0x9b, 0xf3, 0x0d,
+	0xe6, 0xf3, 0xa4, 0xc7, 0x5c, 0xeb, 0x89, 0x73, 0xfe, 0x01, 0x15, 0x5f, 0xc4, 0x91, 0x49, 0x92,
+	0x95, 0xbd, 0xf4, 0x40, 0x56, 0xec, 0x05, 0xa4, 0x5a, 0x44, 0xf5, 0x72, 0x52, 0x43, 0x76, 0x72,
+	0x76, 0x61, 0xb9, 0xcd, 0x30, 0x94, 0xc2, 0x60, 0xe4, 0x0f, 0xdf, 0xe3, 0xf0, 0x20, 0xa0, 0x3c,
+	0x44, 0x76, 0xab, 0xf4, 0xd2, 0x1d, 0xa5, 0xd7, 0x61, 0x65, 0x3c, 0xd8, 0xc3, 0x00, 0xa9, 0x7d,
+	0x1f, 0xff, 0x82, 0x3f, 0x6f, 0x60, 0x45, 0xd4, 0xe3, 0x2a, 0x44, 0xb6, 0xff, 0xdb, 0xe7, 0x6a,
+	0xfa, 0x2f, 0xd1, 0xad, 0x26, 0xad, 0x6c, 0xfe, 0x18, 0x00, 0x06, 0x58, 0x37, 0x94, 0x82, 0x07,
+	0x00, 0x00,
 }
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// TypeIdempotencyKey is the aggregate type of the claims on the idempotency keys of invocations.
+	TypeIdempotencyKey = "idempotency"
+
+	// DefaultIdempotencyWindow is the default duration after the creation of an invocation during which invoking a
+	// workflow with the same idempotency key returns the original invocation.
+	DefaultIdempotencyWindow = 24 * time.Hour
+
+	// claimTimeout is the duration after which a claim that has been neither confirmed nor released is considered to
+	// be abandoned, for example because the process that claimed the key stopped before creating the invocation.
+	claimTimeout = time.Minute
+
+	// claimWait is the maximum duration that a request waits for the pending claim of another request on the same key
+	// to be confirmed or released.
+	claimWait = 10 * time.Second
+
+	// claimPollInterval is the interval at which a request checks whether a pending claim has been resolved.
+	claimPollInterval = 50 * time.Millisecond
+)
+
+// NewIdempotencyKeyAggregate returns the aggregate that holds the claims on the idempotency key of invocations of the
+// workflow. The keys are scoped to the workflow, so the same key can be used to invoke different workflows. The key is
+// hashed, as it is provided by the client and therefore is not guaranteed to be a valid aggregate ID.
+func NewIdempotencyKeyAggregate(workflowID string, key string) *fes.Aggregate {
+	hash := sha256.New()
+	hash.Write([]byte(workflowID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key))
+	aggregate := fes.NewAggregate(TypeIdempotencyKey, hex.EncodeToString(hash.Sum(nil)))
+	return &aggregate
+}
+
+// invokeIdempotent creates the invocation, unless an invocation has already been created with the idempotency key of
+// the spec within the idempotency window, in which case it returns the ID of that invocation. Once the window of the
+// key has expired, the key is claimed again, creating a new invocation.
+//
+// The key is claimed before the invocation is created, and the claim is confirmed once the invocation has been
+// created. Concurrent requests with the same key are resolved by the version of the claims, ensuring that only one of
+// them creates the invocation; the other requests wait until the claim is confirmed before returning the invocation.
+func (ia *Invocation) invokeIdempotent(spec *types.WorkflowInvocationSpec) (string, error) {
+	aggregate := NewIdempotencyKeyAggregate(spec.GetWorkflowId(), spec.GetIdempotencyKey())
+	deadline := time.Now().Add(claimWait)
+
+	// Most keys are new, so the key is first claimed as if it has not been claimed before. This avoids reading the
+	// claims of an aggregate that does not exist yet.
+	var version uint64
+	for {
+		invocationID, err := ia.claimAndCreate(aggregate, version, spec)
+		if !fes.IsConflict(err) {
+			return invocationID, err
+		}
+
+		// The key has been claimed by another request; wait until the claim is confirmed, released or abandoned.
+		for {
+			claims, err := ia.es.Get(*aggregate)
+			if err != nil {
+				return "", err
+			}
+			c, ok := currentClaim(claims, time.Now(), ia.IdempotencyWindow)
+			if !ok {
+				version = uint64(len(claims))
+				break
+			}
+			if c.confirmed {
+				return c.invocationID, nil
+			}
+			if time.Now().After(deadline) {
+				return "", fmt.Errorf("timed out waiting for invocation %s with idempotency key '%s' to be created",
+					c.invocationID, spec.GetIdempotencyKey())
+			}
+			time.Sleep(claimPollInterval)
+		}
+	}
+}
+
+// claimAndCreate claims the key, if the claims are at the expected version, and creates the invocation. It returns a
+// ConflictError if the key has been claimed concurrently.
+func (ia *Invocation) claimAndCreate(aggregate *fes.Aggregate, version uint64, spec *types.WorkflowInvocationSpec) (
+	string, error) {
+	invocationID := newInvocationID()
+	err := ia.appendClaim(aggregate, version, &events.IdempotencyKeyClaimed{
+		InvocationId: invocationID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = ia.create(invocationID, spec)
+	if err != nil {
+		// Release the claim to allow retries of the request to create the invocation.
+		releaseErr := ia.appendClaim(aggregate, version+1, &events.IdempotencyKeyReleased{})
+		if releaseErr != nil {
+			logrus.Errorf("Failed to release idempotency key of invocation %s: %v", invocationID, releaseErr)
+		}
+		return "", err
+	}
+
+	err = ia.appendClaim(aggregate, version+1, &events.IdempotencyKeyConfirmed{})
+	if err != nil {
+		// The invocation has been created, so the request has succeeded. However, as the claim has not been
+		// confirmed, it is considered to be abandoned after the claim timeout.
+		logrus.Errorf("Failed to confirm idempotency key of invocation %s: %v", invocationID, err)
+	}
+	return invocationID, nil
+}
+
+// appendClaim appends the event to the claims of the key, if the claims are at the expected version.
+func (ia *Invocation) appendClaim(aggregate *fes.Aggregate, version uint64, msg proto.Message) error {
+	event, err := fes.NewEvent(*aggregate, msg)
+	if err != nil {
+		return err
+	}
+	event.Hints = &fes.EventHints{Completed: true}
+	return fes.AppendIfVersion(ia.es, event, version)
+}
+
+// claim is a claim on an idempotency key.
+type claim struct {
+	invocationID string
+	claimedAt    time.Time
+
+	// confirmed is true once the invocation of the claim has been created.
+	confirmed bool
+}
+
+// currentClaim returns the claim that holds the idempotency key at the provided time. A key is not held if the last
+// claim has been released, if it has not been confirmed within the claim timeout, or if it is older than the
+// idempotency window.
+func currentClaim(claims []*fes.Event, now time.Time, window time.Duration) (claim, bool) {
+	var current *claim
+	for _, event := range claims {
+		data, err := fes.UnmarshalEventData(event)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid claim on idempotency key %s: %v", event.GetAggregate().GetId(), err)
+			continue
+		}
+		switch m := data.(type) {
+		case *events.IdempotencyKeyClaimed:
+			claimedAt, err := ptypes.Timestamp(event.GetTimestamp())
+			if err != nil {
+				logrus.Warnf("Ignoring invalid claim on idempotency key %s: %v", event.GetAggregate().GetId(), err)
+				current = nil
+				continue
+			}
+			current = &claim{
+				invocationID: m.GetInvocationId(),
+				claimedAt:    claimedAt,
+			}
+		case *events.IdempotencyKeyConfirmed:
+			if current != nil {
+				current.confirmed = true
+			}
+		case *events.IdempotencyKeyReleased:
+			current = nil
+		}
+	}
+	if current == nil || now.Sub(current.claimedAt) >= window {
+		return claim{}, false
+	}
+	if !current.confirmed && now.Sub(current.claimedAt) >= claimTimeout {
+		return claim{}, false
+	}
+	return *current, true
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvokeIdempotent(t *testing.T) {
+	es := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(es)
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId:     "wf-1",
+		IdempotencyKey: "request-1",
+	}
+
+	// Repeated requests with the same key should return the original invocation.
+	id, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	repeatedID, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, id, repeatedID)
+	wfiEvents, err := es.Get(*aggregates.NewWorkflowInvocationAggregate(id))
+	assert.NoError(t, err)
+	assert.Len(t, wfiEvents, 1)
+
+	// Requests with a different key, or without a key, should create new invocations.
+	otherID, err := invocationAPI.Invoke(&types.WorkflowInvocationSpec{
+		WorkflowId:     "wf-1",
+		IdempotencyKey: "request-2",
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, otherID)
+	unkeyedID, err := invocationAPI.Invoke(&types.WorkflowInvocationSpec{WorkflowId: "wf-1"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, unkeyedID)
+
+	// The keys are scoped to the workflow.
+	otherWfID, err := invocationAPI.Invoke(&types.WorkflowInvocationSpec{
+		WorkflowId:     "wf-2",
+		IdempotencyKey: "request-1",
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, otherWfID)
+}
+
+func TestInvokeIdempotentWindow(t *testing.T) {
+	es := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(es)
+	invocationAPI.IdempotencyWindow = 50 * time.Millisecond
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId:     "wf-1",
+		IdempotencyKey: "request-1",
+	}
+
+	id, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	repeatedID, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, id, repeatedID)
+
+	// Once the window has expired, the key should be claimed by a new invocation.
+	time.Sleep(invocationAPI.IdempotencyWindow)
+	newID, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, newID)
+	repeatedID, err = invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, newID, repeatedID)
+}
+
+func TestInvokeIdempotentPendingClaim(t *testing.T) {
+	es := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(es)
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId:     "wf-1",
+		IdempotencyKey: "request-1",
+	}
+	aggregate := NewIdempotencyKeyAggregate(spec.WorkflowId, spec.IdempotencyKey)
+	appendClaim := func(msg proto.Message) {
+		event, err := fes.NewEvent(*aggregate, msg)
+		assert.NoError(t, err)
+		assert.NoError(t, es.Append(event))
+	}
+
+	// Another request has claimed the key, but has not created the invocation yet.
+	appendClaim(&events.IdempotencyKeyClaimed{InvocationId: "wi-1"})
+	result := make(chan string)
+	go func() {
+		id, err := invocationAPI.Invoke(spec)
+		assert.NoError(t, err)
+		result <- id
+	}()
+	time.Sleep(2 * claimPollInterval)
+	appendClaim(&events.IdempotencyKeyConfirmed{})
+	assert.Equal(t, "wi-1", <-result)
+
+	// Once released, the key can be claimed again.
+	appendClaim(&events.IdempotencyKeyReleased{})
+	id, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "wi-1", id)
+	wfiEvents, err := es.Get(*aggregates.NewWorkflowInvocationAggregate(id))
+	assert.NoError(t, err)
+	assert.Len(t, wfiEvents, 1)
+}
+
+func TestCurrentClaim(t *testing.T) {
+	aggregate := NewIdempotencyKeyAggregate("wf-1", "request-1")
+	now := time.Now()
+	newClaim := func(msg proto.Message, age time.Duration) *fes.Event {
+		event, err := fes.NewEvent(*aggregate, msg)
+		assert.NoError(t, err)
+		event.Timestamp, _ = ptypes.TimestampProto(now.Add(-age))
+		return event
+	}
+	claimed := newClaim(&events.IdempotencyKeyClaimed{InvocationId: "wi-1"}, time.Second)
+	confirmed := newClaim(&events.IdempotencyKeyConfirmed{}, 0)
+	released := newClaim(&events.IdempotencyKeyReleased{}, 0)
+
+	_, ok := currentClaim(nil, now, DefaultIdempotencyWindow)
+	assert.False(t, ok)
+
+	// A pending claim holds the key until it is confirmed or released.
+	c, ok := currentClaim([]*fes.Event{claimed}, now, DefaultIdempotencyWindow)
+	assert.True(t, ok)
+	assert.Equal(t, "wi-1", c.invocationID)
+	assert.False(t, c.confirmed)
+
+	c, ok = currentClaim([]*fes.Event{claimed, confirmed}, now, DefaultIdempotencyWindow)
+	assert.True(t, ok)
+	assert.Equal(t, "wi-1", c.invocationID)
+	assert.True(t, c.confirmed)
+
+	_, ok = currentClaim([]*fes.Event{claimed, released}, now, DefaultIdempotencyWindow)
+	assert.False(t, ok)
+
+	// A pending claim is abandoned after the claim timeout, whereas a confirmed claim expires after the idempotency
+	// window.
+	_, ok = currentClaim([]*fes.Event{claimed}, now.Add(claimTimeout), DefaultIdempotencyWindow)
+	assert.False(t, ok)
+	_, ok = currentClaim([]*fes.Event{claimed, confirmed}, now.Add(claimTimeout), DefaultIdempotencyWindow)
+	assert.True(t, ok)
+	_, ok = currentClaim([]*fes.Event{claimed, confirmed}, now.Add(DefaultIdempotencyWindow), DefaultIdempotencyWindow)
+	assert.False(t, ok)
+
+	// Only the last claim is considered.
+	reclaimed := newClaim(&events.IdempotencyKeyClaimed{InvocationId: "wi-2"}, 0)
+	c, ok = currentClaim([]*fes.Event{claimed, released, reclaimed}, now, DefaultIdempotencyWindow)
+	assert.True(t, ok)
+	assert.Equal(t, "wi-2", c.invocationID)
+	assert.False(t, c.confirmed)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api/aggregates"
 	"github.com/fission/fission-workflows/pkg/api/events"
@@ -10,50 +11,78 @@ import (
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/proto"
 )
 
 const ErrInvocationCanceled = "workflow invocation was canceled"
 
 // Invocation contains the API functionality for controlling (workflow) invocations.
 // This includes starting, stopping, and completing invocations.
+//
+// The calls modifying an existing invocation accept CallOptions, such as WithExpectedVersion to guard against
+// concurrent modifications of the invocation.
 type Invocation struct {
 	es fes.Backend
+
+	// IdempotencyWindow is the duration after the creation of an invocation during which invoking the workflow with
+	// the same idempotency key returns the original invocation. Once the window has expired, the key is no longer
+	// associated with the invocation: invoking the workflow with the key creates a new invocation, which claims the
+	// key for a new window.
+	IdempotencyWindow time.Duration
 }
 
-// NewInvocationAPI creates the Invocation API.
+// NewInvocationAPI creates the Invocation API, with the DefaultIdempotencyWindow.
 func NewInvocationAPI(esClient fes.Backend) *Invocation {
-	return &Invocation{esClient}
+	return &Invocation{
+		es:                esClient,
+		IdempotencyWindow: DefaultIdempotencyWindow,
+	}
 }
 
 // Invoke triggers the start of the invocation using the provided specification.
 // The function either returns the invocationID of the invocation or an error.
 // The error can be a validate.Err, proto marshall error, or a fes error.
+//
+// If the spec contains an idempotency key that was used to create an invocation within the IdempotencyWindow, no new
+// invocation is created; instead the invocationID of the original invocation is returned.
 func (ia *Invocation) Invoke(spec *types.WorkflowInvocationSpec) (string, error) {
 	err := validate.WorkflowInvocationSpec(spec)
 	if err != nil {
 		return "", err
 	}
 
-	id := fmt.Sprintf("wi-%s", util.UID())
+	if len(spec.GetIdempotencyKey()) > 0 {
+		return ia.invokeIdempotent(spec)
+	}
 
-	event, err := fes.NewEvent(*aggregates.NewWorkflowInvocationAggregate(id), &events.InvocationCreated{
-		Spec: spec,
-	})
+	id := newInvocationID()
+	err = ia.create(id, spec)
 	if err != nil {
 		return "", err
 	}
-	err = ia.es.Append(event)
+	return id, nil
+}
+
+// create appends the creation event of the invocation with the provided id.
+func (ia *Invocation) create(invocationID string, spec *types.WorkflowInvocationSpec) error {
+	event, err := fes.NewEvent(*aggregates.NewWorkflowInvocationAggregate(invocationID), &events.InvocationCreated{
+		Spec: spec,
+	})
 	if err != nil {
-		return "", err
+		return err
 	}
+	// The invocation is new, so there should not be any existing events for it.
+	return fes.AppendIfVersion(ia.es, event, 0)
+}
 
-	return id, nil
+func newInvocationID() string {
+	return fmt.Sprintf("wi-%s", util.UID())
 }
 
 // Cancel halts an invocation. This does not guarantee that tasks currently running are halted,
 // but beyond the invocation will not progress any further than those tasks. The state of the invocation will
 // become ABORTED. If the API fails to append the event to the event store, it will return an error.
-func (ia *Invocation) Cancel(invocationID string) error {
+func (ia *Invocation) Cancel(invocationID string, opts ...CallOption) error {
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -67,17 +96,139 @@ func (ia *Invocation) Cancel(invocationID string) error {
 		return err
 	}
 	event.Hints = &fes.EventHints{Completed: true}
-	err = ia.es.Append(event)
+	err = appendEvent(ia.es, event, opts)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Start starts an invocation of which the start was deferred, once the time to start the invocation has arrived.
+// If the invocation is not scheduled, it returns a validate.Error.
+func (ia *Invocation) Start(invocationID string, opts ...CallOption) error {
+	return ia.changeStatus(invocationID, types.WorkflowInvocationStatus_SCHEDULED, &events.InvocationStarted{}, nil,
+		opts)
+}
+
+// Pause pauses an invocation that is in progress, which stops the controller from starting any new tasks of the
+// invocation until it is resumed. Tasks that are already running are allowed to finish.
+// If the invocation is not in progress, it returns a validate.Error.
+func (ia *Invocation) Pause(invocationID string, opts ...CallOption) error {
+	return ia.changeStatus(invocationID, types.WorkflowInvocationStatus_IN_PROGRESS, &events.InvocationPaused{}, nil, opts)
+}
+
+// Resume resumes a paused invocation, allowing the controller to continue scheduling its tasks.
+// If the invocation is not paused, it returns a validate.Error.
+func (ia *Invocation) Resume(invocationID string, opts ...CallOption) error {
+	return ia.changeStatus(invocationID, types.WorkflowInvocationStatus_PAUSED, &events.InvocationResumed{}, nil,
+		opts)
+}
+
+// Retry reopens a failed invocation. The outputs of the tasks that succeeded are reused, while the tasks that failed,
+// were aborted or were still in progress are scheduled again, along with the tasks that had not been started yet. The
+// timeout of the invocation applies from the time of the retry. Retry policies of the tasks do not grant any
+// additional attempts, as the attempts of the tasks are preserved.
+//
+// The attempts of the tasks that are still in progress are failed before the invocation is reopened. This completes
+// their attempts, which ensures that the tasks can be started again, and that the results of the abandoned attempts are
+// rejected once they arrive.
+// If the invocation has not failed, it returns a validate.Error.
+func (ia *Invocation) Retry(invocationID string, opts ...CallOption) error {
+	wi, err := ia.getWithStatus(invocationID, types.WorkflowInvocationStatus_FAILED)
+	if err != nil {
+		return err
+	}
+	for taskID, task := range wi.GetStatus().GetTasks() {
+		if task.GetStatus().GetStatus() != types.TaskInvocationStatus_IN_PROGRESS {
+			continue
+		}
+		err := ia.abortTask(invocationID, taskID, TaskVersion(task))
+		if err != nil {
+			return err
+		}
+	}
+	return ia.appendStatusEvent(wi, &events.InvocationRetried{}, &fes.EventHints{Reopened: true}, opts)
+}
+
+// abortTask fails the attempt of a task that is in progress, if the task is still at the version. If the result of
+// the attempt has been recorded in the meantime, the attempt has already been completed.
+func (ia *Invocation) abortTask(invocationID string, taskID string, version uint64) error {
+	event, err := fes.NewEvent(*aggregates.NewTaskInvocationAggregate(taskID), &events.TaskFailed{
+		Error: &types.Error{
+			Code:    types.ErrorCodeAborted,
+			Message: "the task was aborted, because the invocation was retried while the task was in progress",
+		},
+	})
+	if err != nil {
+		return err
+	}
+	event.Parent = aggregates.NewWorkflowInvocationAggregate(invocationID)
+	err = appendEvent(ia.es, event, []CallOption{WithExpectedVersion(version)})
+	if fes.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// changeStatus appends the event, with the optional hints, if the invocation currently has the expected status.
+// Unless the call options specify otherwise, the event is only appended if the invocation has not been modified since
+// its status was checked.
+func (ia *Invocation) changeStatus(invocationID string, expected types.WorkflowInvocationStatus_Status,
+	msg proto.Message, hints *fes.EventHints, opts []CallOption) error {
+	wi, err := ia.getWithStatus(invocationID, expected)
+	if err != nil {
+		return err
+	}
+	return ia.appendStatusEvent(wi, msg, hints, opts)
+}
+
+// getWithStatus reads the invocation from the event store, returning a validate.Error if the invocation does not exist
+// or does not have the expected status.
+func (ia *Invocation) getWithStatus(invocationID string,
+	expected types.WorkflowInvocationStatus_Status) (*aggregates.WorkflowInvocation, error) {
+	if len(invocationID) == 0 {
+		return nil, validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	aggregate := aggregates.NewWorkflowInvocationAggregate(invocationID)
+	evts, err := ia.es.Get(*aggregate)
+	if err != nil {
+		return nil, err
+	}
+	wi := aggregates.NewWorkflowInvocation(invocationID)
+	err = fes.Project(wi, evts...)
+	if err != nil {
+		return nil, err
+	}
+	if wi.WorkflowInvocation == nil {
+		return nil, validate.NewError("invocationID", fmt.Errorf("invocation '%s' does not exist", invocationID))
+	}
+	if current := wi.GetStatus().GetStatus(); current != expected {
+		return nil, validate.NewError("invocation", fmt.Errorf("invocation '%s' is %v, but should be %v",
+			invocationID, current, expected))
+	}
+	return wi, nil
+}
+
+// appendStatusEvent appends the event to the invocation, with the optional hints. Unless the call options specify
+// otherwise, the event is only appended if the invocation is still at the version at which it was read.
+func (ia *Invocation) appendStatusEvent(wi *aggregates.WorkflowInvocation, msg proto.Message, hints *fes.EventHints,
+	opts []CallOption) error {
+	event, err := fes.NewEvent(wi.Aggregate(), msg)
+	if err != nil {
+		return err
+	}
+	event.Hints = hints
+	if parseCallOptions(opts).expectedVersion == nil {
+		opts = append(opts, WithExpectedVersion(wi.Version()))
+	}
+	return appendEvent(ia.es, event, opts)
+}
+
 // Complete forces the completion of an invocation. This function - used by the controller - is the only way
 // to ensure that a workflow invocation turns into the COMPLETED state.
 // If the API fails to append the event to the event store, it will return an error.
-func (ia *Invocation) Complete(invocationID string, output *types.TypedValue) error {
+func (ia *Invocation) Complete(invocationID string, output *types.TypedValue, opts ...CallOption) error {
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -89,13 +240,13 @@ func (ia *Invocation) Complete(invocationID string, output *types.TypedValue) er
 		return err
 	}
 	event.Hints = &fes.EventHints{Completed: true}
-	return ia.es.Append(event)
+	return appendEvent(ia.es, event, opts)
 }
 
 // Fail changes the state of the invocation to FAILED.
 // Optionally you can provide a custom error message to indicate the specific reason for the FAILED state.
 // If the API fails to append the event to the event store, it will return an error.
-func (ia *Invocation) Fail(invocationID string, errMsg error) error {
+func (ia *Invocation) Fail(invocationID string, errMsg error, opts ...CallOption) error {
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -113,13 +264,13 @@ func (ia *Invocation) Fail(invocationID string, errMsg error) error {
 		return err
 	}
 	event.Hints = &fes.EventHints{Completed: true}
-	return ia.es.Append(event)
+	return appendEvent(ia.es, event, opts)
 }
 
 // AddTask provides functionality to add a task to a specific invocation (instead of a workflow).
 // This allows users to modify specific invocations (see dynamic API).
 // The error can be a validate.Err, proto marshall error, or a fes error.
-func (ia *Invocation) AddTask(invocationID string, task *types.Task) error {
+func (ia *Invocation) AddTask(invocationID string, task *types.Task, opts ...CallOption) error {
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -134,5 +285,5 @@ func (ia *Invocation) AddTask(invocationID string, task *types.Task) error {
 	if err != nil {
 		return err
 	}
-	return ia.es.Append(event)
+	return appendEvent(ia.es, event, opts)
 }
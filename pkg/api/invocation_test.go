@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocationRetry(t *testing.T) {
+	es := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(es)
+	runtime := mock.NewRuntime()
+	runtime.Functions["succeed"] = func(spec *types.TaskInvocationSpec) (*types.TypedValue, error) {
+		return typedvalues.MustParse("done"), nil
+	}
+	runtime.Functions["fail"] = func(spec *types.TaskInvocationSpec) (*types.TypedValue, error) {
+		return nil, errors.New("failed")
+	}
+	taskAPI := NewTaskAPI(map[string]fnenv.Runtime{
+		"mock": runtime,
+	}, es, nil)
+
+	wfiID, err := invocationAPI.Invoke(&types.WorkflowInvocationSpec{WorkflowId: "wf-1"})
+	assert.NoError(t, err)
+	newSpec := func(taskID string, fn string) *types.TaskInvocationSpec {
+		return types.NewTaskInvocationSpec(wfiID, taskID, types.FnRef{
+			Runtime: "mock",
+			ID:      fn,
+		})
+	}
+	_, err = taskAPI.Invoke(newSpec("succeeded", "succeed"))
+	assert.NoError(t, err)
+	_, err = taskAPI.Invoke(newSpec("failed", "fail"))
+	assert.NoError(t, err)
+	// The invocation can fail while a task is still in progress, for example due to the timeout of the invocation.
+	_, err = taskAPI.Start(newSpec("running", "succeed"))
+	assert.NoError(t, err)
+
+	// Only failed invocations can be retried.
+	err = invocationAPI.Retry(wfiID)
+	assert.IsType(t, validate.Error{}, err)
+	err = invocationAPI.Fail(wfiID, errors.New("timed out"))
+	assert.NoError(t, err)
+	err = invocationAPI.Retry(wfiID)
+	assert.NoError(t, err)
+	err = invocationAPI.Retry(wfiID)
+	assert.IsType(t, validate.Error{}, err)
+
+	events, err := es.Get(*aggregates.NewWorkflowInvocationAggregate(wfiID))
+	assert.NoError(t, err)
+	wfi := aggregates.NewWorkflowInvocation(wfiID)
+	err = fes.Project(wfi, events...)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, wfi.GetStatus().GetStatus())
+	assert.Nil(t, wfi.GetStatus().GetError())
+
+	// The succeeded task is reused, whereas the other tasks are reset to be scheduled again.
+	tasks := wfi.GetStatus().GetTasks()
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, tasks["succeeded"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_UNKNOWN, tasks["failed"].GetStatus().GetStatus())
+	assert.Nil(t, tasks["failed"].GetStatus().GetError())
+	assert.Equal(t, types.TaskInvocationStatus_UNKNOWN, tasks["running"].GetStatus().GetStatus())
+	for id, task := range tasks {
+		assert.EqualValues(t, 1, task.GetStatus().GetAttempts(), id)
+	}
+}
+
+func TestInvocationRetry_TaskInProgress(t *testing.T) {
+	es := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(es)
+	runtime := mock.NewRuntime()
+	runtime.Functions["succeed"] = func(spec *types.TaskInvocationSpec) (*types.TypedValue, error) {
+		return typedvalues.MustParse("done"), nil
+	}
+	taskAPI := NewTaskAPI(map[string]fnenv.Runtime{
+		"mock": runtime,
+	}, es, nil)
+	getTask := func(wfiID string) *types.TaskInvocation {
+		events, err := es.Get(*aggregates.NewWorkflowInvocationAggregate(wfiID))
+		assert.NoError(t, err)
+		wfi := aggregates.NewWorkflowInvocation(wfiID)
+		assert.NoError(t, fes.Project(wfi, events...))
+		return wfi.GetStatus().GetTasks()["running"]
+	}
+
+	wfiID, err := invocationAPI.Invoke(&types.WorkflowInvocationSpec{WorkflowId: "wf-1"})
+	assert.NoError(t, err)
+	spec := types.NewTaskInvocationSpec(wfiID, "running", types.FnRef{
+		Runtime: "mock",
+		ID:      "succeed",
+	})
+	task, err := taskAPI.Start(spec, WithExpectedVersion(0))
+	assert.NoError(t, err)
+	err = invocationAPI.Fail(wfiID, errors.New("timed out"))
+	assert.NoError(t, err)
+	err = invocationAPI.Retry(wfiID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_UNKNOWN, getTask(wfiID).GetStatus().GetStatus())
+
+	// The result of the abandoned attempt should be rejected.
+	_, err = taskAPI.Run(task, WithExpectedVersion(0))
+	assert.True(t, fes.IsConflict(err))
+
+	// The task should be restarted at the version derived from its status.
+	version := TaskVersion(getTask(wfiID))
+	assert.EqualValues(t, 2, version)
+	_, err = taskAPI.Invoke(spec, WithExpectedVersion(version))
+	assert.NoError(t, err)
+	restarted := getTask(wfiID)
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, restarted.GetStatus().GetStatus())
+	assert.EqualValues(t, 2, restarted.GetStatus().GetAttempts())
+}
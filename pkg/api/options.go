@@ -0,0 +1,39 @@
+package api
+
+import (
+	"github.com/fission/fission-workflows/pkg/fes"
+)
+
+// CallOption configures an individual API call.
+type CallOption func(opts *callOptions)
+
+type callOptions struct {
+	expectedVersion *uint64
+}
+
+// WithExpectedVersion ensures that the event of the call is only appended if the aggregate is still at the expected
+// version, i.e. the version of the entity that the caller based its decision on. If the aggregate has been modified
+// in the meantime, the call fails with a fes.ConflictError, after which the caller should re-read the entity and
+// retry.
+func WithExpectedVersion(version uint64) CallOption {
+	return func(opts *callOptions) {
+		opts.expectedVersion = &version
+	}
+}
+
+func parseCallOptions(opts []CallOption) *callOptions {
+	parsed := &callOptions{}
+	for _, opt := range opts {
+		opt(parsed)
+	}
+	return parsed
+}
+
+// appendEvent appends the event to the event store, taking into account the provided call options.
+func appendEvent(es fes.Backend, event *fes.Event, opts []CallOption) error {
+	cfg := parseCallOptions(opts)
+	if cfg.expectedVersion != nil {
+		return fes.AppendIfVersion(es, event, *cfg.expectedVersion)
+	}
+	return es.Append(event)
+}
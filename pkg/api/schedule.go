@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Schedule contains the API functionality for controlling schedules, which invoke workflows periodically.
+//
+// The calls modifying an existing schedule accept CallOptions, such as WithExpectedVersion to guard against
+// concurrent modifications of the schedule.
+type Schedule struct {
+	es fes.Backend
+}
+
+// NewScheduleAPI creates the Schedule API.
+func NewScheduleAPI(esClient fes.Backend) *Schedule {
+	return &Schedule{esClient}
+}
+
+// Create creates a new schedule based on the provided spec. The first run of the schedule is the first run after the
+// creation of the schedule.
+// The function either returns the id of the schedule or an error.
+// The error can be a validate.Err, proto marshall error, or a fes error.
+func (sa *Schedule) Create(spec *types.ScheduleSpec) (string, error) {
+	err := validate.ScheduleSpec(spec)
+	if err != nil {
+		return "", err
+	}
+
+	id := fmt.Sprintf("sc-%s", util.UID())
+	event, err := fes.NewEvent(*aggregates.NewScheduleAggregate(id), &events.ScheduleCreated{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	// The schedule is new, so there should not be any existing events for it.
+	err = fes.AppendIfVersion(sa.es, event, 0)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Update replaces the spec of the schedule. The runs that were due before the update are discarded; the next run is
+// the first run of the new spec after the update.
+func (sa *Schedule) Update(scheduleID string, spec *types.ScheduleSpec, opts ...CallOption) error {
+	if len(scheduleID) == 0 {
+		return validate.NewError("scheduleID", errors.New("id should not be empty"))
+	}
+	err := validate.ScheduleSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	return sa.appendEvent(scheduleID, &events.ScheduleUpdated{
+		Spec: spec,
+	}, opts)
+}
+
+// Delete marks a schedule as deleted, which stops it from invoking the workflow. Invocations that have already been
+// started by the schedule are not affected.
+func (sa *Schedule) Delete(scheduleID string, opts ...CallOption) error {
+	if len(scheduleID) == 0 {
+		return validate.NewError("scheduleID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(*aggregates.NewScheduleAggregate(scheduleID), &events.ScheduleDeleted{})
+	if err != nil {
+		return err
+	}
+	event.Hints = &fes.EventHints{Completed: true}
+	return appendEvent(sa.es, event, opts)
+}
+
+// Invoked records that the run of the schedule at scheduledAt invoked the workflow.
+func (sa *Schedule) Invoked(scheduleID string, scheduledAt time.Time, invocationID string,
+	opts ...CallOption) error {
+	ts, err := ptypes.TimestampProto(scheduledAt)
+	if err != nil {
+		return err
+	}
+	return sa.appendEvent(scheduleID, &events.ScheduleInvoked{
+		ScheduledAt:  ts,
+		InvocationId: invocationID,
+	}, opts)
+}
+
+// Missed records that the runs of the schedule, up to and including the run at scheduledAt, did not invoke the
+// workflow for the provided reason.
+func (sa *Schedule) Missed(scheduleID string, scheduledAt time.Time, count int64, reason string,
+	opts ...CallOption) error {
+	ts, err := ptypes.TimestampProto(scheduledAt)
+	if err != nil {
+		return err
+	}
+	return sa.appendEvent(scheduleID, &events.ScheduleMissed{
+		ScheduledAt: ts,
+		Count:       count,
+		Reason:      reason,
+	}, opts)
+}
+
+func (sa *Schedule) appendEvent(scheduleID string, msg proto.Message, opts []CallOption) error {
+	event, err := fes.NewEvent(*aggregates.NewScheduleAggregate(scheduleID), msg)
+	if err != nil {
+		return err
+	}
+	return appendEvent(sa.es, event, opts)
+}
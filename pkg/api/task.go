@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api/aggregates"
 	"github.com/fission/fission-workflows/pkg/api/events"
@@ -16,6 +19,9 @@ import (
 
 // TODO move events here
 
+// asyncStatusPollInterval is the interval at which the status of asynchronous function invocations is polled.
+const asyncStatusPollInterval = 100 * time.Millisecond
+
 // Task contains the API functionality for controlling the lifecycle of individual tasks.
 // This includes starting, stopping and completing tasks.
 type Task struct {
@@ -34,15 +40,29 @@ func NewTaskAPI(runtime map[string]fnenv.Runtime, esClient fes.Backend, api *Dyn
 }
 
 // Invoke starts the execution of a task, changing the state of the task into RUNNING.
-// Currently it executes the underlying function synchronously and manage the execution until completion.
-// TODO make asynchronous
-func (ap *Task) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocation, error) {
+// Currently it executes the underlying function synchronously and manage the execution until completion. If the spec
+// contains a timeout, the task fails with a timeout error once the function has not completed within the timeout.
+//
+// The provided CallOptions apply to the start of the task. For example, with WithExpectedVersion the function is
+// only executed if the task has not been started (or otherwise modified) concurrently. In that case, the result of
+// the function is only recorded if the task has not been started again in the meantime, such as by a retry.
+func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*types.TaskInvocation, error) {
+	task, err := ap.Start(spec, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ap.Run(task, opts...)
+}
+
+// Start records the start of the execution of a task, changing the state of the task into RUNNING, without executing
+// the underlying function. The function should be executed by calling Run with the returned task.
+func (ap *Task) Start(spec *types.TaskInvocationSpec, opts ...CallOption) (*types.TaskInvocation, error) {
 	err := validate.TaskInvocationSpec(spec)
 	if err != nil {
 		return nil, err
 	}
 
-	taskID := spec.TaskId // assumption: 1 task == 1 TaskInvocation (How to deal with retries? Same invocation?)
+	taskID := spec.TaskId // assumption: 1 task == 1 TaskInvocation, retries are recorded as new attempts of the task
 	task := &types.TaskInvocation{
 		Metadata: &types.ObjectMetadata{
 			Id:        taskID,
@@ -55,15 +75,29 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocation, e
 	event, err := fes.NewEvent(*aggregates.NewTaskInvocationAggregate(taskID), &events.TaskStarted{
 		Spec: spec,
 	})
-	event.Parent = aggregate
 	if err != nil {
 		return nil, err
 	}
+	event.Parent = aggregate
+	err = appendEvent(ap.es, event, opts)
 	if err != nil {
 		return nil, err
 	}
+	return task, nil
+}
 
-	fnResult, err := ap.runtime[spec.FnRef.Runtime].Invoke(spec)
+// Run executes the function of a task that has been started with Start, and records the result of the execution. It
+// should be called with the same CallOptions as Start.
+func (ap *Task) Run(task *types.TaskInvocation, opts ...CallOption) (*types.TaskInvocation, error) {
+	spec := task.GetSpec()
+	taskID := task.ID()
+	aggregate := aggregates.NewWorkflowInvocationAggregate(spec.InvocationId)
+	var resultOpts []CallOption
+	if cfg := parseCallOptions(opts); cfg.expectedVersion != nil {
+		resultOpts = append(resultOpts, WithExpectedVersion(*cfg.expectedVersion+1))
+	}
+
+	fnResult, err := ap.invokeRuntime(spec)
 	if fnResult == nil && err == nil {
 		err = errors.New("function crashed")
 	}
@@ -73,7 +107,10 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocation, e
 			WithField("wi", spec.InvocationId).
 			WithField("task", spec.TaskId).
 			Infof("Failed to invoke task: %v", err)
-		esErr := ap.Fail(spec.InvocationId, taskID, err.Error())
+		esErr := ap.Fail(spec.InvocationId, taskID, &types.Error{
+			Code:    types.ErrorCodeRuntime,
+			Message: err.Error(),
+		}, resultOpts...)
 		if esErr != nil {
 			return nil, esErr
 		}
@@ -94,16 +131,24 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocation, e
 	}
 
 	if fnResult.Status == types.TaskInvocationStatus_SUCCEEDED {
-		event, err := fes.NewEvent(*aggregates.NewTaskInvocationAggregate(taskID), &events.TaskSucceeded{
+		var event *fes.Event
+		event, err = fes.NewEvent(*aggregates.NewTaskInvocationAggregate(taskID), &events.TaskSucceeded{
 			Result: fnResult,
 		})
 		if err != nil {
 			return nil, err
 		}
 		event.Parent = aggregate
-		err = ap.es.Append(event)
+		err = appendEvent(ap.es, event, resultOpts)
 	} else {
-		err = ap.Fail(spec.InvocationId, taskID, fnResult.Error.GetMessage())
+		taskErr := &types.Error{
+			Code:    fnResult.GetError().GetCode(),
+			Message: fnResult.GetError().GetMessage(),
+		}
+		if len(taskErr.Code) == 0 {
+			taskErr.Code = types.ErrorCodeFunction
+		}
+		err = ap.Fail(spec.InvocationId, taskID, taskErr, resultOpts...)
 	}
 	if err != nil {
 		return nil, err
@@ -113,9 +158,97 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocation, e
 	return task, nil
 }
 
+// invokeRuntime executes the function of the task in its runtime. If the task has a timeout, the execution is
+// abandoned once the timeout has passed, resulting in a failed task with a timeout error.
+//
+// Runtimes that implement AsyncRuntime or ContextRuntime abort the execution after the timeout. The execution in other
+// runtimes cannot be aborted; it keeps running in the background until it returns, after which its result is
+// discarded.
+func (ap *Task) invokeRuntime(spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus, error) {
+	runtime, ok := ap.runtime[spec.FnRef.Runtime]
+	if !ok {
+		return nil, fmt.Errorf("%v: '%v'", fnenv.ErrInvalidRuntime, spec.FnRef.Runtime)
+	}
+	timeout, err := ptypes.Duration(spec.GetTimeout())
+	if err != nil || timeout <= 0 {
+		return runtime.Invoke(spec)
+	}
+
+	// Runtimes that support asynchronous invocations allow the execution to be canceled after the timeout.
+	if asyncRuntime, ok := runtime.(fnenv.AsyncRuntime); ok {
+		return invokeAsyncRuntime(asyncRuntime, spec, timeout)
+	}
+	if ctxRuntime, ok := runtime.(fnenv.ContextRuntime); ok {
+		ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+		defer cancelFn()
+		status, err := ctxRuntime.InvokeContext(ctx, spec)
+		if ctx.Err() == context.DeadlineExceeded && (err != nil || !status.Finished()) {
+			return timedOut(timeout), nil
+		}
+		return status, err
+	}
+
+	type result struct {
+		status *types.TaskInvocationStatus
+		err    error
+	}
+	results := make(chan result, 1)
+	go func() {
+		status, err := runtime.Invoke(spec)
+		results <- result{status, err}
+	}()
+	select {
+	case r := <-results:
+		return r.status, r.err
+	case <-time.After(timeout):
+		return timedOut(timeout), nil
+	}
+}
+
+func invokeAsyncRuntime(runtime fnenv.AsyncRuntime, spec *types.TaskInvocationSpec,
+	timeout time.Duration) (*types.TaskInvocationStatus, error) {
+	asyncID, err := runtime.InvokeAsync(spec)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(asyncStatusPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := runtime.Status(asyncID)
+		if err != nil {
+			return nil, err
+		}
+		if status != nil && status.Finished() {
+			return status, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			if err := runtime.Cancel(asyncID); err != nil {
+				logrus.WithField("task", spec.TaskId).
+					WithField("wi", spec.InvocationId).
+					Warnf("Failed to cancel timed out function invocation %v: %v", asyncID, err)
+			}
+			return timedOut(timeout), nil
+		}
+	}
+}
+
+func timedOut(timeout time.Duration) *types.TaskInvocationStatus {
+	return &types.TaskInvocationStatus{
+		Status:    types.TaskInvocationStatus_FAILED,
+		UpdatedAt: ptypes.TimestampNow(),
+		Error: &types.Error{
+			Code:    types.ErrorCodeTimeout,
+			Message: fmt.Sprintf("task timed out after %v", timeout),
+		},
+	}
+}
+
 // Fail forces the failure of a task. This turns the state of a task into FAILED.
 // If the API fails to append the event to the event store, it will return an error.
-func (ap *Task) Fail(invocationID string, taskID string, errMsg string) error {
+func (ap *Task) Fail(invocationID string, taskID string, taskErr *types.Error, opts ...CallOption) error {
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -124,11 +257,43 @@ func (ap *Task) Fail(invocationID string, taskID string, errMsg string) error {
 	}
 
 	event, err := fes.NewEvent(*aggregates.NewTaskInvocationAggregate(taskID), &events.TaskFailed{
-		Error: &types.Error{Message: errMsg},
+		Error: taskErr,
 	})
 	if err != nil {
 		return err
 	}
 	event.Parent = aggregates.NewWorkflowInvocationAggregate(invocationID)
-	return ap.es.Append(event)
+	return appendEvent(ap.es, event, opts)
+}
+
+// Skip skips a task, which turns the state of the task into SKIPPED. If the task is in progress, the task is
+// abandoned: its result will not be recorded.
+// If the API fails to append the event to the event store, it will return an error.
+func (ap *Task) Skip(invocationID string, taskID string, opts ...CallOption) error {
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(*aggregates.NewTaskInvocationAggregate(taskID), &events.TaskSkipped{})
+	if err != nil {
+		return err
+	}
+	event.Parent = aggregates.NewWorkflowInvocationAggregate(invocationID)
+	return appendEvent(ap.es, event, opts)
+}
+
+// TaskVersion returns the version of the task, which is the number of events of the task. Each attempt of the task
+// consists of the start of the task, followed by the result once the attempt has finished.
+func TaskVersion(task *types.TaskInvocation) uint64 {
+	attempts := uint64(task.GetStatus().GetAttempts())
+	if attempts == 0 {
+		return 0
+	}
+	if task.GetStatus().GetStatus() == types.TaskInvocationStatus_IN_PROGRESS {
+		return 2*attempts - 1
+	}
+	return 2 * attempts
 }
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskInvokeTimeout(t *testing.T) {
+	runtime := mock.NewRuntime()
+	runtime.ManualExecution = true
+	runtime.Functions["hanging"] = func(spec *types.TaskInvocationSpec) (*types.TypedValue, error) {
+		return nil, nil
+	}
+	taskAPI := NewTaskAPI(map[string]fnenv.Runtime{
+		"mock": runtime,
+	}, mem.NewBackend(), nil)
+
+	spec := types.NewTaskInvocationSpec("wi-1", "task-1", types.FnRef{
+		Runtime: "mock",
+		ID:      "hanging",
+	})
+	spec.Timeout = ptypes.DurationProto(50 * time.Millisecond)
+
+	// The task should fail with a timeout error, and the invocation in the async runtime should be canceled.
+	task, err := taskAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, task.GetStatus().GetStatus())
+	assert.Equal(t, types.ErrorCodeTimeout, task.GetStatus().GetError().GetCode())
+	assert.Len(t, runtime.AsyncResults, 1)
+	for _, result := range runtime.AsyncResults {
+		assert.Equal(t, types.TaskInvocationStatus_ABORTED, result.GetStatus().GetStatus())
+	}
+}
+
+// contextRuntime is a runtime that blocks until the context of the invocation is done.
+type contextRuntime struct {
+	aborted chan struct{}
+}
+
+func (rt *contextRuntime) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus, error) {
+	return rt.InvokeContext(context.Background(), spec)
+}
+
+func (rt *contextRuntime) InvokeContext(ctx context.Context, spec *types.TaskInvocationSpec) (
+	*types.TaskInvocationStatus, error) {
+	<-ctx.Done()
+	close(rt.aborted)
+	return nil, ctx.Err()
+}
+
+func TestTaskInvokeTimeout_Context(t *testing.T) {
+	runtime := &contextRuntime{aborted: make(chan struct{})}
+	taskAPI := NewTaskAPI(map[string]fnenv.Runtime{
+		"context": runtime,
+	}, mem.NewBackend(), nil)
+
+	spec := types.NewTaskInvocationSpec("wi-1", "task-1", types.FnRef{
+		Runtime: "context",
+		ID:      "hanging",
+	})
+	spec.Timeout = ptypes.DurationProto(50 * time.Millisecond)
+
+	// The task should fail with a timeout error, after the execution in the runtime has been aborted.
+	task, err := taskAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, task.GetStatus().GetStatus())
+	assert.Equal(t, types.ErrorCodeTimeout, task.GetStatus().GetError().GetCode())
+	select {
+	case <-runtime.aborted:
+	default:
+		t.Error("the execution in the runtime should have been aborted")
+	}
+}
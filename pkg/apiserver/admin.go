@@ -5,19 +5,51 @@ import (
 	"github.com/fission/fission-workflows/pkg/version"
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Admin is responsible for all administrative functions related to managing the workflow engine.
 type Admin struct {
-	metaCtrl controller.MetaController
+	metaCtrl *controller.MetaController
+}
+
+// NewAdmin creates the admin API server. The metacontroller is nil if no controllers run in this process.
+func NewAdmin(metaCtrl *controller.MetaController) *Admin {
+	return &Admin{
+		metaCtrl: metaCtrl,
+	}
 }
 
 func (as *Admin) Status(ctx context.Context, _ *empty.Empty) (*Health, error) {
+	if as.metaCtrl != nil && as.metaCtrl.Halted() {
+		return &Health{
+			Status: "HALTED",
+		}, nil
+	}
 	return &Health{
 		Status: "OK!",
 	}, nil
 }
 
+// Halt stops the controllers from evaluating workflows and invocations until the engine is resumed.
+func (as *Admin) Halt(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	if as.metaCtrl == nil {
+		return nil, status.Error(codes.Unavailable, "no controllers are running in this instance")
+	}
+	as.metaCtrl.Halt()
+	return &empty.Empty{}, nil
+}
+
+// Resume resumes the evaluations of the controllers after the engine has been halted.
+func (as *Admin) Resume(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	if as.metaCtrl == nil {
+		return nil, status.Error(codes.Unavailable, "no controllers are running in this instance")
+	}
+	as.metaCtrl.Resume()
+	return &empty.Empty{}, nil
+}
+
 func (as *Admin) Version(ctx context.Context, _ *empty.Empty) (*version.Info, error) {
 	v := version.VersionInfo()
 	return &v, nil
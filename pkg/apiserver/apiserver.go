@@ -4,6 +4,7 @@ Package apiserver contains all request handlers for gRPC and HTTP servers.
 It has these top-level request handlers:
 	Admin	   - Administrative functionality related to managing the workflow engine.
 	Invocation - Functionality related to managing invocations.
+	Schedule   - Functionality related to managing schedules.
 	Workflow   - functionality related to managing workflows.
 
 The purpose of this package is purely to provide handlers to gRPC and HTTP servers. Therefore,
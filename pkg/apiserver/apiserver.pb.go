@@ -12,8 +12,13 @@ It has these top-level messages:
 	SearchWorkflowResponse
 	InvocationListQuery
 	WorkflowInvocationIdentifier
+	InvocationGetAtQuery
+	InvocationWatchQuery
 	WorkflowInvocationList
 	Health
+	ScheduleIdentifier
+	ScheduleUpdateRequest
+	ScheduleList
 */
 package apiserver
 
@@ -22,7 +27,9 @@ import fmt "fmt"
 import math "math"
 import fission_workflows_types "github.com/fission/fission-workflows/pkg/types"
 import fission_workflows_version "github.com/fission/fission-workflows/pkg/version"
+import fission_workflows_eventstore "github.com/fission/fission-workflows/pkg/fes"
 import google_protobuf1 "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf2 "github.com/golang/protobuf/ptypes/timestamp"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
 
 import (
@@ -75,6 +82,13 @@ func (m *SearchWorkflowResponse) GetWorkflows() []string {
 
 type InvocationListQuery struct {
 	Workflows []string `protobuf:"bytes,1,rep,name=workflows" json:"workflows,omitempty"`
+	// Only include the invocations that currently have one of the statuses.
+	Statuses []fission_workflows_types.WorkflowInvocationStatus_Status `protobuf:"varint,2,rep,packed,name=statuses,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"statuses,omitempty"`
+	// Only include the invocations that have been created at or after createdAfter and before createdBefore.
+	CreatedAfter  *google_protobuf2.Timestamp `protobuf:"bytes,3,opt,name=createdAfter" json:"createdAfter,omitempty"`
+	CreatedBefore *google_protobuf2.Timestamp `protobuf:"bytes,4,opt,name=createdBefore" json:"createdBefore,omitempty"`
+	// Only include the invocations that have been invoked by one of the parent invocations.
+	Parents []string `protobuf:"bytes,5,rep,name=parents" json:"parents,omitempty"`
 }
 
 func (m *InvocationListQuery) Reset()                    { *m = InvocationListQuery{} }
@@ -89,6 +103,34 @@ func (m *InvocationListQuery) GetWorkflows() []string {
 	return nil
 }
 
+func (m *InvocationListQuery) GetStatuses() []fission_workflows_types.WorkflowInvocationStatus_Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *InvocationListQuery) GetCreatedAfter() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.CreatedAfter
+	}
+	return nil
+}
+
+func (m *InvocationListQuery) GetCreatedBefore() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return nil
+}
+
+func (m *InvocationListQuery) GetParents() []string {
+	if m != nil {
+		return m.Parents
+	}
+	return nil
+}
+
 type WorkflowInvocationIdentifier struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 }
@@ -105,6 +147,75 @@ func (m *WorkflowInvocationIdentifier) GetId() string {
 	return ""
 }
 
+type InvocationGetAtQuery struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Only replay the events that occurred at or before the timestamp.
+	Timestamp *google_protobuf2.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Only replay the first seq events of the invocation, including the events of its tasks.
+	Seq uint64 `protobuf:"varint,3,opt,name=seq" json:"seq,omitempty"`
+}
+
+func (m *InvocationGetAtQuery) Reset()                    { *m = InvocationGetAtQuery{} }
+func (m *InvocationGetAtQuery) String() string            { return proto.CompactTextString(m) }
+func (*InvocationGetAtQuery) ProtoMessage()               {}
+func (*InvocationGetAtQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *InvocationGetAtQuery) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *InvocationGetAtQuery) GetTimestamp() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *InvocationGetAtQuery) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+type InvocationWatchQuery struct {
+	// Only include the events of the invocations (including the events of their tasks).
+	Invocations []string `protobuf:"bytes,1,rep,name=invocations" json:"invocations,omitempty"`
+	// Only include the events of the invocations of the workflows.
+	Workflows []string `protobuf:"bytes,2,rep,name=workflows" json:"workflows,omitempty"`
+	// Only include the events of the types, such as InvocationCompleted or TaskFailed.
+	EventTypes []string `protobuf:"bytes,3,rep,name=eventTypes" json:"eventTypes,omitempty"`
+}
+
+func (m *InvocationWatchQuery) Reset()                    { *m = InvocationWatchQuery{} }
+func (m *InvocationWatchQuery) String() string            { return proto.CompactTextString(m) }
+func (*InvocationWatchQuery) ProtoMessage()               {}
+func (*InvocationWatchQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *InvocationWatchQuery) GetInvocations() []string {
+	if m != nil {
+		return m.Invocations
+	}
+	return nil
+}
+
+func (m *InvocationWatchQuery) GetWorkflows() []string {
+	if m != nil {
+		return m.Workflows
+	}
+	return nil
+}
+
+func (m *InvocationWatchQuery) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
 type WorkflowInvocationList struct {
 	Invocations []string `protobuf:"bytes,1,rep,name=invocations" json:"invocations,omitempty"`
 }
@@ -112,7 +223,7 @@ type WorkflowInvocationList struct {
 func (m *WorkflowInvocationList) Reset()                    { *m = WorkflowInvocationList{} }
 func (m *WorkflowInvocationList) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocationList) ProtoMessage()               {}
-func (*WorkflowInvocationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*WorkflowInvocationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *WorkflowInvocationList) GetInvocations() []string {
 	if m != nil {
@@ -128,7 +239,7 @@ type Health struct {
 func (m *Health) Reset()                    { *m = Health{} }
 func (m *Health) String() string            { return proto.CompactTextString(m) }
 func (*Health) ProtoMessage()               {}
-func (*Health) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*Health) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *Health) GetStatus() string {
 	if m != nil {
@@ -137,13 +248,74 @@ func (m *Health) GetStatus() string {
 	return ""
 }
 
+type ScheduleIdentifier struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ScheduleIdentifier) Reset()                    { *m = ScheduleIdentifier{} }
+func (m *ScheduleIdentifier) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleIdentifier) ProtoMessage()               {}
+func (*ScheduleIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ScheduleIdentifier) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ScheduleUpdateRequest struct {
+	Id   string                                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Spec *fission_workflows_types.ScheduleSpec `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *ScheduleUpdateRequest) Reset()                    { *m = ScheduleUpdateRequest{} }
+func (m *ScheduleUpdateRequest) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleUpdateRequest) ProtoMessage()               {}
+func (*ScheduleUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ScheduleUpdateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ScheduleUpdateRequest) GetSpec() *fission_workflows_types.ScheduleSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type ScheduleList struct {
+	Schedules []string `protobuf:"bytes,1,rep,name=schedules" json:"schedules,omitempty"`
+}
+
+func (m *ScheduleList) Reset()                    { *m = ScheduleList{} }
+func (m *ScheduleList) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleList) ProtoMessage()               {}
+func (*ScheduleList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ScheduleList) GetSchedules() []string {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*WorkflowIdentifier)(nil), "fission.workflows.apiserver.WorkflowIdentifier")
 	proto.RegisterType((*SearchWorkflowResponse)(nil), "fission.workflows.apiserver.SearchWorkflowResponse")
 	proto.RegisterType((*InvocationListQuery)(nil), "fission.workflows.apiserver.InvocationListQuery")
 	proto.RegisterType((*WorkflowInvocationIdentifier)(nil), "fission.workflows.apiserver.WorkflowInvocationIdentifier")
+	proto.RegisterType((*InvocationGetAtQuery)(nil), "fission.workflows.apiserver.InvocationGetAtQuery")
+	proto.RegisterType((*InvocationWatchQuery)(nil), "fission.workflows.apiserver.InvocationWatchQuery")
 	proto.RegisterType((*WorkflowInvocationList)(nil), "fission.workflows.apiserver.WorkflowInvocationList")
 	proto.RegisterType((*Health)(nil), "fission.workflows.apiserver.Health")
+	proto.RegisterType((*ScheduleIdentifier)(nil), "fission.workflows.apiserver.ScheduleIdentifier")
+	proto.RegisterType((*ScheduleUpdateRequest)(nil), "fission.workflows.apiserver.ScheduleUpdateRequest")
+	proto.RegisterType((*ScheduleList)(nil), "fission.workflows.apiserver.ScheduleList")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -364,13 +536,38 @@ type WorkflowInvocationAPIClient interface {
 	// In case that an invocation already is canceled, has failed or has completed, nothing happens.
 	// In case that an invocation does not exist a HTTP 404 error status is returned.
 	Cancel(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Pause a workflow invocation
+	//
+	// A paused invocation does not start any new tasks, but tasks that are already running are allowed to finish.
+	// In case that an invocation is not in progress, a HTTP 400 error status is returned.
+	Pause(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Resume a paused workflow invocation
+	//
+	// In case that an invocation is not paused, a HTTP 400 error status is returned.
+	Resume(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Retry a failed workflow invocation
+	//
+	// The invocation is reopened, reusing the outputs of the tasks that succeeded and scheduling the other tasks again.
+	// In case that an invocation has not failed, a HTTP 400 error status is returned.
+	Retry(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
 	List(ctx context.Context, in *InvocationListQuery, opts ...grpc.CallOption) (*WorkflowInvocationList, error)
 	// Get the specification and status of a workflow invocation
 	//
 	// Get returns three different aspects of the workflow invocation, namely the spec (specification), status and logs.
 	// To lighten the request load, consider using a more specific request.
 	Get(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*fission_workflows_types.WorkflowInvocation, error)
+	// Get the workflow invocation as it was at a specific point in its history
+	//
+	// GetAt reconstructs the invocation by replaying the events of the invocation up to the provided point, which
+	// can either be a timestamp or a number of events. In case that the invocation does not exist a HTTP 404 error
+	// status is returned.
+	GetAt(ctx context.Context, in *InvocationGetAtQuery, opts ...grpc.CallOption) (*fission_workflows_types.WorkflowInvocation, error)
 	Validate(ctx context.Context, in *fission_workflows_types.WorkflowInvocationSpec, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Watch the lifecycle events of invocations and their tasks
+	//
+	// Watch streams the events that are appended after the start of the watch. Over HTTP, the events are available as
+	// server-sent events at /invocation/events, which accepts the fields of the query as query parameters.
+	Watch(ctx context.Context, in *InvocationWatchQuery, opts ...grpc.CallOption) (WorkflowInvocationAPI_WatchClient, error)
 }
 
 type workflowInvocationAPIClient struct {
@@ -408,6 +605,33 @@ func (c *workflowInvocationAPIClient) Cancel(ctx context.Context, in *WorkflowIn
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) Pause(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Pause", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Resume(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Resume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Retry(ctx context.Context, in *WorkflowInvocationIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Retry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workflowInvocationAPIClient) List(ctx context.Context, in *InvocationListQuery, opts ...grpc.CallOption) (*WorkflowInvocationList, error) {
 	out := new(WorkflowInvocationList)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/List", in, out, c.cc, opts...)
@@ -426,6 +650,15 @@ func (c *workflowInvocationAPIClient) Get(ctx context.Context, in *WorkflowInvoc
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) GetAt(ctx context.Context, in *InvocationGetAtQuery, opts ...grpc.CallOption) (*fission_workflows_types.WorkflowInvocation, error) {
+	out := new(fission_workflows_types.WorkflowInvocation)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/GetAt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workflowInvocationAPIClient) Validate(ctx context.Context, in *fission_workflows_types.WorkflowInvocationSpec, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
 	out := new(google_protobuf1.Empty)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Validate", in, out, c.cc, opts...)
@@ -435,6 +668,38 @@ func (c *workflowInvocationAPIClient) Validate(ctx context.Context, in *fission_
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) Watch(ctx context.Context, in *InvocationWatchQuery, opts ...grpc.CallOption) (WorkflowInvocationAPI_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_WorkflowInvocationAPI_serviceDesc.Streams[0], c.cc, "/fission.workflows.apiserver.WorkflowInvocationAPI/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workflowInvocationAPIWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkflowInvocationAPI_WatchClient interface {
+	Recv() (*fission_workflows_eventstore.Event, error)
+	grpc.ClientStream
+}
+
+type workflowInvocationAPIWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *workflowInvocationAPIWatchClient) Recv() (*fission_workflows_eventstore.Event, error) {
+	m := new(fission_workflows_eventstore.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for WorkflowInvocationAPI service
 
 type WorkflowInvocationAPIServer interface {
@@ -449,13 +714,38 @@ type WorkflowInvocationAPIServer interface {
 	// In case that an invocation already is canceled, has failed or has completed, nothing happens.
 	// In case that an invocation does not exist a HTTP 404 error status is returned.
 	Cancel(context.Context, *WorkflowInvocationIdentifier) (*google_protobuf1.Empty, error)
+	// Pause a workflow invocation
+	//
+	// A paused invocation does not start any new tasks, but tasks that are already running are allowed to finish.
+	// In case that an invocation is not in progress, a HTTP 400 error status is returned.
+	Pause(context.Context, *WorkflowInvocationIdentifier) (*google_protobuf1.Empty, error)
+	// Resume a paused workflow invocation
+	//
+	// In case that an invocation is not paused, a HTTP 400 error status is returned.
+	Resume(context.Context, *WorkflowInvocationIdentifier) (*google_protobuf1.Empty, error)
+	// Retry a failed workflow invocation
+	//
+	// The invocation is reopened, reusing the outputs of the tasks that succeeded and scheduling the other tasks again.
+	// In case that an invocation has not failed, a HTTP 400 error status is returned.
+	Retry(context.Context, *WorkflowInvocationIdentifier) (*google_protobuf1.Empty, error)
 	List(context.Context, *InvocationListQuery) (*WorkflowInvocationList, error)
 	// Get the specification and status of a workflow invocation
 	//
 	// Get returns three different aspects of the workflow invocation, namely the spec (specification), status and logs.
 	// To lighten the request load, consider using a more specific request.
 	Get(context.Context, *WorkflowInvocationIdentifier) (*fission_workflows_types.WorkflowInvocation, error)
+	// Get the workflow invocation as it was at a specific point in its history
+	//
+	// GetAt reconstructs the invocation by replaying the events of the invocation up to the provided point, which
+	// can either be a timestamp or a number of events. In case that the invocation does not exist a HTTP 404 error
+	// status is returned.
+	GetAt(context.Context, *InvocationGetAtQuery) (*fission_workflows_types.WorkflowInvocation, error)
 	Validate(context.Context, *fission_workflows_types.WorkflowInvocationSpec) (*google_protobuf1.Empty, error)
+	// Watch the lifecycle events of invocations and their tasks
+	//
+	// Watch streams the events that are appended after the start of the watch. Over HTTP, the events are available as
+	// server-sent events at /invocation/events, which accepts the fields of the query as query parameters.
+	Watch(*InvocationWatchQuery, WorkflowInvocationAPI_WatchServer) error
 }
 
 func RegisterWorkflowInvocationAPIServer(s *grpc.Server, srv WorkflowInvocationAPIServer) {
@@ -516,6 +806,60 @@ func _WorkflowInvocationAPI_Cancel_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowInvocationIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Pause(ctx, req.(*WorkflowInvocationIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowInvocationIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Resume(ctx, req.(*WorkflowInvocationIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Retry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowInvocationIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Retry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Retry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Retry(ctx, req.(*WorkflowInvocationIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkflowInvocationAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InvocationListQuery)
 	if err := dec(in); err != nil {
@@ -552,6 +896,24 @@ func _WorkflowInvocationAPI_Get_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_GetAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvocationGetAtQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).GetAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/GetAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).GetAt(ctx, req.(*InvocationGetAtQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkflowInvocationAPI_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(fission_workflows_types.WorkflowInvocationSpec)
 	if err := dec(in); err != nil {
@@ -570,6 +932,27 @@ func _WorkflowInvocationAPI_Validate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvocationWatchQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkflowInvocationAPIServer).Watch(m, &workflowInvocationAPIWatchServer{stream})
+}
+
+type WorkflowInvocationAPI_WatchServer interface {
+	Send(*fission_workflows_eventstore.Event) error
+	grpc.ServerStream
+}
+
+type workflowInvocationAPIWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *workflowInvocationAPIWatchServer) Send(m *fission_workflows_eventstore.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fission.workflows.apiserver.WorkflowInvocationAPI",
 	HandlerType: (*WorkflowInvocationAPIServer)(nil),
@@ -586,6 +969,18 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Cancel",
 			Handler:    _WorkflowInvocationAPI_Cancel_Handler,
 		},
+		{
+			MethodName: "Pause",
+			Handler:    _WorkflowInvocationAPI_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _WorkflowInvocationAPI_Resume_Handler,
+		},
+		{
+			MethodName: "Retry",
+			Handler:    _WorkflowInvocationAPI_Retry_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _WorkflowInvocationAPI_List_Handler,
@@ -594,12 +989,22 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _WorkflowInvocationAPI_Get_Handler,
 		},
+		{
+			MethodName: "GetAt",
+			Handler:    _WorkflowInvocationAPI_GetAt_Handler,
+		},
 		{
 			MethodName: "Validate",
 			Handler:    _WorkflowInvocationAPI_Validate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _WorkflowInvocationAPI_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "pkg/apiserver/apiserver.proto",
 }
 
@@ -608,6 +1013,12 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 type AdminAPIClient interface {
 	Status(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*Health, error)
 	Version(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*fission_workflows_version.Info, error)
+	// Resume the evaluation of invocations by the engine after it has been halted.
+	Resume(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Halt stops the engine from evaluating invocations, until it is resumed.
+	//
+	// Like a paused invocation, tasks that are already running are allowed to finish.
+	Halt(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
 }
 
 type adminAPIClient struct {
@@ -636,11 +1047,35 @@ func (c *adminAPIClient) Version(ctx context.Context, in *google_protobuf1.Empty
 	return out, nil
 }
 
+func (c *adminAPIClient) Resume(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.AdminAPI/Resume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) Halt(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.AdminAPI/Halt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for AdminAPI service
 
 type AdminAPIServer interface {
 	Status(context.Context, *google_protobuf1.Empty) (*Health, error)
 	Version(context.Context, *google_protobuf1.Empty) (*fission_workflows_version.Info, error)
+	// Resume the evaluation of invocations by the engine after it has been halted.
+	Resume(context.Context, *google_protobuf1.Empty) (*google_protobuf1.Empty, error)
+	// Halt stops the engine from evaluating invocations, until it is resumed.
+	//
+	// Like a paused invocation, tasks that are already running are allowed to finish.
+	Halt(context.Context, *google_protobuf1.Empty) (*google_protobuf1.Empty, error)
 }
 
 func RegisterAdminAPIServer(s *grpc.Server, srv AdminAPIServer) {
@@ -683,6 +1118,42 @@ func _AdminAPI_Version_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminAPI_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.AdminAPI/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).Resume(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_Halt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).Halt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.AdminAPI/Halt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).Halt(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fission.workflows.apiserver.AdminAPI",
 	HandlerType: (*AdminAPIServer)(nil),
@@ -695,6 +1166,210 @@ var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Version",
 			Handler:    _AdminAPI_Version_Handler,
 		},
+		{
+			MethodName: "Resume",
+			Handler:    _AdminAPI_Resume_Handler,
+		},
+		{
+			MethodName: "Halt",
+			Handler:    _AdminAPI_Halt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/apiserver/apiserver.proto",
+}
+
+// Client API for ScheduleAPI service
+
+type ScheduleAPIClient interface {
+	Create(ctx context.Context, in *fission_workflows_types.ScheduleSpec, opts ...grpc.CallOption) (*ScheduleIdentifier, error)
+	List(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ScheduleList, error)
+	Get(ctx context.Context, in *ScheduleIdentifier, opts ...grpc.CallOption) (*fission_workflows_types.Schedule, error)
+	Update(ctx context.Context, in *ScheduleUpdateRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Delete(ctx context.Context, in *ScheduleIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+}
+
+type scheduleAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewScheduleAPIClient(cc *grpc.ClientConn) ScheduleAPIClient {
+	return &scheduleAPIClient{cc}
+}
+
+func (c *scheduleAPIClient) Create(ctx context.Context, in *fission_workflows_types.ScheduleSpec, opts ...grpc.CallOption) (*ScheduleIdentifier, error) {
+	out := new(ScheduleIdentifier)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.ScheduleAPI/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleAPIClient) List(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ScheduleList, error) {
+	out := new(ScheduleList)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.ScheduleAPI/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleAPIClient) Get(ctx context.Context, in *ScheduleIdentifier, opts ...grpc.CallOption) (*fission_workflows_types.Schedule, error) {
+	out := new(fission_workflows_types.Schedule)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.ScheduleAPI/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleAPIClient) Update(ctx context.Context, in *ScheduleUpdateRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.ScheduleAPI/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleAPIClient) Delete(ctx context.Context, in *ScheduleIdentifier, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.ScheduleAPI/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ScheduleAPI service
+
+type ScheduleAPIServer interface {
+	Create(context.Context, *fission_workflows_types.ScheduleSpec) (*ScheduleIdentifier, error)
+	List(context.Context, *google_protobuf1.Empty) (*ScheduleList, error)
+	Get(context.Context, *ScheduleIdentifier) (*fission_workflows_types.Schedule, error)
+	Update(context.Context, *ScheduleUpdateRequest) (*google_protobuf1.Empty, error)
+	Delete(context.Context, *ScheduleIdentifier) (*google_protobuf1.Empty, error)
+}
+
+func RegisterScheduleAPIServer(s *grpc.Server, srv ScheduleAPIServer) {
+	s.RegisterService(&_ScheduleAPI_serviceDesc, srv)
+}
+
+func _ScheduleAPI_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types.ScheduleSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleAPIServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.ScheduleAPI/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleAPIServer).Create(ctx, req.(*fission_workflows_types.ScheduleSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleAPIServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.ScheduleAPI/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleAPIServer).List(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleAPI_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleAPIServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.ScheduleAPI/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleAPIServer).Get(ctx, req.(*ScheduleIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleAPI_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleAPIServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.ScheduleAPI/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleAPIServer).Update(ctx, req.(*ScheduleUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleAPI_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleAPIServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.ScheduleAPI/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleAPIServer).Delete(ctx, req.(*ScheduleIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ScheduleAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "fission.workflows.apiserver.ScheduleAPI",
+	HandlerType: (*ScheduleAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ScheduleAPI_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ScheduleAPI_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ScheduleAPI_Get_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ScheduleAPI_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ScheduleAPI_Delete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiserver/apiserver.proto",
@@ -703,48 +1378,76 @@ var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/apiserver/apiserver.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 675 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc7, 0xe5, 0xb6, 0xf2, 0xaf, 0x99, 0xe8, 0x57, 0x95, 0x69, 0x09, 0x25, 0x6d, 0xd5, 0xb0,
-	0x80, 0x54, 0x82, 0xf0, 0xa2, 0x56, 0x42, 0x22, 0x07, 0xa4, 0x52, 0x10, 0x44, 0xe2, 0x00, 0x0d,
-	0x6a, 0xa5, 0xde, 0x5c, 0x67, 0x93, 0xac, 0x9a, 0x7a, 0x8d, 0xbd, 0x49, 0x15, 0x10, 0x97, 0x5e,
-	0xb8, 0x70, 0xe3, 0xc8, 0x73, 0x70, 0xe0, 0xc6, 0x3b, 0xf0, 0x0a, 0x3c, 0x08, 0xca, 0x7a, 0xed,
-	0xb8, 0xd8, 0x4e, 0x6a, 0xb8, 0xc4, 0xf1, 0xec, 0xce, 0x7c, 0xbe, 0x3b, 0x7f, 0xd6, 0xb0, 0xe9,
-	0x9d, 0x76, 0xa9, 0xed, 0xf1, 0x80, 0xf9, 0x43, 0xe6, 0x4f, 0xfe, 0x59, 0x9e, 0x2f, 0xa4, 0xc0,
-	0xf5, 0x0e, 0x0f, 0x02, 0x2e, 0x5c, 0xeb, 0x5c, 0xf8, 0xa7, 0x9d, 0xbe, 0x38, 0x0f, 0xac, 0x78,
-	0x4b, 0xb5, 0xd1, 0xe5, 0xb2, 0x37, 0x38, 0xb1, 0x1c, 0x71, 0x46, 0xf5, 0xbe, 0xe8, 0xf9, 0x20,
-	0xde, 0x4f, 0xc7, 0x00, 0x39, 0xf2, 0x58, 0x10, 0xfe, 0x86, 0x81, 0xab, 0x4f, 0xae, 0xec, 0x3b,
-	0x64, 0xbe, 0x5a, 0xd5, 0x4f, 0xed, 0xbf, 0xde, 0x15, 0xa2, 0xdb, 0x67, 0x54, 0xbd, 0x9d, 0x0c,
-	0x3a, 0x94, 0x9d, 0x79, 0x72, 0xa4, 0x17, 0x37, 0xf4, 0xa2, 0xed, 0x71, 0x6a, 0xbb, 0xae, 0x90,
-	0xb6, 0xe4, 0xc2, 0xd5, 0x68, 0x72, 0x07, 0xf0, 0x48, 0x13, 0x9a, 0x6d, 0xe6, 0x4a, 0xde, 0xe1,
-	0xcc, 0xc7, 0x25, 0x98, 0xe3, 0xed, 0x35, 0xa3, 0x66, 0x6c, 0x97, 0x0e, 0xe6, 0x78, 0x9b, 0x3c,
-	0x82, 0x4a, 0x8b, 0xd9, 0xbe, 0xd3, 0x8b, 0xf6, 0x1e, 0xb0, 0xc0, 0x13, 0x6e, 0xc0, 0x70, 0x03,
-	0x4a, 0xb1, 0xc2, 0x35, 0xa3, 0x36, 0xbf, 0x5d, 0x3a, 0x98, 0x18, 0xc8, 0x2e, 0xac, 0x34, 0xdd,
-	0xa1, 0x70, 0x14, 0xf2, 0x15, 0x0f, 0xe4, 0x9b, 0x01, 0xf3, 0x47, 0x33, 0x9c, 0x2c, 0xd8, 0x88,
-	0x25, 0xc5, 0xce, 0x53, 0xc4, 0x35, 0xa0, 0x92, 0xde, 0x3f, 0x86, 0x61, 0x0d, 0xca, 0x3c, 0xb6,
-	0x44, 0xa4, 0xa4, 0x89, 0xd4, 0xc0, 0x7c, 0xc9, 0xec, 0xbe, 0xec, 0x61, 0x05, 0xcc, 0x40, 0xda,
-	0x72, 0x10, 0xe8, 0xc8, 0xfa, 0x6d, 0xe7, 0xdb, 0x02, 0x94, 0xa3, 0xf0, 0x7b, 0xaf, 0x9b, 0x38,
-	0x04, 0x73, 0xdf, 0x67, 0xb6, 0x64, 0x78, 0xd7, 0x4a, 0xf7, 0x43, 0x58, 0xd5, 0x68, 0x7f, 0xcb,
-	0x63, 0x4e, 0x95, 0x5a, 0x53, 0xda, 0xc6, 0x4a, 0x27, 0x9f, 0xac, 0x5e, 0xfc, 0xfc, 0xf5, 0x65,
-	0x6e, 0x89, 0x94, 0x68, 0xe4, 0xd0, 0x30, 0xea, 0xd8, 0x81, 0x05, 0x75, 0xa6, 0x8a, 0x15, 0xd6,
-	0xd3, 0x8a, 0x8a, 0x6d, 0x3d, 0x1f, 0x17, 0xbb, 0xba, 0x3b, 0x15, 0x93, 0x5d, 0x3d, 0x72, 0x4d,
-	0xa1, 0xca, 0x38, 0x41, 0xe1, 0x3b, 0x98, 0x7f, 0xc1, 0x24, 0x16, 0x55, 0x5d, 0xbd, 0x35, 0x33,
-	0x1b, 0xa4, 0xa2, 0x68, 0xcb, 0xb8, 0x14, 0xd3, 0xe8, 0x07, 0xde, 0xfe, 0x88, 0x1c, 0xcc, 0x67,
-	0xac, 0xcf, 0x24, 0x2b, 0x4e, 0xcd, 0xc9, 0x46, 0x84, 0xaa, 0xff, 0x89, 0xea, 0xc1, 0xe2, 0xa1,
-	0xdd, 0xe7, 0xed, 0x02, 0xf5, 0xcb, 0x43, 0x6c, 0x2a, 0xc4, 0x0d, 0x82, 0x13, 0xc4, 0x50, 0x87,
-	0x6e, 0x18, 0xf5, 0x9d, 0x1f, 0x26, 0x5c, 0x4f, 0xb7, 0xe5, 0xb8, 0x83, 0x3e, 0x1b, 0x60, 0x8e,
-	0x2d, 0xa7, 0xd9, 0xe7, 0xbd, 0x2c, 0x61, 0xe2, 0xaa, 0xc4, 0x3c, 0xbe, 0x5a, 0x82, 0x32, 0xc6,
-	0x26, 0x4a, 0x09, 0x29, 0xd3, 0xc9, 0x00, 0x8c, 0x1b, 0xeb, 0xab, 0x01, 0x10, 0xca, 0x69, 0x8d,
-	0x5c, 0xa7, 0xb8, 0xa4, 0xfb, 0x05, 0x1c, 0x08, 0x55, 0x22, 0xee, 0x91, 0xe5, 0x84, 0x08, 0x1a,
-	0x8c, 0x5c, 0xa7, 0x61, 0xd4, 0x8f, 0x11, 0x53, 0x66, 0x1c, 0x80, 0xb9, 0x6f, 0xbb, 0x0e, 0xeb,
-	0xe3, 0xdf, 0x1f, 0x3d, 0xb7, 0x84, 0x6b, 0x4a, 0x0d, 0xd6, 0x2f, 0x61, 0x55, 0x9f, 0x5c, 0x18,
-	0x7a, 0xdc, 0x1e, 0x4e, 0xa5, 0x66, 0x5c, 0x6e, 0x33, 0x06, 0x31, 0xfb, 0xa6, 0x22, 0x2b, 0x4a,
-	0xc9, 0xff, 0x98, 0x2c, 0x0e, 0x7e, 0x32, 0xc2, 0x59, 0xfc, 0x87, 0x93, 0x17, 0x2a, 0x8e, 0x4e,
-	0x07, 0xa6, 0xd3, 0x21, 0x13, 0x63, 0x53, 0xb8, 0x41, 0xf2, 0xb2, 0xbf, 0xa5, 0x70, 0x37, 0xc9,
-	0x6a, 0x12, 0x97, 0x1c, 0xa1, 0xef, 0x06, 0x2c, 0xee, 0xb5, 0xcf, 0xb8, 0x9a, 0x9a, 0x23, 0x30,
-	0x5b, 0xea, 0x46, 0xce, 0xbd, 0x01, 0x6f, 0x4f, 0x4d, 0x53, 0x78, 0xcd, 0x93, 0x65, 0x05, 0x05,
-	0x5c, 0xa4, 0x3d, 0x65, 0x78, 0x8f, 0x6f, 0xe1, 0xbf, 0xc3, 0xf0, 0x6b, 0x9a, 0x1b, 0x79, 0x2b,
-	0x23, 0x72, 0xf4, 0x05, 0x6e, 0xba, 0x1d, 0x91, 0x88, 0xaa, 0xcd, 0x4f, 0xcb, 0xc7, 0xa5, 0x98,
-	0x7d, 0x62, 0xaa, 0x78, 0xbb, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x7a, 0xd9, 0x98, 0xf7, 0x60,
-	0x08, 0x00, 0x00,
+	// 1131 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
+	0x13, 0x07, 0xf5, 0x65, 0x6b, 0x14, 0xfb, 0xef, 0xff, 0xda, 0x56, 0x58, 0xd9, 0x89, 0xd5, 0x4d,
+	0x03, 0x38, 0x6e, 0x4b, 0xa6, 0x32, 0x10, 0x24, 0x3e, 0x04, 0x75, 0x52, 0x23, 0x31, 0xd0, 0x43,
+	0x2a, 0xb9, 0x31, 0x10, 0xf4, 0x42, 0x53, 0x23, 0x8b, 0xb0, 0x44, 0xd2, 0xdc, 0x95, 0x02, 0xb9,
+	0xed, 0x25, 0x28, 0xd0, 0x4b, 0x6f, 0x3d, 0xf6, 0xd2, 0x4b, 0x1f, 0xa1, 0x4f, 0xd1, 0x63, 0x5f,
+	0xa1, 0x0f, 0x52, 0x70, 0xc9, 0xa5, 0x3e, 0x28, 0x4a, 0x64, 0x8b, 0xf4, 0x90, 0x90, 0x3b, 0x9c,
+	0x99, 0xdf, 0x7c, 0xed, 0xf8, 0x27, 0xb8, 0xe3, 0x5e, 0x5d, 0xea, 0x86, 0x6b, 0x31, 0xf4, 0x86,
+	0xe8, 0x8d, 0xdf, 0x34, 0xd7, 0x73, 0xb8, 0x43, 0x76, 0x3a, 0x16, 0x63, 0x96, 0x63, 0x6b, 0x6f,
+	0x1d, 0xef, 0xaa, 0xd3, 0x73, 0xde, 0x32, 0x2d, 0x52, 0xa9, 0x1d, 0x5d, 0x5a, 0xbc, 0x3b, 0xb8,
+	0xd0, 0x4c, 0xa7, 0xaf, 0x87, 0x7a, 0xf2, 0xf9, 0x69, 0xa4, 0xaf, 0xfb, 0x00, 0x7c, 0xe4, 0x22,
+	0x0b, 0xfe, 0x0f, 0x1c, 0xd7, 0x9e, 0xa6, 0xb6, 0x1d, 0xa2, 0x27, 0xbe, 0x86, 0xcf, 0xd0, 0xfe,
+	0x51, 0x6a, 0xfb, 0x0e, 0x32, 0xff, 0x5f, 0x68, 0xb7, 0x73, 0xe9, 0x38, 0x97, 0x3d, 0xd4, 0xc5,
+	0xe9, 0x62, 0xd0, 0xd1, 0xb1, 0xef, 0xf2, 0x51, 0xf8, 0x71, 0x6f, 0xf6, 0x23, 0xb7, 0xfa, 0xc8,
+	0xb8, 0xd1, 0x77, 0x43, 0x85, 0xdd, 0x50, 0xc1, 0x70, 0x2d, 0xdd, 0xb0, 0x6d, 0x87, 0x1b, 0xdc,
+	0x72, 0xec, 0xd0, 0x37, 0xfd, 0x08, 0xc8, 0x79, 0x08, 0x7d, 0xda, 0x46, 0x9b, 0x5b, 0x1d, 0x0b,
+	0x3d, 0xb2, 0x0e, 0x39, 0xab, 0xad, 0x2a, 0x75, 0x65, 0xbf, 0xdc, 0xcc, 0x59, 0x6d, 0xfa, 0x08,
+	0xaa, 0x2d, 0x34, 0x3c, 0xb3, 0x2b, 0x75, 0x9b, 0xc8, 0x5c, 0xc7, 0x66, 0x48, 0x76, 0xa1, 0x1c,
+	0x85, 0xae, 0x2a, 0xf5, 0xfc, 0x7e, 0xb9, 0x39, 0x16, 0xd0, 0xdf, 0x72, 0xb0, 0x79, 0x6a, 0x0f,
+	0x1d, 0x53, 0x60, 0x7e, 0x69, 0x31, 0xfe, 0xd5, 0x00, 0xbd, 0xd1, 0x62, 0x2b, 0x72, 0x06, 0xab,
+	0x8c, 0x1b, 0x7c, 0xc0, 0x90, 0xa9, 0xb9, 0x7a, 0x7e, 0x7f, 0xbd, 0xf1, 0x58, 0x8b, 0xf7, 0x34,
+	0xe8, 0x4c, 0x14, 0x7c, 0x84, 0xd2, 0x12, 0xa6, 0x5a, 0xf0, 0x68, 0x46, 0x9e, 0xc8, 0x53, 0xb8,
+	0x65, 0x7a, 0x68, 0x70, 0x6c, 0x1f, 0x77, 0x38, 0x7a, 0x6a, 0xbe, 0xae, 0xec, 0x57, 0x1a, 0x35,
+	0x2d, 0x28, 0x8f, 0x26, 0xeb, 0xa7, 0x9d, 0xc9, 0xfa, 0x35, 0xa7, 0xf4, 0xc9, 0xe7, 0xb0, 0x16,
+	0x9e, 0x9f, 0x61, 0xc7, 0xf1, 0x50, 0x2d, 0x2c, 0x75, 0x30, 0x6d, 0x40, 0x54, 0x58, 0x71, 0x0d,
+	0x0f, 0x6d, 0xce, 0xd4, 0xa2, 0xc8, 0x59, 0x1e, 0xa9, 0x06, 0xbb, 0xf1, 0x44, 0x16, 0xf4, 0xc3,
+	0x83, 0xad, 0xb1, 0xde, 0x0b, 0xe4, 0xc7, 0x61, 0x5d, 0x67, 0xf4, 0xc8, 0x63, 0x28, 0x47, 0xe3,
+	0xa0, 0xe6, 0x96, 0xc6, 0x3b, 0x56, 0x26, 0x1b, 0x90, 0x67, 0x78, 0x2d, 0x8a, 0x54, 0x68, 0xfa,
+	0xaf, 0x74, 0x38, 0x89, 0x79, 0x6e, 0x70, 0xb3, 0x1b, 0x60, 0xd6, 0xa1, 0x62, 0x45, 0x72, 0xd9,
+	0xcd, 0x49, 0xd1, 0x74, 0xb7, 0x73, 0xb3, 0xdd, 0xbe, 0x0b, 0x80, 0x43, 0xb4, 0xf9, 0x99, 0xdf,
+	0x4f, 0x35, 0x2f, 0x3e, 0x4f, 0x48, 0xe8, 0x11, 0x54, 0xe3, 0xb5, 0xf1, 0x47, 0x69, 0x39, 0x32,
+	0xad, 0x43, 0xe9, 0x25, 0x1a, 0x3d, 0xde, 0x25, 0x55, 0x28, 0x05, 0x93, 0x10, 0x56, 0x27, 0x3c,
+	0xf9, 0xf3, 0xdf, 0x32, 0xbb, 0xd8, 0x1e, 0xf4, 0x70, 0x41, 0xbd, 0x2f, 0x60, 0x5b, 0x6a, 0x7d,
+	0xed, 0xb6, 0x0d, 0x8e, 0x4d, 0xbc, 0x1e, 0x20, 0xe3, 0xb1, 0x82, 0x3f, 0x81, 0x02, 0x73, 0xd1,
+	0x0c, 0x6b, 0x7d, 0x3f, 0x71, 0x6c, 0xa5, 0xb7, 0x96, 0x8b, 0x66, 0x53, 0x98, 0xd0, 0x4f, 0xe0,
+	0x96, 0x94, 0x8a, 0xec, 0x76, 0xa1, 0xcc, 0xc2, 0x73, 0x74, 0x47, 0x22, 0x41, 0xe3, 0xf7, 0x02,
+	0x54, 0x64, 0x59, 0x8e, 0x5f, 0x9d, 0x92, 0x21, 0x94, 0x9e, 0x8b, 0x61, 0x23, 0xf7, 0x97, 0xde,
+	0x15, 0x1f, 0xb4, 0xa6, 0x6b, 0x0b, 0xd6, 0xa4, 0x16, 0xdf, 0x09, 0x74, 0xeb, 0xdd, 0x9f, 0x7f,
+	0xfd, 0x9c, 0x5b, 0x3f, 0x52, 0x0e, 0x68, 0x59, 0x97, 0x36, 0xa4, 0x03, 0x05, 0x11, 0x6d, 0x35,
+	0x36, 0x56, 0x27, 0xfe, 0x92, 0xaa, 0x1d, 0x2e, 0x84, 0x99, 0xbf, 0x54, 0xe8, 0xff, 0x05, 0x54,
+	0x85, 0x4c, 0xe0, 0x5c, 0x43, 0xfe, 0x05, 0x72, 0x92, 0x35, 0xea, 0xda, 0x87, 0x4b, 0xab, 0x41,
+	0xab, 0x02, 0x6d, 0x83, 0xac, 0x47, 0x68, 0xfa, 0xb7, 0x56, 0xfb, 0x7b, 0x62, 0x41, 0xe9, 0x0b,
+	0xec, 0x21, 0xc7, 0xec, 0xa8, 0x09, 0xd5, 0x90, 0x50, 0x07, 0xb3, 0x50, 0x5d, 0x58, 0x7d, 0x6d,
+	0xf4, 0xac, 0x76, 0x86, 0xfe, 0x25, 0x41, 0xdc, 0x11, 0x10, 0xb7, 0xfd, 0x36, 0x91, 0x31, 0xca,
+	0x30, 0xf4, 0xde, 0xf8, 0xb5, 0x02, 0xdb, 0xf1, 0xeb, 0xe4, 0x4f, 0xd0, 0x4f, 0x0a, 0x94, 0x7c,
+	0xc9, 0xd5, 0xfc, 0x7c, 0x13, 0xd7, 0xad, 0x1f, 0xcc, 0x93, 0x74, 0x05, 0x9a, 0xb3, 0xda, 0x64,
+	0x49, 0xfc, 0x78, 0x2b, 0xfa, 0xf8, 0xee, 0x92, 0x5f, 0x14, 0x80, 0x20, 0x9c, 0xd6, 0xc8, 0x36,
+	0xb3, 0x87, 0xf4, 0x71, 0x06, 0x03, 0xaa, 0x8b, 0x20, 0x1e, 0x1c, 0x29, 0x07, 0x6f, 0x08, 0xd9,
+	0x98, 0x08, 0x43, 0x67, 0x23, 0xdb, 0xa4, 0x31, 0x09, 0x19, 0x40, 0xe9, 0xb9, 0x61, 0x9b, 0xd8,
+	0x23, 0xff, 0x3c, 0xf5, 0xc4, 0x16, 0xaa, 0x22, 0x1a, 0x72, 0x30, 0x05, 0x2b, 0xe6, 0xe4, 0x06,
+	0x8a, 0xaf, 0x8c, 0x01, 0xc3, 0xf7, 0x81, 0x7a, 0x57, 0xa0, 0xaa, 0xb4, 0x3a, 0x8b, 0xaa, 0xbb,
+	0x02, 0xf2, 0x3b, 0x28, 0x35, 0x91, 0x0d, 0xfa, 0xef, 0x05, 0x7c, 0x4f, 0x80, 0x7f, 0x40, 0x6f,
+	0xc7, 0xc0, 0xbd, 0x00, 0xf3, 0x06, 0x8a, 0x4d, 0xe4, 0xde, 0xe8, 0xbf, 0xcd, 0xdc, 0x13, 0x90,
+	0xef, 0x94, 0x70, 0xc9, 0x3d, 0x5c, 0x88, 0x3d, 0x87, 0xe8, 0xd4, 0x0e, 0x33, 0x46, 0xeb, 0x5b,
+	0xd2, 0x4d, 0x11, 0xcf, 0x1a, 0x99, 0xba, 0x0f, 0x3f, 0x2a, 0xc1, 0x06, 0xfc, 0x17, 0xf9, 0x67,
+	0xba, 0x12, 0xe1, 0x10, 0x92, 0xf8, 0x10, 0xfe, 0xa0, 0x40, 0x51, 0x70, 0x0e, 0xf2, 0x59, 0xca,
+	0x7a, 0x8c, 0x19, 0x4a, 0xb6, 0x18, 0x76, 0x44, 0x0c, 0xdb, 0x64, 0x33, 0xd6, 0x18, 0x83, 0x13,
+	0x3e, 0xb1, 0x33, 0x33, 0x6f, 0x87, 0x25, 0x73, 0xe8, 0x6f, 0xa3, 0xad, 0x49, 0x50, 0xb9, 0x3f,
+	0x89, 0x01, 0x45, 0xc1, 0x7d, 0x52, 0xe7, 0x3e, 0x66, 0x4a, 0xb5, 0x7b, 0x73, 0x4c, 0x04, 0xd1,
+	0x61, 0xdc, 0xf1, 0x50, 0x3b, 0xf1, 0x5f, 0x1f, 0x2a, 0x8d, 0x3f, 0x72, 0xb0, 0x7a, 0xdc, 0xee,
+	0x5b, 0x62, 0x2b, 0x9f, 0x43, 0x29, 0x60, 0xb2, 0x89, 0x7f, 0x61, 0xef, 0x2d, 0x0c, 0x24, 0xa0,
+	0x3f, 0x74, 0x43, 0xe4, 0x05, 0x64, 0x55, 0xef, 0x0a, 0xc1, 0x0d, 0x39, 0x83, 0x95, 0xd7, 0xc1,
+	0xaf, 0x93, 0x44, 0xcf, 0x7b, 0x73, 0x3c, 0xcb, 0x5f, 0x34, 0xa7, 0x76, 0xc7, 0x99, 0xf0, 0x1a,
+	0x8a, 0xc9, 0x69, 0xb4, 0x24, 0x92, 0x9c, 0x26, 0x55, 0xfe, 0x7f, 0xc2, 0x57, 0x99, 0xae, 0xc8,
+	0x1b, 0x7f, 0x02, 0x85, 0x97, 0x46, 0x8f, 0x67, 0x76, 0xb4, 0x26, 0x1c, 0xad, 0xd0, 0xa2, 0xde,
+	0x35, 0x7a, 0x5c, 0x10, 0x25, 0xc9, 0xab, 0xd2, 0x12, 0xa5, 0x49, 0x76, 0xb6, 0x84, 0x28, 0xc5,
+	0xc9, 0xe3, 0x34, 0x51, 0x92, 0x8c, 0x8d, 0x7c, 0xb3, 0x84, 0x28, 0x3d, 0x48, 0x05, 0x23, 0xf6,
+	0xc3, 0x98, 0x1e, 0x45, 0xde, 0x53, 0xd1, 0xa3, 0x78, 0xac, 0x0b, 0xe8, 0x91, 0x54, 0x9e, 0xa0,
+	0x47, 0x12, 0x2d, 0x58, 0x03, 0x1e, 0x94, 0x02, 0x2e, 0x4c, 0x1a, 0xa9, 0x50, 0xa7, 0x88, 0x73,
+	0x62, 0xf7, 0x76, 0x05, 0x5a, 0xf5, 0x48, 0x70, 0xe2, 0xda, 0x2c, 0x66, 0x5a, 0x4a, 0x36, 0x27,
+	0xd3, 0xe5, 0x94, 0x6c, 0x0a, 0xea, 0x59, 0xe5, 0x4d, 0x39, 0xf2, 0x77, 0x51, 0x12, 0x46, 0x87,
+	0x7f, 0x0f, 0x00, 0x0a, 0x51, 0xf6, 0x2d, 0x7a, 0x10, 0x00, 0x00,
 }
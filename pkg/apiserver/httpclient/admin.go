@@ -2,7 +2,11 @@ package httpclient
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/version"
@@ -32,3 +36,30 @@ func (api *AdminAPI) Version(ctx context.Context) (*version.Info, error) {
 	err := call(http.MethodGet, api.formatURL("/version"), nil, result)
 	return result, err
 }
+
+func (api *AdminAPI) Halt(ctx context.Context) error {
+	return call(http.MethodPost, api.formatURL("/halt"), nil, nil)
+}
+
+func (api *AdminAPI) Resume(ctx context.Context) error {
+	return call(http.MethodPost, api.formatURL("/resume"), nil, nil)
+}
+
+// Export writes the events of the event store, in the format of fes.Export, to w.
+func (api *AdminAPI) Export(ctx context.Context, w io.Writer) error {
+	req, err := http.NewRequest(http.MethodGet, api.formatURL("/export"), nil)
+	if err != nil {
+		return fmt.Errorf("%v: %v", ErrRequestCreate, err)
+	}
+	resp, err := api.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("%v: %v", ErrRequestSend, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%v (%s): %s", ErrResponseError, resp.Status, strings.TrimSpace(string(data)))
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
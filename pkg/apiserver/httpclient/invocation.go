@@ -3,9 +3,13 @@ package httpclient
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
 )
 
 type InvocationAPI struct {
@@ -39,6 +43,18 @@ func (api *InvocationAPI) Cancel(ctx context.Context, id string) error {
 	return call(http.MethodDelete, api.formatURL("/invocation/"+id), nil, nil)
 }
 
+func (api *InvocationAPI) Pause(ctx context.Context, id string) error {
+	return call(http.MethodPost, api.formatURL("/invocation/"+id+"/pause"), nil, nil)
+}
+
+func (api *InvocationAPI) Resume(ctx context.Context, id string) error {
+	return call(http.MethodPost, api.formatURL("/invocation/"+id+"/resume"), nil, nil)
+}
+
+func (api *InvocationAPI) Retry(ctx context.Context, id string) error {
+	return call(http.MethodPost, api.formatURL("/invocation/"+id+"/retry"), nil, nil)
+}
+
 func (api *InvocationAPI) List(ctx context.Context) (*apiserver.WorkflowInvocationList, error) {
 	result := &apiserver.WorkflowInvocationList{}
 	err := call(http.MethodGet, api.formatURL("/invocation"), nil, result)
@@ -51,6 +67,24 @@ func (api *InvocationAPI) Get(ctx context.Context, id string) (*types.WorkflowIn
 	return result, err
 }
 
+func (api *InvocationAPI) GetAt(ctx context.Context, query *apiserver.InvocationGetAtQuery) (*types.
+	WorkflowInvocation, error) {
+	params := url.Values{}
+	if query.GetTimestamp() != nil {
+		ts, err := ptypes.Timestamp(query.GetTimestamp())
+		if err != nil {
+			return nil, err
+		}
+		params.Set("timestamp", ts.Format(time.RFC3339Nano))
+	}
+	if query.GetSeq() > 0 {
+		params.Set("seq", strconv.FormatUint(query.GetSeq(), 10))
+	}
+	result := &types.WorkflowInvocation{}
+	err := call(http.MethodGet, api.formatURL("/invocation/"+query.GetId()+"/at?"+params.Encode()), nil, result)
+	return result, err
+}
+
 func (api *InvocationAPI) Validate(ctx context.Context, spec *types.WorkflowInvocationSpec) error {
 	return call(http.MethodPost, api.formatURL("/invocation/validate"), spec, nil)
 }
@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/types"
+)
+
+type ScheduleAPI struct {
+	baseAPI
+}
+
+func NewScheduleAPI(endpoint string, client http.Client) *ScheduleAPI {
+	return &ScheduleAPI{
+		baseAPI: baseAPI{
+			endpoint: endpoint,
+			client:   client,
+		},
+	}
+}
+
+func (api *ScheduleAPI) Create(ctx context.Context, spec *types.ScheduleSpec) (*apiserver.ScheduleIdentifier, error) {
+	result := &apiserver.ScheduleIdentifier{}
+	err := call(http.MethodPost, api.formatURL("/schedule"), spec, result)
+	return result, err
+}
+
+func (api *ScheduleAPI) List(ctx context.Context) (*apiserver.ScheduleList, error) {
+	result := &apiserver.ScheduleList{}
+	err := call(http.MethodGet, api.formatURL("/schedule"), nil, result)
+	return result, err
+}
+
+func (api *ScheduleAPI) Get(ctx context.Context, id string) (*types.Schedule, error) {
+	result := &types.Schedule{}
+	err := call(http.MethodGet, api.formatURL("/schedule/"+id), nil, result)
+	return result, err
+}
+
+func (api *ScheduleAPI) Update(ctx context.Context, id string, spec *types.ScheduleSpec) error {
+	err := call(http.MethodPut, api.formatURL("/schedule/"+id), spec, nil)
+	return err
+}
+
+func (api *ScheduleAPI) Delete(ctx context.Context, id string) error {
+	err := call(http.MethodDelete, api.formatURL("/schedule/"+id), nil, nil)
+	return err
+}
@@ -2,6 +2,8 @@ package apiserver
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/api/aggregates"
@@ -9,15 +11,24 @@ import (
 	"github.com/fission/fission-workflows/pkg/fnenv/workflows"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// HeaderIdempotencyKey is the request header, or gRPC metadata key, with which clients can provide the idempotency
+// key of an invocation as an alternative to setting it in the invocation spec.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
 // Invocation is responsible for all functionality related to managing invocations.
 type Invocation struct {
 	api      *api.Invocation
 	wfiCache fes.CacheReader
+	es       fes.Backend
 	fnenv    *workflows.Runtime
 }
 
@@ -29,11 +40,15 @@ func (gi *Invocation) Validate(ctx context.Context, spec *types.WorkflowInvocati
 	return &empty.Empty{}, nil
 }
 
-func NewInvocation(api *api.Invocation, wfiCache fes.CacheReader) WorkflowInvocationAPIServer {
-	return &Invocation{api, wfiCache, workflows.NewRuntime(api, wfiCache)}
+// NewInvocation creates the invocation API server. The runtime is used to wait for the results of synchronous
+// invocations.
+func NewInvocation(api *api.Invocation, wfiCache fes.CacheReader, es fes.Backend,
+	runtime *workflows.Runtime) WorkflowInvocationAPIServer {
+	return &Invocation{api, wfiCache, es, runtime}
 }
 
 func (gi *Invocation) Invoke(ctx context.Context, spec *types.WorkflowInvocationSpec) (*WorkflowInvocationIdentifier, error) {
+	setIdempotencyKey(ctx, spec)
 	eventID, err := gi.api.Invoke(spec)
 	if err != nil {
 		return nil, toErrorStatus(err)
@@ -43,6 +58,7 @@ func (gi *Invocation) Invoke(ctx context.Context, spec *types.WorkflowInvocation
 }
 
 func (gi *Invocation) InvokeSync(ctx context.Context, spec *types.WorkflowInvocationSpec) (*types.WorkflowInvocation, error) {
+	setIdempotencyKey(ctx, spec)
 	wfi, err := gi.fnenv.InvokeWorkflow(ctx, spec)
 	if err != nil {
 		return nil, toErrorStatus(err)
@@ -50,6 +66,21 @@ func (gi *Invocation) InvokeSync(ctx context.Context, spec *types.WorkflowInvoca
 	return wfi, nil
 }
 
+// setIdempotencyKey sets the idempotency key of the spec to the key in the metadata of the request, unless the spec
+// already contains an idempotency key.
+func setIdempotencyKey(ctx context.Context, spec *types.WorkflowInvocationSpec) {
+	if spec == nil || len(spec.GetIdempotencyKey()) > 0 {
+		return
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return
+	}
+	if keys := md[strings.ToLower(HeaderIdempotencyKey)]; len(keys) > 0 {
+		spec.IdempotencyKey = keys[0]
+	}
+}
+
 func (gi *Invocation) Cancel(ctx context.Context, invocationID *WorkflowInvocationIdentifier) (*empty.Empty, error) {
 	err := gi.api.Cancel(invocationID.GetId())
 	if err != nil {
@@ -59,6 +90,33 @@ func (gi *Invocation) Cancel(ctx context.Context, invocationID *WorkflowInvocati
 	return &empty.Empty{}, nil
 }
 
+func (gi *Invocation) Pause(ctx context.Context, invocationID *WorkflowInvocationIdentifier) (*empty.Empty, error) {
+	err := gi.api.Pause(invocationID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (gi *Invocation) Resume(ctx context.Context, invocationID *WorkflowInvocationIdentifier) (*empty.Empty, error) {
+	err := gi.api.Resume(invocationID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (gi *Invocation) Retry(ctx context.Context, invocationID *WorkflowInvocationIdentifier) (*empty.Empty, error) {
+	err := gi.api.Retry(invocationID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (gi *Invocation) Get(ctx context.Context, invocationID *WorkflowInvocationIdentifier) (*types.WorkflowInvocation, error) {
 	wi := aggregates.NewWorkflowInvocation(invocationID.GetId())
 	err := gi.wfiCache.Get(wi)
@@ -68,7 +126,61 @@ func (gi *Invocation) Get(ctx context.Context, invocationID *WorkflowInvocationI
 	return wi.WorkflowInvocation, nil
 }
 
+// GetAt reconstructs the invocation from its events in the event store, only replaying the events up to the
+// timestamp and/or sequence number of the query.
+func (gi *Invocation) GetAt(ctx context.Context, query *InvocationGetAtQuery) (*types.WorkflowInvocation, error) {
+	if query.GetTimestamp() == nil && query.GetSeq() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "either a timestamp or a sequence number is required")
+	}
+	var at time.Time
+	if query.GetTimestamp() != nil {
+		var err error
+		at, err = ptypes.Timestamp(query.GetTimestamp())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	events, err := gi.es.Get(*aggregates.NewWorkflowInvocationAggregate(query.GetId()))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	var n int
+	for ; n < len(events); n++ {
+		if query.GetSeq() > 0 && uint64(n) >= query.GetSeq() {
+			break
+		}
+		if query.GetTimestamp() != nil {
+			ts, err := ptypes.Timestamp(events[n].GetTimestamp())
+			if err != nil || ts.After(at) {
+				break
+			}
+		}
+	}
+	if n == 0 {
+		return nil, status.Errorf(codes.NotFound, "invocation %s did not exist at the provided point", query.GetId())
+	}
+
+	wi := aggregates.NewWorkflowInvocation(query.GetId())
+	err = fes.Project(wi, events[:n]...)
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return wi.WorkflowInvocation, nil
+}
+
 func (gi *Invocation) List(ctx context.Context, query *InvocationListQuery) (*WorkflowInvocationList, error) {
+	if index, ok := gi.wfiCache.(fes.IndexReader); ok {
+		invocations, err := listIndexed(index, query)
+		if err != fes.ErrIndexNotFound {
+			if err != nil {
+				return nil, toErrorStatus(err)
+			}
+			return &WorkflowInvocationList{invocations}, nil
+		}
+	}
+
+	// Fallback to scanning all invocations in the cache
 	var invocations []string
 	as := gi.wfiCache.List()
 	for _, aggregate := range as {
@@ -76,15 +188,14 @@ func (gi *Invocation) List(ctx context.Context, query *InvocationListQuery) (*Wo
 			return nil, toErrorStatus(errors.New("invalid type in invocation cache"))
 		}
 
-		if len(query.Workflows) > 0 {
-			// TODO make more efficient (by moving list queries to cache)
+		if hasFilters(query) {
 			entity, err := gi.wfiCache.GetAggregate(aggregate)
-			if err != nil {
-				logrus.Error("List: failed to fetch %v from cache: %v", aggregate, err)
+			if err != nil || entity == nil {
+				logrus.Errorf("List: failed to fetch %v from cache: %v", aggregate, err)
 				continue
 			}
 			wfi := entity.(*aggregates.WorkflowInvocation)
-			if !contains(query.Workflows, wfi.GetSpec().GetWorkflowId()) {
+			if !matches(query, wfi.WorkflowInvocation) {
 				continue
 			}
 		}
@@ -94,6 +205,131 @@ func (gi *Invocation) List(ctx context.Context, query *InvocationListQuery) (*Wo
 	return &WorkflowInvocationList{invocations}, nil
 }
 
+// listIndexed lists the invocations matching the query using the secondary indexes of the invocation cache.
+//
+// The filters of the query are combined; within a filter, the invocations need to match one of the provided values.
+func listIndexed(index fes.IndexReader, query *InvocationListQuery) ([]string, error) {
+	var statuses []string
+	for _, status := range query.GetStatuses() {
+		statuses = append(statuses, status.String())
+	}
+	filters := []struct {
+		index string
+		keys  []string
+	}{
+		{aggregates.IndexInvocationWorkflow, query.GetWorkflows()},
+		{aggregates.IndexInvocationStatus, statuses},
+		{aggregates.IndexInvocationParent, query.GetParents()},
+	}
+
+	var candidates map[fes.Aggregate]bool
+	var ordered []fes.Aggregate
+	for _, filter := range filters {
+		if len(filter.keys) == 0 {
+			continue
+		}
+		matched := map[fes.Aggregate]bool{}
+		ordered = nil
+		for _, key := range filter.keys {
+			as, err := index.Lookup(filter.index, key)
+			if err != nil {
+				return nil, err
+			}
+			for _, aggregate := range as {
+				if (candidates == nil || candidates[aggregate]) && !matched[aggregate] {
+					matched[aggregate] = true
+					ordered = append(ordered, aggregate)
+				}
+			}
+		}
+		candidates = matched
+	}
+
+	// Without a creation time filter, the range lookup is only needed to list all invocations.
+	if candidates != nil && query.GetCreatedAfter() == nil && query.GetCreatedBefore() == nil {
+		invocations := make([]string, 0, len(ordered))
+		for _, aggregate := range ordered {
+			invocations = append(invocations, aggregate.Id)
+		}
+		return invocations, nil
+	}
+
+	// The creation time index contains all invocations, ordering the results by their creation time.
+	var from, to string
+	if query.GetCreatedAfter() != nil {
+		createdAfter, err := ptypes.Timestamp(query.GetCreatedAfter())
+		if err != nil {
+			return nil, err
+		}
+		from = aggregates.FormatIndexTime(createdAfter)
+	}
+	if query.GetCreatedBefore() != nil {
+		createdBefore, err := ptypes.Timestamp(query.GetCreatedBefore())
+		if err != nil {
+			return nil, err
+		}
+		to = aggregates.FormatIndexTime(createdBefore)
+	}
+	as, err := index.LookupRange(aggregates.IndexInvocationCreatedAt, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var invocations []string
+	for _, aggregate := range as {
+		if candidates == nil || candidates[aggregate] {
+			invocations = append(invocations, aggregate.Id)
+		}
+	}
+	return invocations, nil
+}
+
+func hasFilters(query *InvocationListQuery) bool {
+	return len(query.GetWorkflows()) > 0 || len(query.GetStatuses()) > 0 || len(query.GetParents()) > 0 ||
+		query.GetCreatedAfter() != nil || query.GetCreatedBefore() != nil
+}
+
+// matches checks whether the invocation matches all filters of the query.
+func matches(query *InvocationListQuery, wfi *types.WorkflowInvocation) bool {
+	if len(query.GetWorkflows()) > 0 && !contains(query.GetWorkflows(), wfi.GetSpec().GetWorkflowId()) {
+		return false
+	}
+	if len(query.GetParents()) > 0 && !contains(query.GetParents(), wfi.GetSpec().GetParentId()) {
+		return false
+	}
+	if len(query.GetStatuses()) > 0 {
+		var found bool
+		for _, status := range query.GetStatuses() {
+			if status == wfi.GetStatus().GetStatus() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if query.GetCreatedAfter() == nil && query.GetCreatedBefore() == nil {
+		return true
+	}
+	createdAt, err := ptypes.Timestamp(wfi.GetMetadata().GetCreatedAt())
+	if err != nil {
+		return false
+	}
+	if query.GetCreatedAfter() != nil {
+		createdAfter, err := ptypes.Timestamp(query.GetCreatedAfter())
+		if err != nil || createdAt.Before(createdAfter) {
+			return false
+		}
+	}
+	if query.GetCreatedBefore() != nil {
+		createdBefore, err := ptypes.Timestamp(query.GetCreatedBefore())
+		if err != nil || !createdAt.Before(createdBefore) {
+			return false
+		}
+	}
+	return true
+}
+
 func contains(haystack []string, needle string) bool {
 	for i := 0; i < len(haystack); i++ {
 		if haystack[i] == needle {
@@ -0,0 +1,93 @@
+package apiserver
+
+import (
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Schedule is responsible for all functionality related to managing schedules.
+type Schedule struct {
+	api   *api.Schedule
+	cache fes.CacheReader
+}
+
+func NewSchedule(api *api.Schedule, cache fes.CacheReader) *Schedule {
+	return &Schedule{
+		api:   api,
+		cache: cache,
+	}
+}
+
+func (sa *Schedule) Create(ctx context.Context, spec *types.ScheduleSpec) (*ScheduleIdentifier, error) {
+	id, err := sa.api.Create(spec)
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &ScheduleIdentifier{id}, nil
+}
+
+func (sa *Schedule) List(ctx context.Context, req *empty.Empty) (*ScheduleList, error) {
+	var results []string
+	for _, result := range sa.cache.List() {
+		results = append(results, result.Id)
+	}
+	return &ScheduleList{results}, nil
+}
+
+func (sa *Schedule) Get(ctx context.Context, scheduleID *ScheduleIdentifier) (*types.Schedule, error) {
+	entity := aggregates.NewSchedule(scheduleID.GetId())
+	err := sa.cache.Get(entity)
+	if err == fes.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "schedule %s does not exist", scheduleID.GetId())
+	}
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return entity.Schedule, nil
+}
+
+func (sa *Schedule) Update(ctx context.Context, req *ScheduleUpdateRequest) (*empty.Empty, error) {
+	err := sa.ensureExists(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	err = sa.api.Update(req.GetId(), req.GetSpec())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (sa *Schedule) Delete(ctx context.Context, scheduleID *ScheduleIdentifier) (*empty.Empty, error) {
+	err := sa.ensureExists(scheduleID.GetId())
+	if err != nil {
+		return nil, err
+	}
+	err = sa.api.Delete(scheduleID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// ensureExists returns a NotFound error if the schedule does not exist, which avoids appending the events of a
+// schedule that has never been created. Requests without an id are left to the validation of the API.
+func (sa *Schedule) ensureExists(scheduleID string) error {
+	if len(scheduleID) == 0 {
+		return nil
+	}
+	err := sa.cache.Get(aggregates.NewSchedule(scheduleID))
+	if err == fes.ErrNotFound {
+		return status.Errorf(codes.NotFound, "schedule %s does not exist", scheduleID)
+	}
+	if err != nil {
+		return toErrorStatus(err)
+	}
+	return nil
+}
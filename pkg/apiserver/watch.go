@@ -0,0 +1,149 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchBuffer is the number of events buffered for a watcher. Events are dropped if the watcher falls further behind.
+const watchBuffer = 100
+
+var defaultJSONPBMarshaller = jsonpb.Marshaler{}
+
+func (gi *Invocation) Watch(query *InvocationWatchQuery, stream WorkflowInvocationAPI_WatchServer) error {
+	return watchInvocations(stream.Context(), gi.wfiCache, query, stream.Send)
+}
+
+// InvocationEventsHandler serves the events of invocations as server-sent events, using the query parameters
+// (invocations, workflows and eventTypes) of the request as the InvocationWatchQuery.
+type InvocationEventsHandler struct {
+	wfiCache fes.CacheReader
+}
+
+func NewInvocationEventsHandler(wfiCache fes.CacheReader) *InvocationEventsHandler {
+	return &InvocationEventsHandler{
+		wfiCache: wfiCache,
+	}
+}
+
+func (h *InvocationEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	params := r.URL.Query()
+	query := &InvocationWatchQuery{
+		Invocations: params["invocations"],
+		Workflows:   params["workflows"],
+		EventTypes:  params["eventTypes"],
+	}
+	if _, ok := h.wfiCache.(pubsub.Publisher); !ok {
+		http.Error(w, "watching invocations is not supported", http.StatusNotImplemented)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err := watchInvocations(r.Context(), h.wfiCache, query, func(event *fes.Event) error {
+		data, err := defaultJSONPBMarshaller.MarshalToString(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.GetId(), event.GetType(), data)
+		if err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil && err != context.Canceled {
+		logrus.Errorf("Failed to stream invocation events: %v", err)
+	}
+}
+
+// watchInvocations passes the events of the invocations that match the query to the handler, until either the
+// context is done or the handler returns an error.
+//
+// The events are received from the notifications of the invocation cache, which ensures that the invocation (and
+// therefore its workflow) is known for each of the events.
+func watchInvocations(ctx context.Context, wfiCache fes.CacheReader, query *InvocationWatchQuery,
+	handler func(event *fes.Event) error) error {
+	pub, ok := wfiCache.(pubsub.Publisher)
+	if !ok {
+		return status.Error(codes.Unimplemented, "watching invocations is not supported by the invocation cache")
+	}
+	sub := pub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       watchBuffer,
+		LabelMatcher: watchMatcher(query),
+	})
+	defer pub.Unsubscribe(sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-sub.Ch:
+			if !ok {
+				// The publisher has been closed.
+				return nil
+			}
+			notification, ok := msg.(*fes.Notification)
+			if !ok || notification.Event == nil {
+				logrus.WithField("msg", msg).Debug("Ignoring unknown message in watch.")
+				continue
+			}
+			if len(query.GetWorkflows()) > 0 {
+				wfi, ok := notification.Payload.(*aggregates.WorkflowInvocation)
+				if !ok || !contains(query.GetWorkflows(), wfi.GetSpec().GetWorkflowId()) {
+					continue
+				}
+			}
+			err := handler(notification.Event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchMatcher creates the label matcher for the invocation and event type filters of the query. The workflow filter
+// cannot be expressed in labels, as the events of an invocation do not contain the workflow of the invocation.
+func watchMatcher(query *InvocationWatchQuery) labels.Matcher {
+	var matchers []labels.Matcher
+	if len(query.GetInvocations()) > 0 {
+		matchers = append(matchers, labels.Or(
+			labels.And(
+				labels.In(fes.PubSubLabelAggregateType, aggregates.TypeWorkflowInvocation),
+				labels.In(fes.PubSubLabelAggregateID, query.GetInvocations()...)),
+			labels.And(
+				labels.In(fes.PubSubLabelParentType, aggregates.TypeWorkflowInvocation),
+				labels.In(fes.PubSubLabelParentID, query.GetInvocations()...)),
+		))
+	}
+	if len(query.GetEventTypes()) > 0 {
+		matchers = append(matchers, labels.In(fes.PubSubLabelEventType, query.GetEventTypes()...))
+	}
+	if len(matchers) == 0 {
+		return nil
+	}
+	return labels.And(matchers...)
+}
@@ -0,0 +1,308 @@
+// Package cluster coordinates the replicas of the controllers that share an event store.
+//
+// Each replica holds a lease, which it renews by periodically appending a heartbeat to its own aggregate in the event
+// store. The replicas learn about each other through these heartbeats; a replica that did not renew its lease before
+// it expired is considered to have disappeared. The entities are divided over the live replicas using rendezvous
+// hashing, so that the entities of a replica that disappears are rebalanced over the remaining replicas, while all
+// other entities remain with their current replica.
+//
+// The leases do not fence the replicas: while the entities are rebalanced, two replicas can briefly both consider
+// themselves responsible for the same entity. The replicas should therefore only modify the entities with conditional
+// appends (see fes.ConditionalAppender), so that the modifications based on an outdated version of an entity are
+// rejected by the event store.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	TypeReplica          = "replica"
+	DefaultLeaseDuration = 10 * time.Second
+
+	// compactionInterval is the number of heartbeats after which a replica removes its previous heartbeats from the
+	// event store, if the event store supports the removal of events.
+	compactionInterval = 100
+)
+
+var (
+	log = logrus.WithField("component", "cluster")
+
+	replicasLive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "cluster",
+		Name:      "replicas",
+		Help:      "The current number of live replicas, including this replica.",
+	})
+
+	replicaChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "cluster",
+		Name:      "replica_changes_total",
+		Help:      "Count of the replicas that joined or disappeared.",
+	}, []string{"change"})
+)
+
+func init() {
+	prometheus.MustRegister(replicasLive, replicaChanges)
+}
+
+// Config contains the options of the membership of a replica.
+type Config struct {
+	// ReplicaID identifies the replica. It should be unique among the replicas that share the event store.
+	ReplicaID string
+
+	// LeaseDuration is the duration after a heartbeat after which the replica is considered to have disappeared. The
+	// replica sends a heartbeat at every third of the lease duration. If not set, the DefaultLeaseDuration is used.
+	LeaseDuration time.Duration
+}
+
+// Membership tracks the live replicas that share the event store, and determines the shard of this replica.
+type Membership struct {
+	backend fes.Backend
+	config  Config
+
+	// leases contains the expiry of the lease of each replica, including this replica.
+	leases     map[string]time.Time
+	heartbeats int
+	left       bool
+	lock       sync.Mutex
+}
+
+// NewMembership creates the membership of the replica, which stores its heartbeats in the backend. The backend does
+// not need to persist the heartbeats; in that case, the replicas only learn about each other from the heartbeats that
+// they receive after they have joined.
+func NewMembership(backend fes.Backend, config Config) *Membership {
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = DefaultLeaseDuration
+	}
+	return &Membership{
+		backend: backend,
+		config:  config,
+		leases:  map[string]time.Time{},
+	}
+}
+
+// ID returns the id of this replica.
+func (m *Membership) ID() string {
+	return m.config.ReplicaID
+}
+
+// Run renews the lease of the replica at every third of the lease duration, and tracks the leases of the other
+// replicas using the events received over the subscription.
+func (m *Membership) Run(ctx context.Context, sub *pubsub.Subscription) {
+	err := m.Heartbeat()
+	if err != nil {
+		log.Errorf("Failed to send heartbeat: %v", err)
+	}
+	ticker := time.NewTicker(m.config.LeaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("Membership: listener stopped.")
+			return
+		case msg := <-sub.Ch:
+			event, ok := msg.(*fes.Event)
+			if !ok {
+				log.WithField("msg", msg).Error("Received a malformed message. Ignoring.")
+				continue
+			}
+			m.HandleEvent(event)
+		case <-ticker.C:
+			err := m.Heartbeat()
+			if err != nil {
+				log.Errorf("Failed to send heartbeat: %v", err)
+			}
+		}
+	}
+}
+
+// Load tracks the leases of the replicas that are already persisted in the backend, which avoids having to wait for
+// the next heartbeats of the other replicas after the replica has started.
+func (m *Membership) Load() error {
+	aggregates, err := m.backend.List(func(s string) bool {
+		return strings.HasPrefix(s, TypeReplica)
+	})
+	if err != nil {
+		return err
+	}
+	for _, aggregate := range aggregates {
+		if aggregate.Type != TypeReplica {
+			continue
+		}
+		events, err := m.backend.Get(aggregate)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			m.HandleEvent(event)
+		}
+	}
+	return nil
+}
+
+// Heartbeat renews the lease of the replica.
+func (m *Membership) Heartbeat() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.left {
+		return errors.New("replica has left the cluster")
+	}
+
+	aggregate := fes.NewAggregate(TypeReplica, m.config.ReplicaID)
+	m.heartbeats++
+	if deleter, ok := m.backend.(fes.Deleter); ok && m.heartbeats%compactionInterval == 0 {
+		// Only the last heartbeat matters, so the previous heartbeats can be removed. The lease of the replica is not
+		// affected, as the other replicas track the leases themselves.
+		err := deleter.Delete(aggregate)
+		if err != nil {
+			log.Warnf("Failed to remove previous heartbeats: %v", err)
+		}
+	}
+	expiresAt := time.Now().Add(m.config.LeaseDuration)
+	ts, err := ptypes.TimestampProto(expiresAt)
+	if err != nil {
+		return err
+	}
+	event, err := fes.NewEvent(aggregate, &events.ReplicaHeartbeat{
+		ExpiresAt: ts,
+	})
+	if err != nil {
+		return err
+	}
+	err = m.backend.Append(event)
+	if err != nil {
+		return err
+	}
+	m.renew(m.config.ReplicaID, expiresAt)
+	return nil
+}
+
+// Leave releases the lease of the replica, after which the other replicas take over its shard. Once the replica has
+// left, it does not renew its lease anymore.
+func (m *Membership) Leave() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.left {
+		return nil
+	}
+	m.left = true
+	delete(m.leases, m.config.ReplicaID)
+	event, err := fes.NewEvent(fes.NewAggregate(TypeReplica, m.config.ReplicaID), &events.ReplicaLeft{})
+	if err != nil {
+		return err
+	}
+	return m.backend.Append(event)
+}
+
+// HandleEvent renews or releases the lease of the replica of the event.
+func (m *Membership) HandleEvent(event *fes.Event) {
+	if event.GetAggregate().GetType() != TypeReplica {
+		return
+	}
+	replicaID := event.GetAggregate().GetId()
+	data, err := fes.UnmarshalEventData(event)
+	if err != nil {
+		log.WithField("event.id", event.GetId()).Errorf("Failed to unmarshal event: %v", err)
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	switch msg := data.(type) {
+	case *events.ReplicaHeartbeat:
+		expiresAt, err := ptypes.Timestamp(msg.GetExpiresAt())
+		if err != nil {
+			log.WithField("replica", replicaID).Errorf("Received heartbeat with invalid expiry: %v", err)
+			return
+		}
+		m.renew(replicaID, expiresAt)
+	case *events.ReplicaLeft:
+		if _, ok := m.leases[replicaID]; ok {
+			log.WithField("replica", replicaID).Info("Replica left.")
+			replicaChanges.WithLabelValues("left").Inc()
+			delete(m.leases, replicaID)
+		}
+	default:
+		log.WithField("replica", replicaID).Warnf("Skipping unimplemented event: %T", data)
+	}
+}
+
+// renew extends the lease of the replica, assuming that the lock is held.
+func (m *Membership) renew(replicaID string, expiresAt time.Time) {
+	if m.left && replicaID == m.config.ReplicaID {
+		return
+	}
+	lease, ok := m.leases[replicaID]
+	if !ok || !lease.After(time.Now()) {
+		log.WithField("replica", replicaID).Info("Replica joined.")
+		replicaChanges.WithLabelValues("joined").Inc()
+	}
+	if expiresAt.After(lease) {
+		m.leases[replicaID] = expiresAt
+	}
+}
+
+// Replicas returns the ids of the live replicas in sorted order. This replica is always considered to be live, until it
+// has left.
+func (m *Membership) Replicas() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := time.Now()
+	var replicas []string
+	for replicaID, expiresAt := range m.leases {
+		if expiresAt.After(now) || replicaID == m.config.ReplicaID {
+			replicas = append(replicas, replicaID)
+			continue
+		}
+		log.WithField("replica", replicaID).Info("Replica disappeared.")
+		replicaChanges.WithLabelValues("disappeared").Inc()
+		delete(m.leases, replicaID)
+	}
+	if _, ok := m.leases[m.config.ReplicaID]; !ok && !m.left {
+		replicas = append(replicas, m.config.ReplicaID)
+	}
+	sort.Strings(replicas)
+	replicasLive.Set(float64(len(replicas)))
+	return replicas
+}
+
+// Owner returns the id of the live replica that is responsible for the entity with the provided id.
+func (m *Membership) Owner(id string) string {
+	var owner string
+	var max uint64
+	for _, replicaID := range m.Replicas() {
+		if score := hash(replicaID, id); len(owner) == 0 || score > max {
+			owner = replicaID
+			max = score
+		}
+	}
+	return owner
+}
+
+// Contains returns true if this replica is responsible for the entity with the provided id.
+func (m *Membership) Contains(id string) bool {
+	return m.Owner(id) == m.config.ReplicaID
+}
+
+func hash(replicaID string, id string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(replicaID))
+	h.Write([]byte{0})
+	h.Write([]byte(id))
+	return h.Sum64()
+}
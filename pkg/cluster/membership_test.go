@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+// join creates a membership for the replica, which tracks the other replicas through the events of the backend.
+func join(t *testing.T, backend *mem.Backend, replicaID string, lease time.Duration) *Membership {
+	m := NewMembership(backend, Config{
+		ReplicaID:     replicaID,
+		LeaseDuration: lease,
+	})
+	sub := backend.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       50,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, TypeReplica),
+	})
+	go func() {
+		for msg := range sub.Ch {
+			m.HandleEvent(msg.(*fes.Event))
+		}
+	}()
+	assert.NoError(t, m.Load())
+	assert.NoError(t, m.Heartbeat())
+	return m
+}
+
+// assertReplicas asserts that the membership observes the expected replicas, allowing the events of the other replicas
+// to be handled asynchronously.
+func assertReplicas(t *testing.T, m *Membership, expected ...string) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && !assert.ObjectsAreEqual(expected, m.Replicas()) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, expected, m.Replicas())
+}
+
+func owners(ids []string, memberships ...*Membership) map[string][]string {
+	result := map[string][]string{}
+	for _, id := range ids {
+		for _, m := range memberships {
+			if m.Contains(id) {
+				result[id] = append(result[id], m.ID())
+			}
+		}
+	}
+	return result
+}
+
+func TestMembershipSharding(t *testing.T) {
+	backend := mem.NewBackend()
+	a := join(t, backend, "a", time.Minute)
+	b := join(t, backend, "b", time.Minute)
+	assertReplicas(t, a, "a", "b")
+	assertReplicas(t, b, "a", "b")
+
+	var ids []string
+	for i := 0; i < 100; i++ {
+		ids = append(ids, fmt.Sprintf("wi-%d", i))
+	}
+
+	// Each id is owned by exactly one replica, and both replicas own some of the ids.
+	shards := map[string]int{}
+	for id, replicas := range owners(ids, a, b) {
+		assert.Len(t, replicas, 1, id)
+		shards[replicas[0]]++
+	}
+	assert.Len(t, owners(ids, a, b), len(ids))
+	assert.NotZero(t, shards["a"])
+	assert.NotZero(t, shards["b"])
+
+	// The remaining replica takes over the ids of the replica that left.
+	assert.NoError(t, b.Leave())
+	assertReplicas(t, a, "a")
+	assertReplicas(t, b, "a")
+	for _, id := range ids {
+		assert.True(t, a.Contains(id))
+		assert.False(t, b.Contains(id))
+	}
+	assert.Error(t, b.Heartbeat())
+}
+
+func TestMembershipLeaseExpiry(t *testing.T) {
+	backend := mem.NewBackend()
+	a := join(t, backend, "a", time.Minute)
+	b := join(t, backend, "b", 100*time.Millisecond)
+
+	// A replica that joins later learns about the existing replicas from the backend.
+	c := join(t, backend, "c", time.Minute)
+	assertReplicas(t, c, "a", "b", "c")
+
+	// Without heartbeats, the lease of b expires and its ids are rebalanced over the remaining replicas.
+	time.Sleep(200 * time.Millisecond)
+	assertReplicas(t, a, "a", "c")
+	assertReplicas(t, c, "a", "c")
+	ids := []string{"wi-1", "wi-2", "wi-3", "wi-4", "wi-5", "wi-6", "wi-7", "wi-8"}
+	for id, replicas := range owners(ids, a, c) {
+		assert.Len(t, replicas, 1, id)
+	}
+	assert.Len(t, owners(ids, a, c), len(ids))
+
+	// Once b resumes its heartbeats, it rejoins.
+	assert.NoError(t, b.Heartbeat())
+	assertReplicas(t, a, "a", "b", "c")
+}
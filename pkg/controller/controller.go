@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/fes"
@@ -47,10 +48,46 @@ var (
 		Name:      "eval_queue_size",
 		Help:      "A gauge of the evaluation queue size",
 	}, []string{"controller"})
+
+	EvalQueueDeduplicated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "controller_workflow",
+		Name:      "eval_queue_deduplicated",
+		Help:      "Count of the submissions that were merged with an already queued or running evaluation.",
+	}, []string{"controller"})
+
+	EvalQueueWait = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "workflows",
+		Subsystem: "controller_workflow",
+		Name:      "eval_queue_wait",
+		Help:      "Duration that submissions waited in the evaluation queue, which grows if the workers fall behind.",
+	}, []string{"controller"})
+
+	EvalWorkersActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "controller_workflow",
+		Name:      "eval_workers_active",
+		Help:      "A gauge of the number of workers that are evaluating.",
+	}, []string{"controller"})
+
+	ExecutorJobsActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "controller_workflow",
+		Name:      "executor_jobs_active",
+		Help:      "A gauge of the number of jobs that the executor is running in the background.",
+	}, []string{"controller"})
+
+	ExecutorBackpressure = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "workflows",
+		Subsystem: "controller_workflow",
+		Name:      "executor_backpressure",
+		Help:      "Duration that submissions were blocked, because the executor was running its maximum of jobs.",
+	}, []string{"controller"})
 )
 
 func init() {
-	prometheus.MustRegister(EvalJobs, EvalDuration, EvalQueueSize, EvalRecovered)
+	prometheus.MustRegister(EvalJobs, EvalDuration, EvalQueueSize, EvalRecovered, EvalQueueDeduplicated,
+		EvalQueueWait, EvalWorkersActive, ExecutorJobsActive, ExecutorBackpressure)
 }
 
 type Controller interface {
@@ -60,6 +97,19 @@ type Controller interface {
 	Evaluate(id string)
 }
 
+// Halter is implemented by controllers that can be halted, which stops them from evaluating until they are resumed.
+type Halter interface {
+	Halt()
+	Resume()
+}
+
+// Shard determines the entities that a replica of a controller is responsible for. Dividing the entities over the
+// shards of multiple replicas allows the replicas to run alongside each other, without evaluating the same entities.
+type Shard interface {
+	// Contains returns true if the entity with the provided id is part of the shard.
+	Contains(id string) bool
+}
+
 type Action interface {
 	Apply() error
 }
@@ -75,8 +125,8 @@ type EvalContext interface {
 // MetaController is a 'controller for controllers', allowing for composition with controllers. It allows users to
 // interface with the metacontroller, instead of needing to control the lifecycle of all underlying controllers.
 type MetaController struct {
-	// suspended indicates whether this metacontroller is suspended from running.
-	suspended bool
+	// suspended indicates whether this metacontroller is suspended from running. It is non-zero if suspended.
+	suspended int32
 
 	// ctrls contains the controllers that the metacontroller manages.
 	ctrls []Controller
@@ -117,7 +167,7 @@ func (mc *MetaController) Start(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			if !mc.suspended {
+			if !mc.Halted() {
 				mc.Tick(tick)
 				tick++
 			}
@@ -125,6 +175,33 @@ func (mc *MetaController) Start(ctx context.Context) error {
 	}
 }
 
+// Halt suspends the metacontroller and halts the evaluations of the underlying controllers, until it is resumed.
+func (mc *MetaController) Halt() {
+	atomic.StoreInt32(&mc.suspended, 1)
+	for _, ctrl := range mc.ctrls {
+		if halter, ok := ctrl.(Halter); ok {
+			halter.Halt()
+		}
+	}
+	metaLog.Info("Controllers halted.")
+}
+
+// Resume resumes the metacontroller and the underlying controllers after they have been halted.
+func (mc *MetaController) Resume() {
+	for _, ctrl := range mc.ctrls {
+		if halter, ok := ctrl.(Halter); ok {
+			halter.Resume()
+		}
+	}
+	atomic.StoreInt32(&mc.suspended, 0)
+	metaLog.Info("Controllers resumed.")
+}
+
+// Halted returns true if the metacontroller has been halted.
+func (mc *MetaController) Halted() bool {
+	return atomic.LoadInt32(&mc.suspended) != 0
+}
+
 func (mc *MetaController) Tick(tick uint64) error {
 	var err error
 	for _, ctrl := range mc.ctrls {
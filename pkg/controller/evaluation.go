@@ -72,6 +72,9 @@ type EvalState struct {
 
 	// dataLock ensures thread-safe read and writes to this state. For example appending and reading logs.
 	dataLock sync.RWMutex
+
+	// notBefore is the time before which the resource does not need to be re-evaluated, unless it has changed.
+	notBefore time.Time
 }
 
 func NewEvalState(id string) *EvalState {
@@ -148,6 +151,22 @@ func (e *EvalState) Record(record EvalRecord) {
 	e.dataLock.Unlock()
 }
 
+// SetNotBefore postpones the periodic re-evaluation of the resource until the provided time, for example because the
+// resource is waiting for a point in time. Changes to the resource should still be evaluated immediately.
+func (e *EvalState) SetNotBefore(t time.Time) {
+	e.dataLock.Lock()
+	e.notBefore = t
+	e.dataLock.Unlock()
+}
+
+// NotBefore returns the time before which the resource does not need to be re-evaluated periodically. It returns the
+// zero time if the re-evaluation has not been postponed.
+func (e *EvalState) NotBefore() time.Time {
+	e.dataLock.RLock()
+	defer e.dataLock.RUnlock()
+	return e.notBefore
+}
+
 // EvalRecord contains all metadata related to a single evaluation of a controller.
 type EvalRecord struct {
 	// Timestamp is the time at which the evaluation started. As an evaluation should not take any significant amount
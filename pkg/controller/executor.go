@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"time"
+)
+
+// Executor runs jobs in the background, while bounding the number of jobs that run concurrently.
+//
+// Submit blocks while the maximum number of jobs is running, instead of dropping the job. This allows callers to
+// record that a job has been started before submitting it, without the risk that the job is never run.
+type Executor struct {
+	name  string
+	slots chan struct{}
+}
+
+// NewExecutor creates an executor that runs at most workers jobs concurrently. The name is used to label the
+// executor metrics.
+func NewExecutor(name string, workers int) *Executor {
+	return &Executor{
+		name:  name,
+		slots: make(chan struct{}, workers),
+	}
+}
+
+// Submit runs the job in the background, blocking until there is room for the job to run.
+func (e *Executor) Submit(job func()) {
+	select {
+	case e.slots <- struct{}{}:
+	default:
+		blocked := time.Now()
+		e.slots <- struct{}{}
+		ExecutorBackpressure.WithLabelValues(e.name).Observe(float64(time.Now().Sub(blocked)))
+	}
+	ExecutorJobsActive.WithLabelValues(e.name).Inc()
+	go func() {
+		defer func() {
+			ExecutorJobsActive.WithLabelValues(e.name).Dec()
+			<-e.slots
+		}()
+		job()
+	}()
+}
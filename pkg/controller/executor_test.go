@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor_Bounded(t *testing.T) {
+	e := NewExecutor("test", 2)
+	release := make(chan struct{})
+	started := make(chan int, 3)
+	for i := 0; i < 2; i++ {
+		i := i
+		e.Submit(func() {
+			started <- i
+			<-release
+		})
+	}
+
+	// The third job should only be submitted once one of the running jobs has finished.
+	submitted := make(chan struct{})
+	go func() {
+		e.Submit(func() {
+			started <- 2
+		})
+		close(submitted)
+	}()
+	<-started
+	<-started
+	select {
+	case <-submitted:
+		t.Fatal("submission should block while the executor is running its maximum of jobs")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release <- struct{}{}
+	<-submitted
+	assert.Equal(t, 2, <-started)
+	close(release)
+}
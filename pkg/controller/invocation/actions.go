@@ -7,6 +7,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/controller"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/scheduler"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
@@ -22,31 +23,54 @@ import (
 
 // ActonAbort aborts an invocation.
 type ActonAbort struct {
-	API          *api.Invocation
-	InvocationID string
+	API               *api.Invocation
+	InvocationID      string
+	InvocationVersion uint64
 }
 
 func (a *ActonAbort) Eval(cec controller.EvalContext) controller.Action {
 	ec := EnsureInvocationContext(cec)
 	a.InvocationID = ec.Invocation().ID()
+	a.InvocationVersion = ec.InvocationVersion()
 	return a
 }
 
 func (a *ActonAbort) Apply() error {
 	wfiLog.Info("Applying action: abort")
-	return a.API.Cancel(a.InvocationID)
+	return a.API.Cancel(a.InvocationID, api.WithExpectedVersion(a.InvocationVersion))
+}
+
+// ActionStart starts an invocation of which the deferred start has arrived.
+type ActionStart struct {
+	API               *api.Invocation
+	InvocationID      string
+	InvocationVersion uint64
+}
+
+func (a *ActionStart) Eval(cec controller.EvalContext) controller.Action {
+	ec := EnsureInvocationContext(cec)
+	a.InvocationID = ec.Invocation().ID()
+	a.InvocationVersion = ec.InvocationVersion()
+	return a
+}
+
+func (a *ActionStart) Apply() error {
+	wfiLog.Info("Applying action: start")
+	return a.API.Start(a.InvocationID, api.WithExpectedVersion(a.InvocationVersion))
 }
 
 // ActionFail halts an invocation.
 type ActionFail struct {
-	API          *api.Invocation
-	InvocationID string
-	Err          error
+	API               *api.Invocation
+	InvocationID      string
+	InvocationVersion uint64
+	Err               error
 }
 
 func (a *ActionFail) Eval(cec controller.EvalContext) controller.Action {
 	ec := EnsureInvocationContext(cec)
 	a.InvocationID = ec.Invocation().ID()
+	a.InvocationVersion = ec.InvocationVersion()
 	if a.Err == nil {
 		if s, ok := ec.EvalState().Last(); ok {
 			a.Err = s.Error
@@ -60,7 +84,7 @@ func (a *ActionFail) Eval(cec controller.EvalContext) controller.Action {
 
 func (a *ActionFail) Apply() error {
 	wfiLog.Infof("Applying action: fail (%v)", a.Err)
-	return a.API.Fail(a.InvocationID, a.Err)
+	return a.API.Fail(a.InvocationID, a.Err, api.WithExpectedVersion(a.InvocationVersion))
 }
 
 // ActionInvokeTask invokes a function
@@ -68,6 +92,7 @@ type ActionInvokeTask struct {
 	Wf         *types.Workflow
 	Wfi        *types.WorkflowInvocation
 	API        *api.Task
+	Executor   *controller.Executor
 	Task       *scheduler.InvokeTaskAction
 	StateStore *expr.Store
 }
@@ -98,21 +123,41 @@ func (a *ActionInvokeTask) Apply() error {
 		return fmt.Errorf("no resolved Task could be found for FunctionRef '%v'", task.Spec.FunctionRef)
 	}
 
-	// Pre-execution: Resolve expression inputs
+	// Pre-execution: Resolve the condition and the expression inputs
 	exprEvalStart := time.Now()
-	inputs, err := a.resolveInputs()
+	var run bool
+	var inputs map[string]*types.TypedValue
+	scope, err := a.resolveScope()
+	if err == nil {
+		run, err = a.resolveCondition(scope, task.Spec.GetWhen())
+	}
+	if err == nil && run {
+		inputs, err = a.resolveInputs(scope)
+	}
 	exprEvalDuration.Observe(float64(time.Now().Sub(exprEvalStart)))
 	if err != nil {
 		log.Error(err)
 		return err
 	}
 
+	// Skip the task if its condition evaluated to false
+	if !run {
+		log.Info("Skipping task, because its condition evaluated to false")
+		// Skipping the task at its current version prevents it from being skipped after it has been started.
+		version := api.TaskVersion(a.Wfi.GetStatus().GetTasks()[a.Task.Id])
+		return a.API.Skip(a.Wfi.ID(), a.Task.Id, api.WithExpectedVersion(version))
+	}
+
 	// Invoke task
 	spec := &types.TaskInvocationSpec{
 		FnRef:        task.Status.FnRef,
 		TaskId:       a.Task.Id,
 		InvocationId: a.Wfi.ID(),
 		Inputs:       inputs,
+		Timeout:      task.Spec.GetTimeout(),
+	}
+	if attempts := a.Wfi.GetStatus().GetTasks()[a.Task.Id].GetStatus().GetAttempts(); attempts > 0 {
+		log.Infof("Retrying task after %d failed attempt(s)", attempts)
 	}
 	log.Infof("Executing function: %v", spec.GetFnRef().Format())
 	if logrus.GetLevel() == logrus.DebugLevel {
@@ -123,19 +168,34 @@ func (a *ActionInvokeTask) Apply() error {
 			log.Debugf("Using inputs: %v", i)
 		}
 	}
-	_, err = a.API.Invoke(spec)
+	// The scheduler only schedules tasks that have not been started yet, or failed tasks that should be retried.
+	// Starting the task at its current version prevents it from being executed twice if it is scheduled again before
+	// it has been started.
+	version := api.TaskVersion(a.Wfi.GetStatus().GetTasks()[a.Task.Id])
+	started, err := a.API.Start(spec, api.WithExpectedVersion(version))
 	if err != nil {
-		log.Errorf("Failed to execute task: %v", err)
 		return err
 	}
 
+	// The function is executed in the background, to avoid occupying the evaluation worker for the duration of the
+	// task. The controller is notified of the progress of the task through the events of the task.
+	a.Executor.Submit(func() {
+		_, err := a.API.Run(started, api.WithExpectedVersion(version))
+		if err != nil {
+			if fes.IsConflict(err) {
+				log.Infof("Task was skipped or restarted concurrently: %v", err)
+			} else {
+				log.Errorf("Failed to execute task: %v", err)
+			}
+		}
+	})
 	return nil
 }
 
-func (a *ActionInvokeTask) resolveInputs() (map[string]*types.TypedValue, error) {
+// resolveScope sets up the scope for the expressions of the task.
+func (a *ActionInvokeTask) resolveScope() (*expr.Scope, error) {
 	log := a.logger()
 
-	// Setup the scope for the expressions
 	scope, err := expr.NewScope(a.Wf, a.Wfi)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create scope for task '%v'", a.Task.Id)
@@ -153,6 +213,29 @@ func (a *ActionInvokeTask) resolveInputs() (map[string]*types.TypedValue, error)
 		}
 	}
 
+	return scope, nil
+}
+
+// resolveCondition evaluates the condition of the task, returning whether the task should be run.
+func (a *ActionInvokeTask) resolveCondition(scope *expr.Scope, condition *types.TypedValue) (bool, error) {
+	if condition == nil {
+		return true, nil
+	}
+	resolved, err := expr.Resolve(scope, a.Task.Id, condition)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve condition: %v", err)
+	}
+	run, err := typedvalues.FormatBool(resolved)
+	if err != nil {
+		return false, fmt.Errorf("condition should evaluate to a boolean: %v", err)
+	}
+	a.logger().Infof("Resolved condition: %v -> %v", typedvalues.MustFormat(condition), run)
+	return run, nil
+}
+
+func (a *ActionInvokeTask) resolveInputs(scope *expr.Scope) (map[string]*types.TypedValue, error) {
+	log := a.logger()
+
 	// Resolve each of the inputs (based on priority)
 	inputs := map[string]*types.TypedValue{}
 	for _, input := range typedvalues.Prioritize(a.Task.Inputs) {
@@ -169,3 +252,24 @@ func (a *ActionInvokeTask) resolveInputs() (map[string]*types.TypedValue, error)
 	}
 	return inputs, nil
 }
+
+// ActionSkipTask skips a task that should not be run.
+type ActionSkipTask struct {
+	Wfi  *types.WorkflowInvocation
+	API  *api.Task
+	Task *scheduler.SkipTaskAction
+}
+
+func (a *ActionSkipTask) Eval(cec controller.EvalContext) controller.Action {
+	panic("not implemented")
+}
+
+func (a *ActionSkipTask) Apply() error {
+	logrus.WithFields(logrus.Fields{
+		"invocation": a.Wfi.ID(),
+		"task":       a.Task.Id,
+	}).Info("Skipping task")
+	// Skipping the task at its current version prevents it from being skipped after it has been started.
+	version := api.TaskVersion(a.Wfi.GetStatus().GetTasks()[a.Task.Id])
+	return a.API.Skip(a.Wfi.ID(), a.Task.Id, api.WithExpectedVersion(version))
+}
@@ -2,12 +2,13 @@ package invocation
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/controller"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
 	"github.com/fission/fission-workflows/pkg/fes"
@@ -20,9 +21,11 @@ import (
 )
 
 const (
-	NotificationBuffer   = 100
-	defaultEvalQueueSize = 50
-	Name                 = "invocation"
+	NotificationBuffer = 100
+	defaultWorkers     = 10
+	defaultTaskWorkers = 100
+	defaultTimeout     = time.Duration(10) * time.Minute
+	Name               = "invocation"
 )
 
 var (
@@ -55,6 +58,25 @@ func init() {
 	prometheus.MustRegister(invocationStatus, invocationDuration, exprEvalDuration)
 }
 
+// Config contains the options to tune the evaluation of invocations by the controller.
+type Config struct {
+	// Workers is the maximum number of invocations that are evaluated concurrently.
+	Workers int
+
+	// Timeout is the timeout of invocations of which neither the invocation nor the workflow specify a timeout.
+	Timeout time.Duration
+
+	// TaskWorkers is the maximum number of task functions that are executed concurrently. Starting tasks blocks while
+	// the maximum has been reached.
+	TaskWorkers int
+}
+
+var DefaultConfig = Config{
+	Workers:     defaultWorkers,
+	Timeout:     defaultTimeout,
+	TaskWorkers: defaultTaskWorkers,
+}
+
 type Controller struct {
 	invokeCache   fes.CacheReader
 	wfCache       fes.CacheReader
@@ -66,22 +88,44 @@ type Controller struct {
 	cancelFn      context.CancelFunc
 	evalPolicy    controller.Rule
 	evalCache     *controller.EvalCache
+	config        Config
 
-	// evalQueue is a queue of invocation ids
-	evalQueue chan string
+	// evalQueue is a priority queue of invocation ids, in which older invocations have a higher priority.
+	evalQueue *controller.EvalQueue
+
+	// taskExecutor executes the functions of the started tasks.
+	taskExecutor *controller.Executor
+
+	// halted is non-zero if the controller has been halted, in which case the invocations are not evaluated.
+	halted int32
+
+	// shard contains the invocations that the controller is responsible for. If nil, the controller is responsible for
+	// all invocations.
+	shard controller.Shard
 }
 
 func NewController(invokeCache fes.CacheReader, wfCache fes.CacheReader, workflowScheduler *scheduler.WorkflowScheduler,
-	taskAPI *api.Task, invocationAPI *api.Invocation, stateStore *expr.Store) *Controller {
+	taskAPI *api.Task, invocationAPI *api.Invocation, stateStore *expr.Store, config Config) *Controller {
+	if config.Workers <= 0 {
+		config.Workers = DefaultConfig.Workers
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultConfig.Timeout
+	}
+	if config.TaskWorkers <= 0 {
+		config.TaskWorkers = DefaultConfig.TaskWorkers
+	}
 	ctr := &Controller{
 		invokeCache:   invokeCache,
 		wfCache:       wfCache,
 		scheduler:     workflowScheduler,
 		taskAPI:       taskAPI,
 		invocationAPI: invocationAPI,
-		evalQueue:     make(chan string, defaultEvalQueueSize),
+		evalQueue:     controller.NewEvalQueue(Name),
+		taskExecutor:  controller.NewExecutor(Name, config.TaskWorkers),
 		evalCache:     controller.NewEvalCache(),
 		stateStore:    stateStore,
+		config:        config,
 
 		// States maintains an active cache of currently running invocations, with execution related data.
 		// This state information is considered preemptable and can be removed or lost at any time.
@@ -92,6 +136,16 @@ func NewController(invokeCache fes.CacheReader, wfCache fes.CacheReader, workflo
 	return ctr
 }
 
+// SetShard restricts the controller to the invocations in the shard. It should be called before the controller is
+// initialized.
+func (cr *Controller) SetShard(shard controller.Shard) {
+	cr.shard = shard
+}
+
+func (cr *Controller) owns(invocationID string) bool {
+	return cr.shard == nil || cr.shard.Contains(invocationID)
+}
+
 func (cr *Controller) Init(sctx context.Context) error {
 	ctx, cancelFn := context.WithCancel(sctx)
 	cr.cancelFn = cancelFn
@@ -119,22 +173,44 @@ func (cr *Controller) Init(sctx context.Context) error {
 	}
 
 	// process evaluation queue
-	go func(ctx context.Context) {
-		for {
-			select {
-			case eval := <-cr.evalQueue:
-				go cr.Evaluate(eval) // TODO limit number of goroutines
-				controller.EvalQueueSize.WithLabelValues("invocation").Dec()
-			case <-ctx.Done():
-				wfiLog.Debug("Evaluation queue listener stopped.")
-				return
-			}
-		}
-	}(ctx)
+	for i := 0; i < cr.config.Workers; i++ {
+		go cr.runWorker()
+	}
 
 	return nil
 }
 
+// runWorker evaluates the invocations in the evaluation queue, until the queue is closed.
+func (cr *Controller) runWorker() {
+	for {
+		invocationID, ok := cr.evalQueue.Pop()
+		if !ok {
+			wfiLog.Debug("Evaluation worker stopped.")
+			return
+		}
+		if atomic.LoadInt32(&cr.halted) != 0 {
+			// The invocations that are skipped are recovered by the control loops once the controller is resumed.
+			controller.EvalJobs.WithLabelValues(Name, "halted").Inc()
+			cr.evalQueue.Done(invocationID)
+			continue
+		}
+		controller.EvalWorkersActive.WithLabelValues(Name).Inc()
+		cr.Evaluate(invocationID)
+		controller.EvalWorkersActive.WithLabelValues(Name).Dec()
+		cr.evalQueue.Done(invocationID)
+	}
+}
+
+// Halt stops the controller from evaluating invocations, and thereby from starting new tasks, until it is resumed.
+func (cr *Controller) Halt() {
+	atomic.StoreInt32(&cr.halted, 1)
+}
+
+// Resume resumes the evaluation of invocations after the controller has been halted.
+func (cr *Controller) Resume() {
+	atomic.StoreInt32(&cr.halted, 0)
+}
+
 func (cr *Controller) handleMsg(msg pubsub.Msg) error {
 	wfiLog.WithField("labels", msg.Labels()).Debug("Handling invocation notification.")
 	switch n := msg.(type) {
@@ -156,7 +232,15 @@ func (cr *Controller) Notify(msg *fes.Notification) error {
 	if !ok {
 		panic(msg)
 	}
-	cr.submitEval(wfi.ID())
+	if msg.EventType == events.TypeOf(&events.InvocationRetried{}) {
+		// Discard the evaluations of the failed invocation, to avoid the errors that led to its failure from
+		// failing the retried invocation.
+		cr.evalCache.Del(wfi.ID())
+	}
+	if !cr.owns(wfi.ID()) {
+		return nil
+	}
+	cr.submitEval(wfi)
 	return nil
 }
 
@@ -177,15 +261,26 @@ func (cr *Controller) Tick(tick uint64) error {
 
 func (cr *Controller) checkEvalCaches() error {
 	for id, state := range cr.evalCache.List() {
+		if !cr.owns(id) {
+			// The invocation has been moved to the shard of another replica.
+			cr.evalCache.Del(id)
+			continue
+		}
 		last, ok := state.Last()
-		if !ok {
+		if !ok || time.Now().Before(state.NotBefore()) {
 			continue
 		}
 
 		reevaluateAt := last.Timestamp.Add(time.Duration(100) * time.Millisecond)
 		if time.Now().UnixNano() > reevaluateAt.UnixNano() {
+			wi := aggregates.NewWorkflowInvocation(id)
+			err := cr.invokeCache.Get(wi)
+			if err != nil {
+				log.Errorf("Failed to read '%v' from cache: %v.", wi.Aggregate(), err)
+				continue
+			}
 			controller.EvalRecovered.WithLabelValues(Name, "evalStore").Inc()
-			cr.submitEval(id)
+			cr.submitEval(wi)
 		}
 	}
 	return nil
@@ -196,7 +291,7 @@ func (cr *Controller) checkModelCaches() error {
 	// Short control loop
 	entities := cr.invokeCache.List()
 	for _, entity := range entities {
-		if _, ok := cr.evalCache.Get(entity.Id); ok {
+		if _, ok := cr.evalCache.Get(entity.Id); ok || !cr.owns(entity.Id) {
 			continue
 		}
 
@@ -209,29 +304,34 @@ func (cr *Controller) checkModelCaches() error {
 
 		if !wi.Status.Finished() {
 			controller.EvalRecovered.WithLabelValues(Name, "cache").Inc()
-			cr.submitEval(wi.ID())
+			cr.submitEval(wi)
 		}
 	}
 	return nil
 }
 
-func (cr *Controller) submitEval(ids ...string) bool {
-	for _, id := range ids {
-		select {
-		case cr.evalQueue <- id:
-			controller.EvalQueueSize.WithLabelValues(Name).Inc()
-			return true
-			// ok
-		default:
-			wfiLog.Warnf("Eval queue is full; dropping eval task for '%v'", id)
-			return false
-		}
+// submitEval queues the invocation for evaluation without blocking, so that the notification listener keeps up with the
+// notifications. Invocations that are closer to timing out are evaluated first.
+func (cr *Controller) submitEval(wfi *aggregates.WorkflowInvocation) bool {
+	wf := aggregates.NewWorkflow(wfi.GetSpec().GetWorkflowId())
+	err := cr.wfCache.Get(wf)
+	if err != nil {
+		wfiLog.Debugf("Failed to read workflow of invocation %v from cache: %v", wfi.ID(), err)
+	}
+	priority, err := wfi.Deadline(wf.Workflow, cr.config.Timeout)
+	if err != nil {
+		priority = time.Now()
 	}
-	return true
+	return cr.evalQueue.Push(wfi.ID(), priority)
 }
 
 func (cr *Controller) Evaluate(invocationID string) {
 	start := time.Now()
+	if !cr.owns(invocationID) {
+		wfiLog.Debugf("Invocation %s is not part of the shard of this controller", invocationID)
+		controller.EvalJobs.WithLabelValues(Name, "unowned").Inc()
+		return
+	}
 	// Fetch and attempt to claim the evaluation
 	evalState := cr.evalCache.GetOrCreate(invocationID)
 	select {
@@ -269,13 +369,18 @@ func (cr *Controller) Evaluate(invocationID string) {
 		log.Errorf("controller failed to get workflow '%s' for invocation id '%s': %v", wfi.Spec.WorkflowId,
 			invocationID, err)
 		controller.EvalJobs.WithLabelValues(Name, "error").Inc()
+
+		// The workflow might not have reached the cache yet, so ensure that the evaluation is retried.
+		record := controller.NewEvalRecord()
+		record.Action = &controller.ActionSkip{}
+		evalState.Record(record)
 		return
 	}
 
 	// Evaluate invocation
 	record := controller.NewEvalRecord() // TODO implement rulepath + cause
 
-	ec := NewEvalContext(evalState, wf.Workflow, wfi.WorkflowInvocation)
+	ec := NewEvalContext(evalState, wf.Workflow, wfi.WorkflowInvocation, wfi.Version())
 
 	action := cr.evalPolicy.Eval(ec)
 	record.Action = action
@@ -286,7 +391,11 @@ func (cr *Controller) Evaluate(invocationID string) {
 
 	// Execute action
 	err = action.Apply()
-	if err != nil {
+	if fes.IsConflict(err) {
+		// The invocation was modified concurrently; the evaluation is retried once the cache has caught up.
+		wfiLog.Infof("Action '%T' conflicted with a concurrent modification: %v", action, err)
+		controller.EvalJobs.WithLabelValues(Name, "conflict").Inc()
+	} else if err != nil {
 		log.Errorf("Action '%T' failed: %v", action, err)
 		record.Error = err
 	}
@@ -314,26 +423,25 @@ func (cr *Controller) Close() error {
 	}
 
 	cr.cancelFn()
+	cr.evalQueue.Close()
 	return nil
 }
 
-func (cr *Controller) createFailAction(invocationID string, err error) controller.Action {
+func (cr *Controller) createFailAction(invocationID string, invocationVersion uint64, err error) controller.Action {
 	return &ActionFail{
-		API:          cr.invocationAPI,
-		InvocationID: invocationID,
-		Err:          err,
+		API:               cr.invocationAPI,
+		InvocationID:      invocationID,
+		InvocationVersion: invocationVersion,
+		Err:               err,
 	}
 }
 
 func defaultPolicy(ctr *Controller) controller.Rule {
 	return &controller.RuleEvalUntilAction{
 		Rules: []controller.Rule{
-			&controller.RuleTimedOut{
-				OnTimedOut: &ActionFail{
-					API: ctr.invocationAPI,
-					Err: errors.New("timed out"),
-				},
-				Timeout: time.Duration(10) * time.Minute,
+			&RuleExceededTimeout{
+				InvocationAPI:  ctr.invocationAPI,
+				DefaultTimeout: ctr.config.Timeout,
 			},
 			&controller.RuleExceededErrorCount{
 				OnExceeded: &ActionFail{
@@ -341,15 +449,20 @@ func defaultPolicy(ctr *Controller) controller.Rule {
 				},
 				MaxErrorCount: 0,
 			},
+			&RuleIsDeferred{
+				InvocationAPI: ctr.invocationAPI,
+			},
 			&RuleHasCompleted{},
 			&RuleCheckIfCompleted{
 				InvocationAPI: ctr.invocationAPI,
 			},
 			&RuleWorkflowIsReady{},
+			&RuleIsPaused{},
 			&RuleSchedule{
 				Scheduler:     ctr.scheduler,
 				InvocationAPI: ctr.invocationAPI,
 				FunctionAPI:   ctr.taskAPI,
+				TaskExecutor:  ctr.taskExecutor,
 				StateStore:    ctr.stateStore,
 			},
 		},
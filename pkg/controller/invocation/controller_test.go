@@ -3,14 +3,24 @@ package invocation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/cluster"
+	"github.com/fission/fission-workflows/pkg/controller"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
 	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/fnenv/native"
+	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/scheduler"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,7 +40,7 @@ func TestController_Lifecycle(t *testing.T) {
 		"mock": mockRuntime,
 	}, es, dynamicAPI)
 
-	ctr := NewController(cache, cache, s, taskAPI, wfiAPI, expr.NewStore())
+	ctr := NewController(cache, cache, s, taskAPI, wfiAPI, expr.NewStore(), DefaultConfig)
 
 	err := ctr.Init(context.TODO())
 	assert.NoError(t, err)
@@ -39,6 +49,235 @@ func TestController_Lifecycle(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestController_Sharding runs the controller of one of two replicas, which should only evaluate the invocations in its
+// own shard until the other replica leaves.
+func TestController_Sharding(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+	es := mem.NewBackend()
+	wfCache := fes.NewSubscribedCache(ctx, fes.NewNamedMapCache("workflow"), func() fes.Entity {
+		return aggregates.NewWorkflow("")
+	}, es.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       50,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, aggregates.TypeWorkflow),
+	}))
+	wfiCache := fes.NewSubscribedCache(ctx, fes.NewNamedMapCache("invocation"), func() fes.Entity {
+		return aggregates.NewWorkflowInvocation("")
+	}, es.Subscribe(pubsub.SubscriptionOptions{
+		Buffer: 50,
+		LabelMatcher: labels.Or(
+			labels.In(fes.PubSubLabelAggregateType, aggregates.TypeWorkflowInvocation),
+			labels.In("parent.type", aggregates.TypeWorkflowInvocation)),
+	}))
+	internalRuntime := native.NewFunctionEnv(builtin.DefaultBuiltinFunctions)
+	resolver := fnenv.NewMetaResolver(map[string]fnenv.RuntimeResolver{
+		"internal": internalRuntime,
+	})
+	wfiAPI := api.NewInvocationAPI(es)
+	wfAPI := api.NewWorkflowAPI(es, resolver)
+	taskAPI := api.NewTaskAPI(map[string]fnenv.Runtime{
+		"internal": internalRuntime,
+	}, es, api.NewDynamicApi(wfAPI, wfiAPI))
+
+	// Join the replicas
+	a := cluster.NewMembership(es, cluster.Config{ReplicaID: "a", LeaseDuration: time.Minute})
+	b := cluster.NewMembership(es, cluster.Config{ReplicaID: "b", LeaseDuration: time.Minute})
+	assert.NoError(t, a.Heartbeat())
+	assert.NoError(t, b.Heartbeat())
+	assert.NoError(t, a.Load())
+	assert.Equal(t, []string{"a", "b"}, a.Replicas())
+
+	// Only run the controller of replica a
+	ctr := NewController(wfiCache, wfCache, &scheduler.WorkflowScheduler{}, taskAPI, wfiAPI, expr.NewStore(),
+		DefaultConfig)
+	ctr.SetShard(a)
+	metaCtr := controller.NewMetaController(ctr)
+	go metaCtr.Run(ctx)
+	defer metaCtr.Close()
+
+	spec := types.NewWorkflowSpec().SetOutput("noop").AddTask("noop", types.NewTaskSpec(builtin.Noop))
+	wfID, err := wfAPI.Create(spec)
+	assert.NoError(t, err)
+	_, err = wfAPI.Parse(&types.Workflow{
+		Metadata: types.NewObjectMetadata(wfID),
+		Spec:     spec,
+	})
+	assert.NoError(t, err)
+	waitFor(t, func() bool {
+		wf := aggregates.NewWorkflow(wfID)
+		return wfCache.Get(wf) == nil && wf.GetStatus().Ready()
+	})
+
+	var ownedByA, ownedByB []string
+	for i := 0; i < 20; i++ {
+		wfiID, err := wfiAPI.Invoke(types.NewWorkflowInvocationSpec(wfID))
+		assert.NoError(t, err)
+		if a.Contains(wfiID) {
+			ownedByA = append(ownedByA, wfiID)
+		} else {
+			ownedByB = append(ownedByB, wfiID)
+		}
+	}
+	finished := func(ids []string) func() bool {
+		return func() bool {
+			for _, id := range ids {
+				wfi := aggregates.NewWorkflowInvocation(id)
+				if wfiCache.Get(wfi) != nil || !wfi.GetStatus().Finished() {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	// The invocations in the shard of b are left untouched.
+	waitFor(t, finished(ownedByA))
+	time.Sleep(10 * controller.TickInterval)
+	for _, id := range ownedByB {
+		wfi := aggregates.NewWorkflowInvocation(id)
+		assert.NoError(t, wfiCache.Get(wfi))
+		assert.False(t, wfi.GetStatus().Finished(), id)
+	}
+
+	// Once b has left, the controller of a takes over its invocations.
+	assert.NoError(t, b.Leave())
+	assert.NoError(t, a.Load())
+	assert.Equal(t, []string{"a"}, a.Replicas())
+	waitFor(t, finished(ownedByB))
+}
+
+// waitFor polls the condition until it holds, failing the test if it does not hold within a few seconds.
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRuleExceededTimeout(t *testing.T) {
+	createdAt, _ := ptypes.TimestampProto(time.Now().Add(-time.Minute))
+	wf := &types.Workflow{
+		Spec: &types.WorkflowSpec{},
+	}
+	wfi := &types.WorkflowInvocation{
+		Metadata: &types.ObjectMetadata{
+			Id:        "wi-123",
+			CreatedAt: createdAt,
+		},
+		Spec: &types.WorkflowInvocationSpec{},
+	}
+	rule := &RuleExceededTimeout{
+		DefaultTimeout: time.Hour,
+	}
+	eval := func() controller.Action {
+		return rule.Eval(NewEvalContext(controller.NewEvalState(wfi.ID()), wf, wfi, 1))
+	}
+
+	// Within the default timeout
+	assert.Nil(t, eval())
+
+	// The timeout of the workflow overrides the default timeout.
+	wf.Spec.Timeout = ptypes.DurationProto(time.Second)
+	action := eval()
+	assert.IsType(t, &ActionFail{}, action)
+	assert.EqualValues(t, 1, action.(*ActionFail).InvocationVersion)
+
+	// The timeout of the invocation overrides the timeout of the workflow.
+	wfi.Spec.Timeout = ptypes.DurationProto(time.Hour)
+	assert.Nil(t, eval())
+
+	// The time that the invocation has been paused does not count towards the timeout.
+	wfi.Spec.Timeout = ptypes.DurationProto(30 * time.Second)
+	assert.IsType(t, &ActionFail{}, eval())
+	pausedAt, _ := ptypes.TimestampProto(time.Now().Add(-50 * time.Second))
+	wfi.Status = &types.WorkflowInvocationStatus{
+		PausedAt: pausedAt,
+	}
+	assert.Nil(t, eval())
+
+	// Once resumed, the clock continues from where it was stopped.
+	wfi.Status = &types.WorkflowInvocationStatus{
+		PausedFor: ptypes.DurationProto(50 * time.Second),
+	}
+	assert.Nil(t, eval())
+	wfi.Status.PausedFor = ptypes.DurationProto(20 * time.Second)
+	assert.IsType(t, &ActionFail{}, eval())
+}
+
+func TestController_CheckEvalCachesNotBefore(t *testing.T) {
+	cache := fes.NewMapCache()
+	es := mem.NewBackend()
+	wfiAPI := api.NewInvocationAPI(es)
+	taskAPI := api.NewTaskAPI(map[string]fnenv.Runtime{}, es, nil)
+	ctr := NewController(cache, cache, &scheduler.WorkflowScheduler{}, taskAPI, wfiAPI, expr.NewStore(), DefaultConfig)
+	wfi := aggregates.NewWorkflowInvocation("wi-123", &types.WorkflowInvocation{
+		Metadata: types.NewObjectMetadata("wi-123"),
+		Spec:     &types.WorkflowInvocationSpec{WorkflowId: "wf-123"},
+		Status:   &types.WorkflowInvocationStatus{Status: types.WorkflowInvocationStatus_SCHEDULED},
+	})
+	assert.NoError(t, cache.Put(wfi))
+	evalState := ctr.evalCache.GetOrCreate(wfi.ID())
+	evalState.Record(controller.EvalRecord{Timestamp: time.Now().Add(-time.Second)})
+
+	// Invocations of which the re-evaluation has been postponed are not re-evaluated periodically.
+	evalState.SetNotBefore(time.Now().Add(time.Hour))
+	assert.NoError(t, ctr.checkEvalCaches())
+	assert.Equal(t, 0, ctr.evalQueue.Len())
+
+	evalState.SetNotBefore(time.Now().Add(-time.Millisecond))
+	assert.NoError(t, ctr.checkEvalCaches())
+	assert.Equal(t, 1, ctr.evalQueue.Len())
+}
+
+func TestRuleIsDeferred(t *testing.T) {
+	es := mem.NewBackend()
+	wfiAPI := api.NewInvocationAPI(es)
+	wf := &types.Workflow{
+		Spec: &types.WorkflowSpec{
+			Timeout: ptypes.DurationProto(time.Minute),
+		},
+	}
+	id, err := wfiAPI.Invoke(&types.WorkflowInvocationSpec{
+		WorkflowId: "wf-123",
+		Delay:      ptypes.DurationProto(time.Hour),
+	})
+	assert.NoError(t, err)
+	load := func() *aggregates.WorkflowInvocation {
+		wfi := aggregates.NewWorkflowInvocation(id)
+		assert.NoError(t, fes.Rehydrate(es, *aggregates.NewWorkflowInvocationAggregate(id), wfi))
+		return wfi
+	}
+	wfi := load()
+	assert.Equal(t, types.WorkflowInvocationStatus_SCHEDULED, wfi.GetStatus().GetStatus())
+	rule := &RuleIsDeferred{
+		InvocationAPI: wfiAPI,
+	}
+	evalState := controller.NewEvalState(wfi.ID())
+	eval := func(rule controller.Rule) controller.Action {
+		return rule.Eval(NewEvalContext(evalState, wf, wfi.WorkflowInvocation, wfi.Version()))
+	}
+
+	// Until the invocation starts, it is skipped and its timeout does not apply. The periodic re-evaluation of the
+	// invocation is postponed until its start.
+	assert.IsType(t, &controller.ActionSkip{}, eval(rule))
+	startAt, err := wfi.StartAt()
+	assert.NoError(t, err)
+	assert.Equal(t, startAt, evalState.NotBefore())
+	assert.Nil(t, eval(&RuleExceededTimeout{InvocationAPI: wfiAPI}))
+
+	// Once the start has arrived, the invocation is started.
+	wfi.Spec.Delay = ptypes.DurationProto(0)
+	action := eval(rule)
+	assert.IsType(t, &ActionStart{}, action)
+	assert.NoError(t, action.Apply())
+	wfi = load()
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, wfi.GetStatus().GetStatus())
+	assert.Nil(t, eval(rule))
+}
+
 /*
 TODO test informer
 TODO test ticks
@@ -2,6 +2,8 @@ package invocation
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/controller"
@@ -21,19 +23,26 @@ type EvalContext interface {
 	controller.EvalContext
 	Workflow() *types.Workflow
 	Invocation() *types.WorkflowInvocation
+
+	// InvocationVersion is the version of the invocation that is being evaluated, which is used to detect concurrent
+	// modifications of the invocation.
+	InvocationVersion() uint64
 }
 
 type WfiEvalContext struct {
 	controller.EvalContext
-	wf  *types.Workflow
-	wfi *types.WorkflowInvocation
+	wf         *types.Workflow
+	wfi        *types.WorkflowInvocation
+	wfiVersion uint64
 }
 
-func NewEvalContext(state *controller.EvalState, wf *types.Workflow, wfi *types.WorkflowInvocation) WfiEvalContext {
+func NewEvalContext(state *controller.EvalState, wf *types.Workflow, wfi *types.WorkflowInvocation,
+	wfiVersion uint64) WfiEvalContext {
 	return WfiEvalContext{
 		EvalContext: controller.NewEvalContext(state),
 		wf:          wf,
 		wfi:         wfi,
+		wfiVersion:  wfiVersion,
 	}
 }
 
@@ -45,6 +54,68 @@ func (ec WfiEvalContext) Invocation() *types.WorkflowInvocation {
 	return ec.wfi
 }
 
+func (ec WfiEvalContext) InvocationVersion() uint64 {
+	return ec.wfiVersion
+}
+
+// RuleExceededTimeout fails the invocation once the duration since its start exceeds its timeout. The timeout
+// specified for the invocation takes precedence over the timeout of the workflow, which in turn takes precedence over
+// the default timeout. The time that the invocation has been paused does not count towards the timeout, so a paused
+// invocation does not time out.
+type RuleExceededTimeout struct {
+	InvocationAPI  *api.Invocation
+	DefaultTimeout time.Duration
+}
+
+func (r *RuleExceededTimeout) Eval(cec controller.EvalContext) controller.Action {
+	ec := EnsureInvocationContext(cec)
+	wfi := ec.Invocation()
+	deadline, err := wfi.Deadline(ec.Workflow(), r.DefaultTimeout)
+	if err != nil {
+		log.Warnf("Failed to determine deadline of invocation %v: %v", wfi.ID(), err)
+		return nil
+	}
+	if time.Now().Before(deadline) {
+		return nil
+	}
+	timeout, ok := wfi.Timeout(ec.Workflow())
+	if !ok {
+		timeout = r.DefaultTimeout
+	}
+	return &ActionFail{
+		API:               r.InvocationAPI,
+		InvocationID:      wfi.ID(),
+		InvocationVersion: ec.InvocationVersion(),
+		Err:               fmt.Errorf("timed out after %v", timeout),
+	}
+}
+
+// RuleIsDeferred holds off the evaluation of scheduled invocations until their start has arrived, at which point the
+// invocation is started. Until then, the periodic re-evaluation of the invocation is postponed to its start.
+type RuleIsDeferred struct {
+	InvocationAPI *api.Invocation
+}
+
+func (rd *RuleIsDeferred) Eval(cec controller.EvalContext) controller.Action {
+	ec := EnsureInvocationContext(cec)
+	wfi := ec.Invocation()
+	if wfi.GetStatus().GetStatus() != types.WorkflowInvocationStatus_SCHEDULED {
+		return nil
+	}
+	startAt, err := wfi.StartAt()
+	if err != nil {
+		log.Warnf("Failed to determine start of invocation %v: %v", wfi.ID(), err)
+	} else if time.Now().Before(startAt) {
+		ec.EvalState().SetNotBefore(startAt)
+		return &controller.ActionSkip{}
+	}
+	return &ActionStart{
+		API:               rd.InvocationAPI,
+		InvocationID:      wfi.ID(),
+		InvocationVersion: ec.InvocationVersion(),
+	}
+}
+
 type RuleHasCompleted struct{}
 
 func (cf *RuleHasCompleted) Eval(cec controller.EvalContext) controller.Action {
@@ -56,6 +127,18 @@ func (cf *RuleHasCompleted) Eval(cec controller.EvalContext) controller.Action {
 	return nil
 }
 
+// RuleIsPaused stops the evaluation of paused invocations, to avoid starting any new tasks until the invocation has
+// been resumed. Tasks that are already running are unaffected, so a paused invocation can still complete.
+type RuleIsPaused struct{}
+
+func (rp *RuleIsPaused) Eval(cec controller.EvalContext) controller.Action {
+	ec := EnsureInvocationContext(cec)
+	if ec.Invocation().GetStatus().GetStatus() == types.WorkflowInvocationStatus_PAUSED {
+		return &controller.ActionSkip{}
+	}
+	return nil
+}
+
 type RuleWorkflowIsReady struct {
 }
 
@@ -74,6 +157,7 @@ type RuleSchedule struct {
 	Scheduler     *scheduler.WorkflowScheduler
 	InvocationAPI *api.Invocation
 	FunctionAPI   *api.Task
+	TaskExecutor  *controller.Executor
 	StateStore    *expr.Store
 }
 
@@ -101,9 +185,10 @@ func (sf *RuleSchedule) Eval(cec controller.EvalContext) controller.Action {
 				log.Errorf("Failed to unpack Scheduler action: %v", err)
 			}
 			return &ActionFail{
-				API:          sf.InvocationAPI,
-				InvocationID: wfi.ID(),
-				Err:          errors.New(invokeAction.Reason),
+				API:               sf.InvocationAPI,
+				InvocationID:      wfi.ID(),
+				InvocationVersion: ec.InvocationVersion(),
+				Err:               errors.New(invokeAction.Reason),
 			}
 		case scheduler.ActionType_INVOKE_TASK:
 			invokeAction := &scheduler.InvokeTaskAction{}
@@ -115,9 +200,21 @@ func (sf *RuleSchedule) Eval(cec controller.EvalContext) controller.Action {
 				Wf:         wf,
 				Wfi:        wfi,
 				API:        sf.FunctionAPI,
+				Executor:   sf.TaskExecutor,
 				Task:       invokeAction,
 				StateStore: sf.StateStore,
 			})
+		case scheduler.ActionType_SKIP_TASK:
+			skipAction := &scheduler.SkipTaskAction{}
+			err := ptypes.UnmarshalAny(a.Payload, skipAction)
+			if err != nil {
+				log.Errorf("Failed to unpack Scheduler action: %v", err)
+			}
+			actions = append(actions, &ActionSkipTask{
+				Wfi:  wfi,
+				API:  sf.FunctionAPI,
+				Task: skipAction,
+			})
 		default:
 			log.Warnf("Unknown Scheduler action: '%v'", a)
 		}
@@ -138,26 +235,33 @@ func (cc *RuleCheckIfCompleted) Eval(cec controller.EvalContext) controller.Acti
 	var err error
 	finished := true
 	success := true
-	for id := range tasks {
+	for id, task := range tasks {
 		t, ok := wfi.Status.Tasks[id]
-		if !ok || !t.Status.Finished() {
+		// Failed tasks that will be retried have not finished yet.
+		if !ok || !t.Status.Finished() || task.GetSpec().GetRetry().ShouldRetry(t.Status) {
 			finished = false
 			break
 		} else {
-			success = success && t.Status.Status == types.TaskInvocationStatus_SUCCEEDED
+			success = success && taskSucceeded(task, t.Status, tasks)
 		}
 	}
 	if finished {
 		var finalOutput *types.TypedValue
-		if len(wf.Spec.OutputTask) != 0 {
-			finalOutput = typedvalues.ResolveTaskOutput(wf.Spec.OutputTask, wfi)
+		if outputTask := wf.Spec.OutputTask; len(outputTask) != 0 {
+			// The output of the fallback task replaces the output of a failed output task.
+			if fallback := tasks[outputTask].GetSpec().GetOnFailure().GetFallback(); len(fallback) > 0 &&
+				wfi.Status.Tasks[outputTask].GetStatus().GetStatus() == types.TaskInvocationStatus_FAILED {
+				outputTask = fallback
+			}
+			finalOutput = typedvalues.ResolveTaskOutput(outputTask, wfi)
 		}
 
 		// TODO extract to action
+		expectedVersion := api.WithExpectedVersion(ec.InvocationVersion())
 		if success {
-			err = cc.InvocationAPI.Complete(wfi.ID(), finalOutput)
+			err = cc.InvocationAPI.Complete(wfi.ID(), finalOutput, expectedVersion)
 		} else {
-			err = cc.InvocationAPI.Fail(wfi.ID(), errors.New("not all tasks succeeded"))
+			err = cc.InvocationAPI.Fail(wfi.ID(), errors.New("not all tasks succeeded"), expectedVersion)
 		}
 		if err != nil {
 			return &controller.ActionError{
@@ -168,6 +272,25 @@ func (cc *RuleCheckIfCompleted) Eval(cec controller.EvalContext) controller.Acti
 	return nil
 }
 
+// taskSucceeded returns true if the finished task does not fail the invocation. Skipped tasks and failed tasks of which
+// the failure is handled by their failure policy are considered to have succeeded; the success of a fallback task is
+// accounted for by the fallback task itself.
+func taskSucceeded(task *types.Task, status *types.TaskInvocationStatus, tasks map[string]*types.Task) bool {
+	switch status.GetStatus() {
+	case types.TaskInvocationStatus_SUCCEEDED, types.TaskInvocationStatus_SKIPPED:
+		return true
+	case types.TaskInvocationStatus_FAILED:
+		policy := task.GetSpec().GetOnFailure()
+		if fallback := policy.GetFallback(); len(fallback) > 0 {
+			_, ok := tasks[fallback]
+			return ok
+		}
+		return policy.HandlesFailure()
+	default:
+		return false
+	}
+}
+
 func EnsureInvocationContext(cec controller.EvalContext) EvalContext {
 	ec, ok := cec.(EvalContext)
 	if !ok {
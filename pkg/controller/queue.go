@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// EvalQueue is a priority queue of the ids of the objects that need to be evaluated.
+//
+// The queue de-duplicates the ids: submitting an id that is already queued only raises its priority if needed.
+// Likewise, an id that is submitted while it is being evaluated is queued again once the evaluation is done, which
+// ensures that an object is never evaluated concurrently, without losing the submission.
+//
+// As each id is queued at most once, the size of the queue is bounded by the number of objects. The queue is therefore
+// not limited in size, which allows Push to never block or drop submissions. This is needed because submissions are
+// made from the notification listeners, which would otherwise fall behind and miss notifications. If the workers do
+// not keep up with the submissions, this is reported by the size of the queue and the time that ids wait in it.
+type EvalQueue struct {
+	name   string
+	items  evalItems
+	queued map[string]*evalItem
+
+	// processing contains the ids that have been popped, but not yet marked as done. If an id is submitted while it
+	// is being processed, the submission is deferred until it is done.
+	processing map[string]*evalItem
+	seq        uint64
+	closed     bool
+	lock       sync.Mutex
+	notEmpty   *sync.Cond
+}
+
+// NewEvalQueue creates a new queue. The name is used to label the queue metrics.
+func NewEvalQueue(name string) *EvalQueue {
+	q := &EvalQueue{
+		name:       name,
+		queued:     map[string]*evalItem{},
+		processing: map[string]*evalItem{},
+	}
+	q.notEmpty = sync.NewCond(&q.lock)
+	return q
+}
+
+// Push submits the id for evaluation without blocking. Ids with an earlier priority time are evaluated first; ids with
+// equal priorities are evaluated in order of submission. It returns false if the queue has been closed.
+func (q *EvalQueue) Push(id string, priority time.Time) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return false
+	}
+	if item, ok := q.queued[id]; ok {
+		EvalQueueDeduplicated.WithLabelValues(q.name).Inc()
+		if priority.Before(item.priority) {
+			item.priority = priority
+			heap.Fix(&q.items, item.index)
+		}
+		return true
+	}
+	if item, ok := q.processing[id]; ok {
+		EvalQueueDeduplicated.WithLabelValues(q.name).Inc()
+		if !item.requeue || priority.Before(item.priority) {
+			item.priority = priority
+		}
+		item.requeue = true
+		return true
+	}
+	q.push(&evalItem{id: id, priority: priority})
+	return true
+}
+
+// Pop blocks until an id is available, and returns the id with the highest priority. The id has to be marked as done
+// once it has been evaluated. It returns false if the queue has been closed.
+func (q *EvalQueue) Pop() (string, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for len(q.items) == 0 && !q.closed {
+		q.notEmpty.Wait()
+	}
+	if q.closed {
+		return "", false
+	}
+	item := heap.Pop(&q.items).(*evalItem)
+	delete(q.queued, item.id)
+	item.requeue = false
+	q.processing[item.id] = item
+	EvalQueueSize.WithLabelValues(q.name).Dec()
+	EvalQueueWait.WithLabelValues(q.name).Observe(float64(time.Now().Sub(item.queuedAt)))
+	return item.id, true
+}
+
+// Done marks the evaluation of the id as finished, queueing the id again if it was submitted during the evaluation.
+func (q *EvalQueue) Done(id string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	item, ok := q.processing[id]
+	if !ok {
+		return
+	}
+	delete(q.processing, id)
+	if item.requeue && !q.closed {
+		q.push(&evalItem{id: id, priority: item.priority})
+	}
+}
+
+// Len returns the number of queued ids, excluding the ids that are being evaluated.
+func (q *EvalQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.items)
+}
+
+// Close releases all goroutines blocked in Pop. Subsequent submissions are ignored.
+func (q *EvalQueue) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closed = true
+	q.notEmpty.Broadcast()
+}
+
+func (q *EvalQueue) push(item *evalItem) {
+	q.seq++
+	item.seq = q.seq
+	item.queuedAt = time.Now()
+	heap.Push(&q.items, item)
+	q.queued[item.id] = item
+	EvalQueueSize.WithLabelValues(q.name).Inc()
+	q.notEmpty.Signal()
+}
+
+type evalItem struct {
+	id       string
+	priority time.Time
+	seq      uint64
+	index    int
+	requeue  bool
+	queuedAt time.Time
+}
+
+// evalItems implements heap.Interface, ordering the items by priority and submission.
+type evalItems []*evalItem
+
+func (e evalItems) Len() int {
+	return len(e)
+}
+
+func (e evalItems) Less(i, j int) bool {
+	if e[i].priority.Equal(e[j].priority) {
+		return e[i].seq < e[j].seq
+	}
+	return e[i].priority.Before(e[j].priority)
+}
+
+func (e evalItems) Swap(i, j int) {
+	e[i], e[j] = e[j], e[i]
+	e[i].index = i
+	e[j].index = j
+}
+
+func (e *evalItems) Push(x interface{}) {
+	item := x.(*evalItem)
+	item.index = len(*e)
+	*e = append(*e, item)
+}
+
+func (e *evalItems) Pop() interface{} {
+	old := *e
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*e = old[:n-1]
+	return item
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func popAll(t *testing.T, q *EvalQueue) []string {
+	var ids []string
+	for q.Len() > 0 {
+		id, ok := q.Pop()
+		assert.True(t, ok)
+		q.Done(id)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestEvalQueue_Priority(t *testing.T) {
+	q := NewEvalQueue("test")
+	now := time.Now()
+	q.Push("new", now)
+	q.Push("old", now.Add(-time.Minute))
+	q.Push("same1", now.Add(-time.Second))
+	q.Push("same2", now.Add(-time.Second))
+
+	assert.Equal(t, []string{"old", "same1", "same2", "new"}, popAll(t, q))
+}
+
+func TestEvalQueue_Deduplicate(t *testing.T) {
+	q := NewEvalQueue("test")
+	now := time.Now()
+	q.Push("a", now)
+	q.Push("b", now.Add(time.Second))
+	q.Push("a", now.Add(time.Minute))
+	// Resubmitting with an earlier time should raise the priority of the queued id.
+	q.Push("b", now.Add(-time.Second))
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, []string{"b", "a"}, popAll(t, q))
+
+	// Submissions during the evaluation of an id should be deferred until the evaluation is done.
+	q.Push("a", now)
+	id, ok := q.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, "a", id)
+	q.Push("a", now)
+	q.Push("a", now)
+	assert.Equal(t, 0, q.Len())
+	q.Done("a")
+	assert.Equal(t, []string{"a"}, popAll(t, q))
+}
+
+func TestEvalQueue_NonBlocking(t *testing.T) {
+	q := NewEvalQueue("test")
+	now := time.Now()
+	for i := 0; i < 1000; i++ {
+		assert.True(t, q.Push(fmt.Sprintf("%d", i), now))
+	}
+	assert.Equal(t, 1000, q.Len())
+	assert.Len(t, popAll(t, q), 1000)
+
+	// Closing the queue should release blocked consumers.
+	go q.Close()
+	_, ok := q.Pop()
+	assert.False(t, ok)
+	assert.False(t, q.Push("a", now))
+}
@@ -0,0 +1,300 @@
+// Package schedule contains the controller that invokes the workflows of schedules, according to their cron
+// expressions.
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/controller"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	Name = "schedule"
+
+	// DefaultStartingDeadline is the duration after the scheduled time of a run after which the run is considered to
+	// be missed, if it has not invoked the workflow by then.
+	DefaultStartingDeadline = time.Minute
+
+	// maxMissedRuns bounds the number of missed runs that are counted in a single evaluation, to avoid iterating over
+	// all runs of a frequent schedule that has not been evaluated for a long time.
+	maxMissedRuns = 10000
+
+	// The reasons of missed runs
+	ReasonDeadlineExceeded = "the run was not started before its starting deadline"
+	ReasonConcurrent       = "the invocation of the previous run has not finished"
+)
+
+var (
+	log = logrus.WithField("component", "controller.schedule")
+
+	scheduleRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "controller_schedule",
+		Name:      "runs_total",
+		Help:      "Count of the runs of schedules, by whether they invoked the workflow or were missed.",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(scheduleRuns)
+}
+
+// Config contains the options of the schedule controller.
+type Config struct {
+	// StartingDeadline is the duration after the scheduled time of a run after which the run is recorded as missed. If
+	// not set, the DefaultStartingDeadline is used.
+	StartingDeadline time.Duration
+}
+
+// Controller invokes the workflows of the schedules when their runs are due, and records the runs that were missed,
+// such as the runs that were due while no controller was running.
+type Controller struct {
+	scheduleCache fes.CacheReader
+	wfiCache      fes.CacheReader
+	scheduleAPI   *api.Schedule
+	invocationAPI *api.Invocation
+	config        Config
+
+	// handled contains the scheduled time of the last run that the controller handled for each schedule, which
+	// prevents runs from being handled twice before the cache has caught up.
+	handled map[string]time.Time
+	lock    sync.Mutex
+
+	// halted is non-zero if the controller has been halted, in which case the schedules are not evaluated.
+	halted int32
+
+	// shard contains the schedules that the controller is responsible for. If nil, the controller is responsible for
+	// all schedules.
+	shard controller.Shard
+}
+
+func NewController(scheduleCache fes.CacheReader, wfiCache fes.CacheReader, scheduleAPI *api.Schedule,
+	invocationAPI *api.Invocation, config Config) *Controller {
+	if config.StartingDeadline <= 0 {
+		config.StartingDeadline = DefaultStartingDeadline
+	}
+	return &Controller{
+		scheduleCache: scheduleCache,
+		wfiCache:      wfiCache,
+		scheduleAPI:   scheduleAPI,
+		invocationAPI: invocationAPI,
+		config:        config,
+		handled:       map[string]time.Time{},
+	}
+}
+
+// SetShard restricts the controller to the schedules in the shard. It should be called before the controller is
+// initialized.
+func (c *Controller) SetShard(shard controller.Shard) {
+	c.shard = shard
+}
+
+func (c *Controller) owns(scheduleID string) bool {
+	return c.shard == nil || c.shard.Contains(scheduleID)
+}
+
+func (c *Controller) Init(ctx context.Context) error {
+	return nil
+}
+
+// Halt stops the controller from invoking workflows until it is resumed. The runs that were due while the controller
+// was halted are recorded as missed, unless they are still within their starting deadline once the controller resumes.
+func (c *Controller) Halt() {
+	atomic.StoreInt32(&c.halted, 1)
+}
+
+// Resume resumes the evaluation of schedules after the controller has been halted.
+func (c *Controller) Resume() {
+	atomic.StoreInt32(&c.halted, 0)
+}
+
+func (c *Controller) Tick(tick uint64) error {
+	// Cron expressions have a resolution of a second, so there is no need to evaluate the schedules at every tick.
+	if tick%10 != 0 {
+		return nil
+	}
+	for _, entity := range c.scheduleCache.List() {
+		c.Evaluate(entity.Id)
+	}
+	return nil
+}
+
+func (c *Controller) Notify(msg *fes.Notification) error {
+	schedule, ok := msg.Payload.(*aggregates.Schedule)
+	if !ok {
+		return fmt.Errorf("received notification of invalid type '%T'. Expected '*aggregates.Schedule'", msg.Payload)
+	}
+	c.Evaluate(schedule.ID())
+	return nil
+}
+
+// Evaluate handles the runs of the schedule that are due, either by invoking the workflow or by recording the runs as
+// missed.
+func (c *Controller) Evaluate(scheduleID string) {
+	if atomic.LoadInt32(&c.halted) != 0 {
+		controller.EvalJobs.WithLabelValues(Name, "halted").Inc()
+		return
+	}
+	if !c.owns(scheduleID) {
+		controller.EvalJobs.WithLabelValues(Name, "unowned").Inc()
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	schedule := aggregates.NewSchedule(scheduleID)
+	err := c.scheduleCache.Get(schedule)
+	if err != nil || schedule.Schedule == nil {
+		log.Errorf("Failed to read schedule '%s' from cache: %v", scheduleID, err)
+		controller.EvalJobs.WithLabelValues(Name, "error").Inc()
+		return
+	}
+	if schedule.GetStatus().Deleted() {
+		delete(c.handled, scheduleID)
+		return
+	}
+
+	now := time.Now()
+	runs, err := dueRuns(schedule.Schedule, c.handled[scheduleID], now)
+	if err != nil {
+		log.Errorf("Failed to determine the runs of schedule '%s': %v", scheduleID, err)
+		controller.EvalJobs.WithLabelValues(Name, "error").Inc()
+		return
+	}
+	if len(runs) == 0 {
+		controller.EvalJobs.WithLabelValues(Name, "noop").Inc()
+		return
+	}
+	err = c.handleRuns(schedule, runs, now)
+	if fes.IsConflict(err) {
+		// The schedule was modified concurrently; the runs are re-evaluated once the cache has caught up.
+		log.Infof("Schedule '%s' was modified concurrently: %v", scheduleID, err)
+		controller.EvalJobs.WithLabelValues(Name, "conflict").Inc()
+		return
+	} else if err != nil {
+		log.Errorf("Failed to handle the runs of schedule '%s': %v", scheduleID, err)
+		controller.EvalJobs.WithLabelValues(Name, "error").Inc()
+		return
+	}
+	c.handled[scheduleID] = runs[len(runs)-1]
+	controller.EvalJobs.WithLabelValues(Name, "action").Inc()
+}
+
+// handleRuns invokes the workflow for the last of the due runs, if it is within its starting deadline and allowed by
+// the concurrency policy, and records the other runs as missed.
+func (c *Controller) handleRuns(schedule *aggregates.Schedule, runs []time.Time, now time.Time) error {
+	version := schedule.Version()
+	last := runs[len(runs)-1]
+	if now.Sub(last) > c.config.StartingDeadline {
+		return c.recordMissed(schedule.ID(), last, len(runs), ReasonDeadlineExceeded, version)
+	}
+	if len(runs) > 1 {
+		err := c.recordMissed(schedule.ID(), runs[len(runs)-2], len(runs)-1, ReasonDeadlineExceeded, version)
+		if err != nil {
+			return err
+		}
+		version++
+	}
+
+	spec := schedule.GetSpec()
+	if previous := schedule.GetStatus().GetLastInvocationId(); c.unfinished(previous) {
+		switch spec.GetConcurrencyPolicy() {
+		case types.ScheduleSpec_FORBID:
+			return c.recordMissed(schedule.ID(), last, 1, ReasonConcurrent, version)
+		case types.ScheduleSpec_REPLACE:
+			err := c.invocationAPI.Cancel(previous)
+			if err != nil {
+				log.Warnf("Failed to cancel the previous invocation '%s' of schedule '%s': %v", previous,
+					schedule.ID(), err)
+			}
+		}
+	}
+
+	// The handled runs are only tracked in memory, so the run can be handled again after a restart or by another
+	// controller before it has been recorded. The idempotency key of the run ensures that it is only invoked once.
+	invocationID, err := c.invocationAPI.Invoke(&types.WorkflowInvocationSpec{
+		WorkflowId:     spec.GetWorkflowId(),
+		Inputs:         spec.GetInputs(),
+		IdempotencyKey: runIdempotencyKey(schedule.ID(), last),
+	})
+	if err != nil {
+		return err
+	}
+	// Even if the run cannot be recorded, the workflow should not be invoked again for the run.
+	c.handled[schedule.ID()] = last
+	log.WithFields(logrus.Fields{
+		"schedule":   schedule.ID(),
+		"invocation": invocationID,
+	}).Infof("Invoked workflow '%s' for the run scheduled at %v", spec.GetWorkflowId(), last)
+	scheduleRuns.WithLabelValues("invoked").Inc()
+	return c.scheduleAPI.Invoked(schedule.ID(), last, invocationID, api.WithExpectedVersion(version))
+}
+
+// runIdempotencyKey returns the idempotency key of the invocation of the run of the schedule at the scheduled time.
+func runIdempotencyKey(scheduleID string, scheduledAt time.Time) string {
+	return fmt.Sprintf("schedule-%s-%d", scheduleID, scheduledAt.UnixNano())
+}
+
+func (c *Controller) recordMissed(scheduleID string, scheduledAt time.Time, count int, reason string,
+	version uint64) error {
+	log.WithField("schedule", scheduleID).Warnf("Missed %d run(s) up to %v: %s", count, scheduledAt, reason)
+	scheduleRuns.WithLabelValues("missed").Add(float64(count))
+	return c.scheduleAPI.Missed(scheduleID, scheduledAt, int64(count), reason, api.WithExpectedVersion(version))
+}
+
+// unfinished returns true if the invocation exists and has not finished yet.
+func (c *Controller) unfinished(invocationID string) bool {
+	if len(invocationID) == 0 {
+		return false
+	}
+	wfi := aggregates.NewWorkflowInvocation(invocationID)
+	err := c.wfiCache.Get(wfi)
+	if err != nil || wfi.GetStatus() == nil {
+		// The invocation has been removed after it finished.
+		return false
+	}
+	return !wfi.GetStatus().Finished()
+}
+
+// dueRuns returns the scheduled times of the runs of the schedule that are due at the provided time, in chronological
+// order. Runs at or before the time up to which the runs have been handled are not included; the handled time
+// complements the status of the schedule, which might not reflect the latest runs yet.
+func dueRuns(schedule *types.Schedule, handled time.Time, now time.Time) ([]time.Time, error) {
+	spec := schedule.GetSpec()
+	cronSchedule, err := cron.ParseStandard(spec.GetCron())
+	if err != nil {
+		return nil, err
+	}
+	loc, err := spec.Location()
+	if err != nil {
+		return nil, err
+	}
+	until, err := ptypes.Timestamp(schedule.GetStatus().GetScheduledUntil())
+	if err != nil {
+		return nil, err
+	}
+	if handled.After(until) {
+		until = handled
+	}
+
+	var runs []time.Time
+	for next := cronSchedule.Next(until.In(loc)); !next.IsZero() && !next.After(now); next = cronSchedule.Next(next) {
+		runs = append(runs, next)
+		if len(runs) >= maxMissedRuns {
+			break
+		}
+	}
+	return runs, nil
+}
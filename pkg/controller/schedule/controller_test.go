@@ -0,0 +1,181 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/aggregates"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueRuns(t *testing.T) {
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			panic(err)
+		}
+		return ts
+	}
+	cases := []struct {
+		name           string
+		cron           string
+		timezone       string
+		scheduledUntil time.Time
+		handled        time.Time
+		now            time.Time
+		expected       []time.Time
+	}{
+		{
+			name:           "none due",
+			cron:           "*/5 * * * *",
+			scheduledUntil: at("2018-06-01T10:00:00Z"),
+			now:            at("2018-06-01T10:04:59Z"),
+		},
+		{
+			name:           "multiple due",
+			cron:           "*/5 * * * *",
+			scheduledUntil: at("2018-06-01T10:00:00Z"),
+			now:            at("2018-06-01T10:10:00Z"),
+			expected:       []time.Time{at("2018-06-01T10:05:00Z"), at("2018-06-01T10:10:00Z")},
+		},
+		{
+			name:           "handled after scheduled until",
+			cron:           "*/5 * * * *",
+			scheduledUntil: at("2018-06-01T10:00:00Z"),
+			handled:        at("2018-06-01T10:05:00Z"),
+			now:            at("2018-06-01T10:12:00Z"),
+			expected:       []time.Time{at("2018-06-01T10:10:00Z")},
+		},
+		{
+			name:           "handled before scheduled until",
+			cron:           "*/5 * * * *",
+			scheduledUntil: at("2018-06-01T10:05:00Z"),
+			handled:        at("2018-06-01T10:00:00Z"),
+			now:            at("2018-06-01T10:12:00Z"),
+			expected:       []time.Time{at("2018-06-01T10:10:00Z")},
+		},
+		{
+			name:           "timezone",
+			cron:           "0 9 * * *",
+			timezone:       "Europe/Amsterdam",
+			scheduledUntil: at("2018-06-01T00:00:00Z"),
+			now:            at("2018-06-02T00:00:00Z"),
+			expected:       []time.Time{at("2018-06-01T07:00:00Z")},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			until, err := ptypes.TimestampProto(c.scheduledUntil)
+			assert.NoError(t, err)
+			runs, err := dueRuns(&types.Schedule{
+				Spec: &types.ScheduleSpec{
+					WorkflowId: "wf-123",
+					Cron:       c.cron,
+					Timezone:   c.timezone,
+				},
+				Status: &types.ScheduleStatus{
+					ScheduledUntil: until,
+				},
+			}, c.handled, c.now)
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), len(runs))
+			for i := range c.expected {
+				assert.True(t, c.expected[i].Equal(runs[i]), "expected %v, got %v", c.expected[i], runs[i])
+			}
+		})
+	}
+}
+
+func TestController_Evaluate(t *testing.T) {
+	es := mem.NewBackend()
+	scheduleAPI := api.NewScheduleAPI(es)
+	invocationAPI := api.NewInvocationAPI(es)
+	scheduleCache := fes.NewMapCache()
+	wfiCache := fes.NewMapCache()
+
+	id, err := scheduleAPI.Create(&types.ScheduleSpec{
+		WorkflowId:        "wf-123",
+		Cron:              "* * * * *",
+		ConcurrencyPolicy: types.ScheduleSpec_FORBID,
+	})
+	assert.NoError(t, err)
+
+	// load reads the schedule from the event store, pretending that the runs of the schedule have been handled up to
+	// the provided time.
+	load := func(scheduledUntil time.Time) *aggregates.Schedule {
+		schedule := aggregates.NewSchedule(id)
+		err := fes.Rehydrate(es, *aggregates.NewScheduleAggregate(id), schedule)
+		assert.NoError(t, err)
+		schedule.Status.ScheduledUntil, err = ptypes.TimestampProto(scheduledUntil)
+		assert.NoError(t, err)
+		assert.NoError(t, scheduleCache.Put(schedule))
+		return schedule
+	}
+
+	// Of the two runs that are due, the first one is past its starting deadline.
+	ctr := NewController(scheduleCache, wfiCache, scheduleAPI, invocationAPI, Config{})
+	load(time.Now().Add(-2 * time.Minute))
+	ctr.Evaluate(id)
+	schedule := load(time.Now().Add(-time.Minute))
+	assert.EqualValues(t, 1, schedule.GetStatus().GetMissedRuns())
+	assert.Equal(t, ReasonDeadlineExceeded, schedule.GetStatus().GetLastMissedReason())
+	invocationID := schedule.GetStatus().GetLastInvocationId()
+	assert.NotEmpty(t, invocationID)
+	wfi := aggregates.NewWorkflowInvocation(invocationID)
+	assert.NoError(t, fes.Rehydrate(es, *aggregates.NewWorkflowInvocationAggregate(invocationID), wfi))
+	assert.Equal(t, "wf-123", wfi.GetSpec().GetWorkflowId())
+	assert.NoError(t, wfiCache.Put(wfi))
+
+	// The run that has been handled is not handled again, even if the cache has not caught up yet.
+	ctr.Evaluate(id)
+	assert.Equal(t, schedule.Version(), load(time.Now().Add(-time.Minute)).Version())
+
+	// With the previous invocation still running, the next run is missed.
+	ctr = NewController(scheduleCache, wfiCache, scheduleAPI, invocationAPI, Config{})
+	ctr.Evaluate(id)
+	schedule = load(time.Now())
+	assert.EqualValues(t, 2, schedule.GetStatus().GetMissedRuns())
+	assert.Equal(t, ReasonConcurrent, schedule.GetStatus().GetLastMissedReason())
+	assert.Equal(t, invocationID, schedule.GetStatus().GetLastInvocationId())
+
+	// Once deleted, the schedule does not invoke the workflow anymore.
+	assert.NoError(t, scheduleAPI.Delete(id))
+	load(time.Now().Add(-time.Minute))
+	ctr = NewController(scheduleCache, wfiCache, scheduleAPI, invocationAPI, Config{})
+	ctr.Evaluate(id)
+	assert.EqualValues(t, 2, load(time.Now()).GetStatus().GetMissedRuns())
+}
+
+func TestController_EvaluateConcurrently(t *testing.T) {
+	es := mem.NewBackend()
+	scheduleAPI := api.NewScheduleAPI(es)
+	invocationAPI := api.NewInvocationAPI(es)
+	scheduleCache := fes.NewMapCache()
+	wfiCache := fes.NewMapCache()
+
+	id, err := scheduleAPI.Create(&types.ScheduleSpec{
+		WorkflowId: "wf-123",
+		Cron:       "* * * * *",
+	})
+	assert.NoError(t, err)
+	schedule := aggregates.NewSchedule(id)
+	assert.NoError(t, fes.Rehydrate(es, *aggregates.NewScheduleAggregate(id), schedule))
+	schedule.Status.ScheduledUntil, err = ptypes.TimestampProto(time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.NoError(t, scheduleCache.Put(schedule))
+
+	// Controllers that do not share the handled runs, such as after a restart, should invoke the run only once.
+	NewController(scheduleCache, wfiCache, scheduleAPI, invocationAPI, Config{}).Evaluate(id)
+	NewController(scheduleCache, wfiCache, scheduleAPI, invocationAPI, Config{}).Evaluate(id)
+	invocations, err := es.List(func(key string) bool {
+		return strings.HasPrefix(key, aggregates.TypeWorkflowInvocation)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, invocations, 1)
+}
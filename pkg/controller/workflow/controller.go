@@ -58,6 +58,10 @@ type Controller struct {
 	evalQueue  chan string
 	evalCache  *controller.EvalCache
 	evalPolicy controller.Rule
+
+	// shard contains the workflows that the controller is responsible for. If nil, the controller is responsible for
+	// all workflows.
+	shard controller.Shard
 }
 
 func NewController(wfCache fes.CacheReader, wfAPI *api.Workflow) *Controller {
@@ -71,6 +75,16 @@ func NewController(wfCache fes.CacheReader, wfAPI *api.Workflow) *Controller {
 	return ctr
 }
 
+// SetShard restricts the controller to the workflows in the shard. It should be called before the controller is
+// initialized.
+func (c *Controller) SetShard(shard controller.Shard) {
+	c.shard = shard
+}
+
+func (c *Controller) owns(workflowID string) bool {
+	return c.shard == nil || c.shard.Contains(workflowID)
+}
+
 func (c *Controller) Init(sctx context.Context) error {
 	ctx, cancelFn := context.WithCancel(sctx)
 	c.cancelFn = cancelFn
@@ -127,7 +141,31 @@ func (c *Controller) handleMsg(msg pubsub.Msg) error {
 
 func (c *Controller) Tick(tick uint64) error {
 	// TODO short loop: eval cache
-	// TODO longer loop: cache
+
+	// Long loop: to check if there are any unparsed workflows, such as the workflows of a replica that disappeared.
+	if tick%10 == 0 {
+		return c.checkModelCaches()
+	}
+	return nil
+}
+
+// checkModelCaches submits the pending workflows in the shard of the controller that are not being evaluated.
+func (c *Controller) checkModelCaches() error {
+	for _, entity := range c.wfCache.List() {
+		if _, ok := c.evalCache.Get(entity.Id); ok || !c.owns(entity.Id) {
+			continue
+		}
+		wf := aggregates.NewWorkflow(entity.Id)
+		err := c.wfCache.Get(wf)
+		if err != nil {
+			wfLog.Errorf("Failed to read '%v' from cache: %v.", wf.Aggregate(), err)
+			continue
+		}
+		if wf.GetStatus().GetStatus() == types.WorkflowStatus_PENDING {
+			controller.EvalRecovered.WithLabelValues(Name, "cache").Inc()
+			c.submitEval(wf.ID())
+		}
+	}
 	return nil
 }
 
@@ -136,6 +174,9 @@ func (c *Controller) Notify(msg *fes.Notification) error {
 	if !ok {
 		return fmt.Errorf("received notification of invalid type '%s'. Expected '*aggregates.Workflow'", reflect.TypeOf(msg.Payload))
 	}
+	if !c.owns(wf.ID()) {
+		return nil
+	}
 
 	c.submitEval(wf.ID())
 	return nil
@@ -143,6 +184,11 @@ func (c *Controller) Notify(msg *fes.Notification) error {
 
 func (c *Controller) Evaluate(workflowID string) {
 	start := time.Now()
+	if !c.owns(workflowID) {
+		wfLog.Debugf("Workflow %s is not part of the shard of this controller", workflowID)
+		controller.EvalJobs.WithLabelValues(Name, "unowned").Inc()
+		return
+	}
 	// Fetch and attempt to claim the evaluation
 	evalState := c.evalCache.GetOrCreate(workflowID)
 	select {
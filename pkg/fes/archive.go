@@ -0,0 +1,189 @@
+package fes
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const archiveFileExt = ".events.gz"
+
+// Archiver stores the events of aggregates that are removed from the backend.
+type Archiver interface {
+	Archive(aggregate Aggregate, events []*Event) error
+}
+
+// FileArchiver archives the events of each aggregate to a gzip-compressed file in a directory.
+//
+// The file of an aggregate is located at <dir>/<type>/<id>.events.gz, and contains the length-delimited events of the
+// aggregate in the order in which they were appended.
+type FileArchiver struct {
+	Dir string
+}
+
+func NewFileArchiver(dir string) (*FileArchiver, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	return &FileArchiver{
+		Dir: dir,
+	}, nil
+}
+
+// Archive writes the events to the file of the aggregate, replacing any existing archive of the aggregate.
+func (a *FileArchiver) Archive(aggregate Aggregate, events []*Event) error {
+	err := validateAggregate(aggregate)
+	if err != nil {
+		return err
+	}
+	path := a.path(aggregate)
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, to avoid leaving behind a partial archive.
+	f, err := ioutil.TempFile(filepath.Dir(path), ".archive")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	err = WriteEvents(f, events)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Read returns the archived events of the aggregate, or ErrNotFound if the aggregate has not been archived.
+func (a *FileArchiver) Read(aggregate Aggregate) ([]*Event, error) {
+	f, err := os.Open(a.path(aggregate))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return ReadEvents(f)
+}
+
+func (a *FileArchiver) path(aggregate Aggregate) string {
+	return filepath.Join(a.Dir, url.PathEscape(aggregate.Type), url.PathEscape(aggregate.Id)+archiveFileExt)
+}
+
+// WriteEvents writes the events as a gzip-compressed stream of length-delimited events.
+func WriteEvents(w io.Writer, events []*Event) error {
+	ew := NewEventWriter(w)
+	for _, event := range events {
+		if err := ew.Write(event); err != nil {
+			return err
+		}
+	}
+	return ew.Close()
+}
+
+// ReadEvents reads the events from a stream written by WriteEvents.
+func ReadEvents(r io.Reader) ([]*Event, error) {
+	er, err := NewEventReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer er.Close()
+	var events []*Event
+	for {
+		event, err := er.Read()
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+}
+
+// EventWriter writes events one by one as a gzip-compressed stream of length-delimited events.
+type EventWriter struct {
+	zw  *gzip.Writer
+	buf []byte
+}
+
+func NewEventWriter(w io.Writer) *EventWriter {
+	return &EventWriter{
+		zw:  gzip.NewWriter(w),
+		buf: make([]byte, binary.MaxVarintLen64),
+	}
+}
+
+func (ew *EventWriter) Write(event *Event) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	n := binary.PutUvarint(ew.buf, uint64(len(data)))
+	if _, err := ew.zw.Write(ew.buf[:n]); err != nil {
+		return err
+	}
+	_, err = ew.zw.Write(data)
+	return err
+}
+
+// Close flushes the remaining events to the underlying writer. It does not close the underlying writer.
+func (ew *EventWriter) Close() error {
+	return ew.zw.Close()
+}
+
+// EventReader reads events one by one from a stream written by an EventWriter.
+type EventReader struct {
+	zr *gzip.Reader
+	br *bufio.Reader
+}
+
+func NewEventReader(r io.Reader) (*EventReader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &EventReader{
+		zr: zr,
+		br: bufio.NewReader(zr),
+	}, nil
+}
+
+// Read returns the next event in the stream, or io.EOF if there are no more events.
+func (er *EventReader) Read() (*Event, error) {
+	size, err := binary.ReadUvarint(er.br)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(er.br, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	event := &Event{}
+	if err := proto.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (er *EventReader) Close() error {
+	return er.zr.Close()
+}
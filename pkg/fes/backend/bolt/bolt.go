@@ -0,0 +1,362 @@
+// Package bolt provides a persistent, file-based fes backend using bbolt, the maintained fork of BoltDB.
+//
+// The backend is intended for single-node deployments in which operating a NATS Streaming cluster is not feasible.
+// All events are stored in a single, append-only log in the database file. Similar to the NATS backend, events of
+// which the parent is set are folded into the event stream of the parent aggregate.
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	defaultFileMode = 0600
+	defaultTimeout  = time.Duration(10) * time.Second
+)
+
+var (
+	ErrInvalidAggregate = errors.New("invalid aggregate")
+	ErrNoPath           = errors.New("no path provided for the database file")
+
+	// bucketEvents contains the append-only log of all events, keyed by their sequence number.
+	bucketEvents = []byte("events")
+
+	// bucketAggregates contains a nested bucket for each aggregate referencing the sequence numbers of its events.
+	bucketAggregates = []byte("aggregates")
+
+	// bucketSnapshots contains the latest snapshot of each aggregate.
+	bucketSnapshots = []byte("snapshots")
+
+	// bucketVersions contains the current version of each aggregate, keyed by the parent and the aggregate.
+	bucketVersions = []byte("versions")
+
+	eventsAppended = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "bolt",
+		Name:      "events_appended_total",
+		Help:      "Count of appended events (excluding any internal events).",
+	}, []string{"eventType"})
+)
+
+func init() {
+	prometheus.MustRegister(eventsAppended)
+}
+
+type Config struct {
+	Path string // e.g. /var/lib/workflows/events.db
+}
+
+// Backend is a BoltDB-based implementation of the fes backend.
+type Backend struct {
+	pubsub.Publisher
+	db     *bolt.DB
+	Config Config
+}
+
+// Open opens (or creates) the database file specified in the config.
+func Open(cfg Config) (*Backend, error) {
+	if len(cfg.Path) == 0 {
+		return nil, ErrNoPath
+	}
+	db, err := bolt.Open(cfg.Path, defaultFileMode, &bolt.Options{Timeout: defaultTimeout})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{bucketEvents, bucketAggregates, bucketSnapshots, bucketVersions} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	logrus.WithField("path", cfg.Path).Info("opened BoltDB event store")
+
+	return &Backend{
+		Publisher: pubsub.NewPublisher(),
+		db:        db,
+		Config:    cfg,
+	}, nil
+}
+
+// Append persists the event in the event log and publishes it to the subscribers.
+func (b *Backend) Append(event *fes.Event) error {
+	return b.appendEvent(event, nil)
+}
+
+// AppendIfVersion appends the event only if the current version of the aggregate equals the expected version.
+func (b *Backend) AppendIfVersion(event *fes.Event, expectedVersion uint64) error {
+	return b.appendEvent(event, &expectedVersion)
+}
+
+func (b *Backend) appendEvent(event *fes.Event, expectedVersion *uint64) error {
+	if !fes.ValidateAggregate(event.Aggregate) {
+		return ErrInvalidAggregate
+	}
+	key := *event.Aggregate
+	if event.Parent != nil {
+		key = *event.Parent
+	}
+
+	var stored *fes.Event
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		versions := tx.Bucket(bucketVersions)
+		vkey := toVersionKey(event)
+		version := btoi(versions.Get(vkey))
+		if expectedVersion != nil && version != *expectedVersion {
+			return fes.NewConflictError(*event.Aggregate, *expectedVersion, version)
+		}
+		if err := versions.Put(vkey, itob(version+1)); err != nil {
+			return err
+		}
+
+		events := tx.Bucket(bucketEvents)
+		seq, err := events.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		// Similar to the other backends, the id of the event is assigned by the event store.
+		stored = proto.Clone(event).(*fes.Event)
+		stored.Id = fmt.Sprintf("%d", seq)
+		data, err := proto.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		if err := events.Put(itob(seq), data); err != nil {
+			return err
+		}
+
+		aggregate, err := tx.Bucket(bucketAggregates).CreateBucketIfNotExists(toKey(key))
+		if err != nil {
+			return err
+		}
+		return aggregate.Put(itob(seq), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"aggregate": event.Aggregate.Format(),
+		"parent":    event.Parent.Format(),
+		"event.id":  stored.Id,
+	}).Infof("Event added: %v", event.Type)
+
+	eventsAppended.WithLabelValues(event.Type).Inc()
+	return b.Publish(stored)
+}
+
+// Get returns all events related to a specific aggregate in the order in which they were appended.
+func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
+	return b.GetFrom(key, 0)
+}
+
+// GetFrom returns the events related to a specific aggregate, skipping the first events up to the version. Only the
+// events after the version are read from the event log.
+func (b *Backend) GetFrom(key fes.Aggregate, version uint64) ([]*fes.Event, error) {
+	if !fes.ValidateAggregate(&key) {
+		return nil, ErrInvalidAggregate
+	}
+
+	results := []*fes.Event{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		aggregate := tx.Bucket(bucketAggregates).Bucket(toKey(key))
+		if aggregate == nil {
+			return nil
+		}
+		events := tx.Bucket(bucketEvents)
+		c := aggregate.Cursor()
+		seq, _ := c.First()
+		for i := uint64(0); i < version && seq != nil; i++ {
+			seq, _ = c.Next()
+		}
+		for ; seq != nil; seq, _ = c.Next() {
+			event, err := toEvent(events.Get(seq))
+			if err != nil {
+				return err
+			}
+			results = append(results, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Delete removes all events of the aggregate, including the events of its children, and its snapshot.
+func (b *Backend) Delete(key fes.Aggregate) error {
+	if !fes.ValidateAggregate(&key) {
+		return ErrInvalidAggregate
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		aggregates := tx.Bucket(bucketAggregates)
+		if aggregate := aggregates.Bucket(toKey(key)); aggregate != nil {
+			events := tx.Bucket(bucketEvents)
+			err := aggregate.ForEach(func(seq, _ []byte) error {
+				return events.Delete(seq)
+			})
+			if err != nil {
+				return err
+			}
+			if err := aggregates.DeleteBucket(toKey(key)); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Bucket(bucketSnapshots).Delete(toKey(key)); err != nil {
+			return err
+		}
+
+		// Remove the versions of the aggregate and of its children.
+		versions := tx.Bucket(bucketVersions)
+		if err := versions.Delete(toKey(key)); err != nil {
+			return err
+		}
+		prefix := []byte(fmt.Sprintf("%s/", toKey(key)))
+		c := versions.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := versions.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List returns all aggregates of which the key matches the StringMatcher.
+func (b *Backend) List(matchFn fes.StringMatcher) ([]fes.Aggregate, error) {
+	var results []fes.Aggregate
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAggregates).ForEach(func(k, _ []byte) error {
+			a := toAggregate(k)
+			if a != nil && matchFn(a.Type+a.Id) {
+				results = append(results, *a)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Replay passes all persisted events in order of appending to the handler.
+//
+// Contrary to Append, the events are not published to the subscribers; this allows callers to rebuild state (such as
+// caches) synchronously on startup without treating the events as new events.
+func (b *Backend) Replay(handler func(event *fes.Event) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketEvents).ForEach(func(_, data []byte) error {
+			event, err := toEvent(data)
+			if err != nil {
+				return err
+			}
+			return handler(event)
+		})
+	})
+}
+
+// SaveSnapshot stores the snapshot, replacing any existing snapshot of the aggregate.
+func (b *Backend) SaveSnapshot(snapshot *fes.Snapshot) error {
+	if !fes.ValidateAggregate(snapshot.Aggregate) {
+		return ErrInvalidAggregate
+	}
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSnapshots).Put(toKey(*snapshot.Aggregate), data)
+	})
+}
+
+// GetSnapshot returns the latest snapshot of the aggregate, or fes.ErrNotFound if there is none.
+func (b *Backend) GetSnapshot(key fes.Aggregate) (*fes.Snapshot, error) {
+	snapshot := &fes.Snapshot{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketSnapshots).Get(toKey(key))
+		if data == nil {
+			return fes.ErrNotFound
+		}
+		return proto.Unmarshal(data, snapshot)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Close closes the database file. Subsequent calls to the backend will fail.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func toEvent(data []byte) (*fes.Event, error) {
+	if data == nil {
+		return nil, errors.New("event missing from event log")
+	}
+	e := &fes.Event{}
+	err := proto.Unmarshal(data, e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func toKey(a fes.Aggregate) []byte {
+	return []byte(fmt.Sprintf("%s.%s", a.Type, a.Id))
+}
+
+// toVersionKey returns the key of the version of the aggregate of the event, which is scoped to the parent (if any).
+func toVersionKey(event *fes.Event) []byte {
+	if event.Parent == nil {
+		return toKey(*event.Aggregate)
+	}
+	return []byte(fmt.Sprintf("%s/%s", toKey(*event.Parent), toKey(*event.Aggregate)))
+}
+
+func toAggregate(key []byte) *fes.Aggregate {
+	parts := strings.SplitN(string(key), ".", 2)
+	if len(parts) < 2 {
+		return nil
+	}
+	return &fes.Aggregate{
+		Type: parts[0],
+		Id:   parts[1],
+	}
+}
+
+// itob returns an 8-byte big endian representation of v, which preserves the order of the sequence numbers in BoltDB.
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// btoi is the inverse of itob. A missing value is interpreted as 0.
+func btoi(b []byte) uint64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
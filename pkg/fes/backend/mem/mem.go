@@ -23,24 +23,49 @@ var (
 // An in-memory, fes backend for development and testing purposes
 type Backend struct {
 	pubsub.Publisher
-	contents map[fes.Aggregate][]*fes.Event
-	lock     sync.RWMutex
+	contents  map[fes.Aggregate][]*fes.Event
+	snapshots map[fes.Aggregate]*fes.Snapshot
+	versions  map[versionKey]uint64
+	lock      sync.RWMutex
+}
+
+// versionKey identifies the version of an aggregate, which for child aggregates is scoped to the parent.
+type versionKey struct {
+	parent    fes.Aggregate
+	aggregate fes.Aggregate
 }
 
 func NewBackend() *Backend {
 	return &Backend{
 		Publisher: pubsub.NewPublisher(),
 		contents:  map[fes.Aggregate][]*fes.Event{},
+		snapshots: map[fes.Aggregate]*fes.Snapshot{},
+		versions:  map[versionKey]uint64{},
 		lock:      sync.RWMutex{},
 	}
 }
 
 func (b *Backend) Append(event *fes.Event) error {
+	return b.appendEvent(event, nil)
+}
+
+// AppendIfVersion appends the event only if the current version of the aggregate equals the expected version.
+func (b *Backend) AppendIfVersion(event *fes.Event, expectedVersion uint64) error {
+	return b.appendEvent(event, &expectedVersion)
+}
+
+func (b *Backend) appendEvent(event *fes.Event, expectedVersion *uint64) error {
 	if !fes.ValidateAggregate(event.Aggregate) {
 		return ErrInvalidAggregate
 	}
 
+	// Events of child aggregates are stored along with the events of their parent.
 	key := *event.Aggregate
+	vkey := versionKey{aggregate: key}
+	if event.Parent != nil {
+		key = *event.Parent
+		vkey.parent = *event.Parent
+	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
@@ -48,7 +73,12 @@ func (b *Backend) Append(event *fes.Event) error {
 	if !ok {
 		events = []*fes.Event{}
 	}
+	version := b.versions[vkey]
+	if expectedVersion != nil && version != *expectedVersion {
+		return fes.NewConflictError(*event.Aggregate, *expectedVersion, version)
+	}
 	b.contents[key] = append(events, event)
+	b.versions[vkey] = version + 1
 
 	eventsAppended.WithLabelValues(event.Type).Inc()
 	return b.Publish(event)
@@ -67,6 +97,35 @@ func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
 	return events, nil
 }
 
+// GetFrom returns the events related to a specific aggregate, skipping the first events up to the version.
+func (b *Backend) GetFrom(key fes.Aggregate, version uint64) ([]*fes.Event, error) {
+	events, err := b.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if version >= uint64(len(events)) {
+		return []*fes.Event{}, nil
+	}
+	return events[version:], nil
+}
+
+// Delete removes all events of the aggregate, including the events of its children, and its snapshot.
+func (b *Backend) Delete(key fes.Aggregate) error {
+	if !fes.ValidateAggregate(&key) {
+		return ErrInvalidAggregate
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.contents, key)
+	delete(b.snapshots, key)
+	for vkey := range b.versions {
+		if vkey.aggregate == key || vkey.parent == key {
+			delete(b.versions, vkey)
+		}
+	}
+	return nil
+}
+
 func (b *Backend) List(matchFn fes.StringMatcher) ([]fes.Aggregate, error) {
 	b.lock.RLock()
 	defer b.lock.RUnlock()
@@ -79,3 +138,23 @@ func (b *Backend) List(matchFn fes.StringMatcher) ([]fes.Aggregate, error) {
 	}
 	return results, nil
 }
+
+func (b *Backend) SaveSnapshot(snapshot *fes.Snapshot) error {
+	if !fes.ValidateAggregate(snapshot.Aggregate) {
+		return ErrInvalidAggregate
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.snapshots[*snapshot.Aggregate] = snapshot
+	return nil
+}
+
+func (b *Backend) GetSnapshot(key fes.Aggregate) (*fes.Snapshot, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	snapshot, ok := b.snapshots[key]
+	if !ok {
+		return nil, fes.ErrNotFound
+	}
+	return snapshot, nil
+}
@@ -74,6 +74,101 @@ func TestBackend_GetMultiple(t *testing.T) {
 	assert.EqualValues(t, events, getEvents)
 }
 
+func TestBackend_GetFrom(t *testing.T) {
+	mem := NewBackend()
+	key := fes.NewAggregate("type", "id")
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range events {
+		err := mem.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	for version := range events {
+		getEvents, err := mem.GetFrom(key, uint64(version))
+		assert.NoError(t, err)
+		assert.EqualValues(t, events[version:], getEvents)
+	}
+	getEvents, err := mem.GetFrom(key, uint64(len(events)+1))
+	assert.NoError(t, err)
+	assert.Empty(t, getEvents)
+}
+
+func TestBackend_AppendChild(t *testing.T) {
+	mem := NewBackend()
+	parent := fes.NewAggregate("parent", "id")
+	child := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	child.Parent = &parent
+	events := []*fes.Event{
+		newEvent(parent, []byte("parent event")),
+		child,
+	}
+	for k := range events {
+		err := mem.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	getEvents, err := mem.Get(parent)
+	assert.NoError(t, err)
+	assert.EqualValues(t, events, getEvents)
+
+	aggregates, err := mem.List(func(s string) bool { return true })
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{parent}, aggregates)
+}
+
+func TestBackend_AppendIfVersion(t *testing.T) {
+	mem := NewBackend()
+	key := fes.NewAggregate("type", "id")
+
+	err := mem.AppendIfVersion(newEvent(key, []byte("event 1")), 0)
+	assert.NoError(t, err)
+	err = mem.AppendIfVersion(newEvent(key, []byte("event 2")), 1)
+	assert.NoError(t, err)
+
+	// A writer that has not seen the second event should be rejected.
+	err = mem.AppendIfVersion(newEvent(key, []byte("event 3")), 1)
+	assert.True(t, fes.IsConflict(err))
+	assert.EqualValues(t, &fes.ConflictError{Aggregate: key, Expected: 1, Actual: 2}, err)
+	assert.Len(t, mem.contents[key], 2)
+}
+
+func TestBackend_Delete(t *testing.T) {
+	mem := NewBackend()
+	parent := fes.NewAggregate("parent", "id")
+	other := fes.NewAggregate("parent", "other")
+	child := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	child.Parent = &parent
+	events := []*fes.Event{
+		newEvent(parent, []byte("event 1")),
+		child,
+		newEvent(other, []byte("event 1")),
+	}
+	for _, event := range events {
+		err := mem.Append(event)
+		assert.NoError(t, err)
+	}
+	err := mem.SaveSnapshot(&fes.Snapshot{Aggregate: &parent, Version: 2})
+	assert.NoError(t, err)
+
+	err = mem.Delete(parent)
+	assert.NoError(t, err)
+	getEvents, err := mem.Get(parent)
+	assert.NoError(t, err)
+	assert.Empty(t, getEvents)
+	_, err = mem.GetSnapshot(parent)
+	assert.Equal(t, fes.ErrNotFound, err)
+	aggregates, err := mem.List(func(s string) bool { return true })
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{other}, aggregates)
+
+	// The versions of the deleted aggregate should have been reset.
+	err = mem.AppendIfVersion(newEvent(parent, []byte("event 1")), 0)
+	assert.NoError(t, err)
+}
+
 func TestBackend_GetNonexistent(t *testing.T) {
 	mem := NewBackend()
 	key := fes.NewAggregate("type", "id")
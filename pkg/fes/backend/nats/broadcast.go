@@ -0,0 +1,99 @@
+package nats
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/nats-io/go-nats"
+	"github.com/sirupsen/logrus"
+)
+
+// broadcastPrefix separates the subjects of the broadcasted events from the subjects of NATS Streaming.
+const broadcastPrefix = "broadcast."
+
+// Broadcaster is a backend for events that only need to be received by the processes that are connected at the time
+// the events are appended, such as the heartbeats of the replicas in a cluster. NATS Streaming does not support the
+// removal of messages, so persisting such frequent events would grow their subjects without bound. Instead, the
+// Broadcaster publishes the events over plain NATS, which does not persist them.
+//
+// As the events are not persisted, Get and List never return any events or aggregates.
+type Broadcaster struct {
+	pubsub.Publisher
+	conn *nats.Conn
+	subs []*nats.Subscription
+	lock sync.Mutex
+}
+
+func NewBroadcaster(conn *nats.Conn) *Broadcaster {
+	return &Broadcaster{
+		Publisher: pubsub.NewPublisher(),
+		conn:      conn,
+	}
+}
+
+// Broadcaster returns a Broadcaster that uses the NATS connection of the event store.
+func (es *EventStore) Broadcaster() *Broadcaster {
+	return NewBroadcaster(es.conn.NatsConn())
+}
+
+// Append publishes the event to the processes that watch the aggregate type of the event.
+func (b *Broadcaster) Append(event *fes.Event) error {
+	if !fes.ValidateAggregate(event.Aggregate) {
+		return ErrInvalidAggregate
+	}
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.conn.Publish(broadcastPrefix+toSubject(*event.Aggregate), data)
+}
+
+func (b *Broadcaster) Get(aggregate fes.Aggregate) ([]*fes.Event, error) {
+	return nil, nil
+}
+
+func (b *Broadcaster) List(matcher fes.StringMatcher) ([]fes.Aggregate, error) {
+	return nil, nil
+}
+
+// Watch subscribes to the events of the aggregate type that are appended from now on. The events are emitted over the
+// publisher interface.
+func (b *Broadcaster) Watch(aggregateType string) error {
+	subject := fmt.Sprintf("%s%s.>", broadcastPrefix, aggregateType)
+	sub, err := b.conn.Subscribe(subject, func(msg *nats.Msg) {
+		event := &fes.Event{}
+		err := proto.Unmarshal(msg.Data, event)
+		if err != nil {
+			logrus.WithField("nats.Subject", msg.Subject).Errorf("Failed to unmarshal broadcasted event: %v", err)
+			return
+		}
+		err = b.Publisher.Publish(event)
+		if err != nil {
+			logrus.Error(err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	b.lock.Lock()
+	b.subs = append(b.subs, sub)
+	b.lock.Unlock()
+	logrus.Infof("Backend client watches broadcasts: '%s'", subject)
+	return nil
+}
+
+// Close stops watching the broadcasted events. The NATS connection is owned by the event store.
+func (b *Broadcaster) Close() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, sub := range b.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			return err
+		}
+	}
+	b.subs = nil
+	return b.Publisher.Close()
+}
@@ -0,0 +1,147 @@
+package nats
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore durably stores the sequence of the last processed message for each of the subjects.
+type CheckpointStore interface {
+	// Load returns the stored sequences by subject, or an empty map if no checkpoints have been stored.
+	Load() (map[string]uint64, error)
+
+	// Save replaces the stored checkpoints with the provided sequences.
+	Save(checkpoints map[string]uint64) error
+}
+
+// FileCheckpointStore stores the checkpoints as a JSON-encoded object in a file.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{
+		Path: path,
+	}
+}
+
+func (s *FileCheckpointStore) Load() (map[string]uint64, error) {
+	checkpoints := map[string]uint64{}
+	data, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return checkpoints, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(data, &checkpoints)
+	if err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+func (s *FileCheckpointStore) Save(checkpoints map[string]uint64) error {
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.Path)
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, to avoid leaving behind partial checkpoints.
+	f, err := ioutil.TempFile(dir, ".checkpoints")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.Path)
+}
+
+// checkpoints keeps track of the last processed sequence of each subject, which is periodically flushed to the store.
+type checkpoints struct {
+	store CheckpointStore
+	seqs  map[string]uint64
+	dirty bool
+	lock  sync.Mutex
+}
+
+func loadCheckpoints(store CheckpointStore) (*checkpoints, error) {
+	seqs, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoints{
+		store: store,
+		seqs:  seqs,
+	}, nil
+}
+
+// Get returns the last processed sequence of the subject, or 0 if none of the messages of the subject have been
+// processed.
+func (c *checkpoints) Get(subject string) uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.seqs[subject]
+}
+
+// Update records the sequence as processed, if it is newer than the current checkpoint of the subject. It returns
+// false if the sequence has already been processed.
+func (c *checkpoints) Update(subject string, seq uint64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if seq <= c.seqs[subject] {
+		return false
+	}
+	c.seqs[subject] = seq
+	c.dirty = true
+	return true
+}
+
+// Subjects returns the checkpoints of the subjects that match the query.
+func (c *checkpoints) Subjects(query string) map[string]uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	results := map[string]uint64{}
+	for subject, seq := range c.seqs {
+		if queryMatches(subject, query) {
+			results[subject] = seq
+		}
+	}
+	return results
+}
+
+// Flush saves the checkpoints to the store, if any of them have changed since the last flush.
+func (c *checkpoints) Flush() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	err := c.store.Save(c.seqs)
+	if err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,43 @@
+package nats
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nats-checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := NewFileCheckpointStore(filepath.Join(dir, "checkpoints.json"))
+
+	// Without stored checkpoints, all subjects should start from the beginning.
+	cps, err := loadCheckpoints(store)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, cps.Get("invocation.foo"))
+
+	assert.True(t, cps.Update("invocation.foo", 3))
+	assert.True(t, cps.Update("invocation.bar", 1))
+	assert.True(t, cps.Update("workflow.foo", 2))
+	// Already processed sequences should not move the checkpoint back.
+	assert.False(t, cps.Update("invocation.foo", 2))
+	assert.EqualValues(t, 3, cps.Get("invocation.foo"))
+	assert.Equal(t, map[string]uint64{
+		"invocation.foo": 3,
+		"invocation.bar": 1,
+	}, cps.Subjects("invocation.>"))
+	assert.NoError(t, cps.Flush())
+
+	// After a restart, the checkpoints should be resumed from the store.
+	restored, err := loadCheckpoints(store)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, restored.Get("invocation.foo"))
+	assert.EqualValues(t, 1, restored.Get("invocation.bar"))
+	assert.EqualValues(t, 2, restored.Get("workflow.foo"))
+}
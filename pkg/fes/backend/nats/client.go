@@ -1,8 +1,10 @@
 package nats
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,7 +19,8 @@ import (
 )
 
 const (
-	defaultClient = "fes"
+	defaultClient             = "fes"
+	defaultCheckpointInterval = time.Second
 )
 
 var (
@@ -50,25 +53,39 @@ func init() {
 }
 
 // EventStore is a NATS-based implementation of the EventStore interface.
+//
+// If a checkpoint path is configured, the event store durably tracks the last processed sequence of each subject.
+// After a restart, the watched subjects are resumed after their checkpoints, instead of replaying all events to the
+// subscribers. The already processed events can be loaded with CatchUp.
+//
+// The event store does not support snapshots. NATS Streaming cannot replace or remove messages, so the snapshots would
+// accumulate in their subjects. Moreover, whether the conditional events of a subject have been accepted can only be
+// determined by reading the subject from the start, so a snapshot would not avoid reading the preceding events.
 type EventStore struct {
 	pubsub.Publisher
-	conn   *WildcardConn
-	sub    map[fes.Aggregate]stan.Subscription
-	Config Config
+	conn         *WildcardConn
+	sub          map[fes.Aggregate]stan.Subscription
+	checkpoints  *checkpoints
+	subjects     *subjectStates
+	appendStates *appendStates
+	Config       Config
 }
 
 type Config struct {
-	Cluster string
-	Client  string
-	URL     string // e.g. nats://localhost:9300
+	Cluster        string
+	Client         string
+	URL            string // e.g. nats://localhost:9300
+	CheckpointPath string // e.g. /var/lib/workflows/nats-checkpoints.json
 }
 
 func NewEventStore(conn *WildcardConn, cfg Config) *EventStore {
 	return &EventStore{
-		Publisher: pubsub.NewPublisher(),
-		conn:      conn,
-		sub:       map[fes.Aggregate]stan.Subscription{},
-		Config:    cfg,
+		Publisher:    pubsub.NewPublisher(),
+		conn:         conn,
+		sub:          map[fes.Aggregate]stan.Subscription{},
+		subjects:     newSubjectStates(),
+		appendStates: newAppendStates(),
+		Config:       cfg,
 	}
 }
 
@@ -91,18 +108,45 @@ func Connect(cfg Config) (*EventStore, error) {
 		WithField("client", cfg.Client).
 		Info("connected to NATS")
 
-	return NewEventStore(wconn, cfg), nil
+	es := NewEventStore(wconn, cfg)
+	if cfg.CheckpointPath != "" {
+		es.checkpoints, err = loadCheckpoints(NewFileCheckpointStore(cfg.CheckpointPath))
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to load checkpoints: %v", err)
+		}
+		logrus.WithField("path", cfg.CheckpointPath).Info("Resuming NATS subscriptions from checkpoints")
+	}
+	return es, nil
 }
 
 // Watch a aggregate type for new events. The events are emitted over the publisher interface.
 func (es *EventStore) Watch(aggregate fes.Aggregate) error {
 	subject := fmt.Sprintf("%s.>", aggregate.Type)
-	sub, err := es.conn.Subscribe(subject, func(msg *stan.Msg) {
+	sub, err := es.conn.SubscribeFunc(subject, func(msg *stan.Msg) {
+		if es.checkpoints != nil && msg.Sequence <= es.checkpoints.Get(msg.Subject) {
+			logrus.WithFields(logrus.Fields{
+				"nats.Subject":  msg.Subject,
+				"nats.Sequence": msg.Sequence,
+			}).Debug("Ignoring already processed message.")
+			return
+		}
 		event, err := toEvent(msg)
 		if err != nil {
 			logrus.Error(err)
 			return
 		}
+		accepted, err := es.accept(msg, event)
+		if err != nil {
+			logrus.Error(err)
+			return
+		}
+		if !accepted {
+			if es.checkpoints != nil {
+				es.checkpoints.Update(msg.Subject, msg.Sequence)
+			}
+			return
+		}
 
 		logrus.WithFields(logrus.Fields{
 			"aggregate.type": event.Aggregate.Type,
@@ -117,12 +161,15 @@ func (es *EventStore) Watch(aggregate fes.Aggregate) error {
 			logrus.Error(err)
 			return
 		}
+		if es.checkpoints != nil {
+			es.checkpoints.Update(msg.Subject, msg.Sequence)
+		}
 
 		// Record the time it took for the event to be propagated from publisher to subscriber.
 		ts, _ := ptypes.Timestamp(event.Timestamp)
 		eventDelay.Observe(float64(time.Now().Sub(ts).Nanoseconds()))
 
-	}, stan.DeliverAllAvailable())
+	}, es.subscriptionOptions)
 	if err != nil {
 		return err
 	}
@@ -132,7 +179,71 @@ func (es *EventStore) Watch(aggregate fes.Aggregate) error {
 	return nil
 }
 
+// CatchUp passes the events of the aggregate type that have been processed before, according to the checkpoints, to
+// the handler. As Watch resumes the subjects after their checkpoints, this allows the state built from the already
+// processed events (such as caches) to be restored before Watch is called. It returns the number of events passed to
+// the handler.
+func (es *EventStore) CatchUp(aggregateType string, handler func(event *fes.Event) error) (int, error) {
+	if es.checkpoints == nil {
+		return 0, nil
+	}
+	checkpoints := es.checkpoints.Subjects(fmt.Sprintf("%s.>", aggregateType))
+	subjects := make([]string, 0, len(checkpoints))
+	for subject := range checkpoints {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	var count int
+	for _, subject := range subjects {
+		msgs, err := es.conn.MsgSeqRange(subject, firstMsg, checkpoints[subject])
+		if err != nil {
+			return count, fmt.Errorf("failed to catch up on subject '%s': %v", subject, err)
+		}
+		vs := versions{}
+		events, err := acceptedEvents(msgs, vs)
+		if err != nil {
+			return count, err
+		}
+		es.prime(subject, checkpoints[subject], vs)
+		for _, event := range events {
+			err = handler(event)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Run periodically flushes the checkpoints to the checkpoint store until the context is done.
+func (es *EventStore) Run(ctx context.Context) {
+	if es.checkpoints == nil {
+		return
+	}
+	ticker := time.NewTicker(defaultCheckpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("NATS event store: checkpointing stopped.")
+			return
+		case <-ticker.C:
+		}
+		if err := es.checkpoints.Flush(); err != nil {
+			logrus.Errorf("Failed to flush checkpoints: %v", err)
+		}
+	}
+}
+
 func (es *EventStore) Close() error {
+	if es.checkpoints != nil {
+		err := es.checkpoints.Flush()
+		if err != nil {
+			logrus.Errorf("Failed to flush checkpoints: %v", err)
+		}
+	}
 	err := es.conn.Close()
 	if err != nil {
 		return err
@@ -140,6 +251,20 @@ func (es *EventStore) Close() error {
 	return nil
 }
 
+// subscriptionOptions resumes the subscription to the subject at its checkpoint, or replays all messages of the
+// subject if none of them have been processed yet.
+//
+// The subscription starts at the checkpoint itself rather than the sequence following it, because NATS Streaming
+// rejects start sequences beyond the last message of the subject. The message at the checkpoint is ignored by Watch.
+func (es *EventStore) subscriptionOptions(subject string) []stan.SubscriptionOption {
+	if es.checkpoints != nil {
+		if seq := es.checkpoints.Get(subject); seq > 0 {
+			return []stan.SubscriptionOption{stan.StartAtSequence(seq)}
+		}
+	}
+	return []stan.SubscriptionOption{stan.DeliverAllAvailable()}
+}
+
 // Append publishes (and persists) an event on the NATS message queue
 func (es *EventStore) Append(event *fes.Event) error {
 	// TODO make generic / configurable whether to fold event into parent's Subject
@@ -151,14 +276,18 @@ func (es *EventStore) Append(event *fes.Event) error {
 	if err != nil {
 		return err
 	}
+	return es.publish(subject, event, data)
+}
 
+// publish publishes the marshaled event on the subject.
+func (es *EventStore) publish(subject string, event *fes.Event, data []byte) error {
 	logrus.WithFields(logrus.Fields{
 		"aggregate":    event.Aggregate.Format(),
 		"parent":       event.Parent.Format(),
 		"nats.subject": subject,
 	}).Infof("Event added: %v", event.Type)
 
-	err = es.conn.Publish(subject, data)
+	err := es.conn.Publish(subject, data)
 	if err != nil {
 		return err
 	}
@@ -180,16 +309,7 @@ func (es *EventStore) Get(aggregate fes.Aggregate) ([]*fes.Event, error) {
 	if err != nil {
 		return nil, err
 	}
-	var results []*fes.Event
-	for k := range msgs {
-		event, err := toEvent(msgs[k])
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, event)
-	}
-
-	return results, nil
+	return acceptedEvents(msgs, versions{})
 }
 
 // List returns all entities of which the subject matches the StringMatcher
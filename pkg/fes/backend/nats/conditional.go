@@ -0,0 +1,292 @@
+package nats
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/proto"
+	"github.com/nats-io/go-nats-streaming"
+	"github.com/sirupsen/logrus"
+)
+
+// NATS Streaming has no way to reject a message based on the messages that precede it in the subject. Instead,
+// conditionally appended events carry their condition, and the readers of the subject (Get, Watch and CatchUp) ignore
+// the events of which the condition did not hold at their position in the subject. As all readers observe the same
+// order of messages, they agree on which of the events have been accepted.
+
+// versionKey identifies the version of an aggregate, which for child aggregates is scoped to the parent.
+type versionKey struct {
+	parent    fes.Aggregate
+	aggregate fes.Aggregate
+}
+
+// versions tracks the versions of the aggregates of which the events are published on a subject.
+type versions map[versionKey]uint64
+
+// apply applies the event to the versions. It returns false if the event was appended conditionally and the
+// condition did not hold, in which case the event should be ignored.
+func (v versions) apply(event *fes.Event) bool {
+	key := versionKey{aggregate: *event.Aggregate}
+	if event.Parent != nil {
+		key.parent = *event.Parent
+	}
+	version := v[key]
+	if event.Condition != nil && event.Condition.ExpectedVersion != version {
+		return false
+	}
+	v[key] = version + 1
+	return true
+}
+
+// subjectState is the state of a watched subject, which is used by Watch to filter the rejected events.
+type subjectState struct {
+	// seq is the sequence of the last message of the subject that has been applied to the versions.
+	seq uint64
+
+	// versions is nil until the state has been loaded. As long as none of the events of the subject are conditional,
+	// there is no need to track the versions, so the state is only loaded once a conditional event is received.
+	versions versions
+}
+
+// subjectStates holds the states of the watched subjects.
+type subjectStates struct {
+	states map[string]*subjectState
+	lock   sync.Mutex
+}
+
+func newSubjectStates() *subjectStates {
+	return &subjectStates{
+		states: map[string]*subjectState{},
+	}
+}
+
+// appendState is the state of a subject as last read by AppendIfVersion. It allows AppendIfVersion to only read the
+// messages that have been published since the previous conditional append to the subject.
+type appendState struct {
+	// seq is the sequence of the last message of the subject that has been applied to the versions.
+	seq      uint64
+	versions versions
+
+	// pending contains the IDs of the conditional events published by this process of which the outcome has not been
+	// read yet. The outcomes of these events are stored in results, keyed by the ID of the event.
+	pending map[string]bool
+	results map[string]error
+	lock    sync.Mutex
+}
+
+func newAppendState() *appendState {
+	return &appendState{
+		versions: versions{},
+		pending:  map[string]bool{},
+		results:  map[string]error{},
+	}
+}
+
+// apply applies the messages that follow the sequence of the state to the versions, storing the outcomes of the
+// pending events. It returns true if an event of the messages completed the aggregate.
+func (s *appendState) apply(msgs []*stan.Msg) (bool, error) {
+	var completed bool
+	for _, msg := range msgs {
+		if msg.Sequence <= s.seq {
+			continue
+		}
+		event := &fes.Event{}
+		err := proto.Unmarshal(msg.Data, event)
+		if err != nil {
+			return completed, err
+		}
+		// The ID of the event is assigned by the publisher; the readers replace it by the sequence of the message.
+		id := event.Id
+		event.Id = fmt.Sprintf("%d", msg.Sequence)
+
+		key := versionKey{aggregate: *event.Aggregate}
+		if event.Parent != nil {
+			key.parent = *event.Parent
+		}
+		version := s.versions[key]
+		accepted := s.versions.apply(event)
+		if s.pending[id] {
+			if accepted {
+				s.results[id] = nil
+			} else {
+				s.results[id] = fes.NewConflictError(*event.Aggregate, event.Condition.ExpectedVersion, version)
+			}
+		}
+		s.seq = msg.Sequence
+		completed = completed || (accepted && event.GetHints().GetCompleted())
+	}
+	return completed, nil
+}
+
+// appendStates holds the states of the subjects to which events have been appended conditionally.
+type appendStates struct {
+	states map[string]*appendState
+	lock   sync.Mutex
+}
+
+func newAppendStates() *appendStates {
+	return &appendStates{
+		states: map[string]*appendState{},
+	}
+}
+
+// get returns the state of the subject, creating it if it does not exist yet.
+func (as *appendStates) get(subject string) *appendState {
+	as.lock.Lock()
+	defer as.lock.Unlock()
+	state, ok := as.states[subject]
+	if !ok {
+		state = newAppendState()
+		as.states[subject] = state
+	}
+	return state
+}
+
+// remove removes the state of the subject. Callers that still hold the state can continue to use it.
+func (as *appendStates) remove(subject string) {
+	as.lock.Lock()
+	defer as.lock.Unlock()
+	delete(as.states, subject)
+}
+
+// AppendIfVersion appends the event only if the current version of the aggregate equals the expected version.
+//
+// The event is published along with its condition and a unique ID, after which the messages that were published to
+// the subject since the previous conditional append are read to determine whether the condition held at the position
+// of the event in the subject. If not, the event remains in the subject, but it is ignored by all readers.
+//
+// The state of a subject is kept until an event completes its aggregate, so the messages of a subject are only read
+// once by the conditional appends of this process, rather than on every append.
+func (es *EventStore) AppendIfVersion(event *fes.Event, expectedVersion uint64) error {
+	if !fes.ValidateAggregate(event.Aggregate) {
+		return ErrInvalidAggregate
+	}
+	conditional := *event
+	conditional.Id = util.UID()
+	conditional.Condition = &fes.AppendCondition{ExpectedVersion: expectedVersion}
+	data, err := proto.Marshal(&conditional)
+	if err != nil {
+		return err
+	}
+	subject := toSubject(*event.Aggregate)
+	if event.Parent != nil {
+		subject = toSubject(*event.Parent)
+	}
+
+	state := es.appendStates.get(subject)
+	state.lock.Lock()
+	state.pending[conditional.Id] = true
+	state.lock.Unlock()
+	defer func() {
+		state.lock.Lock()
+		delete(state.pending, conditional.Id)
+		delete(state.results, conditional.Id)
+		state.lock.Unlock()
+	}()
+
+	err = es.publish(subject, &conditional, data)
+	if err != nil {
+		return err
+	}
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if _, ok := state.results[conditional.Id]; !ok {
+		// The outcome has not been read yet by a concurrent append to the subject.
+		msgs, err := es.conn.MsgSeqRange(subject, state.seq+1, mostRecentMsg)
+		if err != nil {
+			return fmt.Errorf("failed to check the condition of the appended event: %v", err)
+		}
+		completed, err := state.apply(msgs)
+		if err != nil {
+			return err
+		}
+		if completed {
+			es.appendStates.remove(subject)
+		}
+	}
+	result, ok := state.results[conditional.Id]
+	if !ok {
+		return fmt.Errorf("failed to find the appended event in subject '%s'", subject)
+	}
+	return result
+}
+
+// acceptedEvents converts the messages of a subject to events, ignoring the events that were rejected.
+func acceptedEvents(msgs []*stan.Msg, vs versions) ([]*fes.Event, error) {
+	var results []*fes.Event
+	for _, msg := range msgs {
+		event, err := toEvent(msg)
+		if err != nil {
+			return nil, err
+		}
+		if !vs.apply(event) {
+			continue
+		}
+		results = append(results, event)
+	}
+	return results, nil
+}
+
+// accept determines whether Watch should publish the event of the message to the subscribers. Messages that have
+// already been applied to the state of the subject (due to a redelivery) and rejected events are not accepted.
+//
+// The messages of a subject are delivered sequentially, so only the access to the states needs to be synchronized.
+func (es *EventStore) accept(msg *stan.Msg, event *fes.Event) (bool, error) {
+	state := es.subjects.get(msg.Subject)
+	if state.versions == nil {
+		if event.Condition == nil {
+			return true, nil
+		}
+		// Load the versions from the preceding messages of the subject to check the condition of this event.
+		vs := versions{}
+		if msg.Sequence > firstMsg {
+			msgs, err := es.conn.MsgSeqRange(msg.Subject, firstMsg, msg.Sequence-1)
+			if err != nil {
+				return false, fmt.Errorf("failed to load the versions of subject '%s': %v", msg.Subject, err)
+			}
+			if _, err := acceptedEvents(msgs, vs); err != nil {
+				return false, err
+			}
+		}
+		state.versions = vs
+		state.seq = msg.Sequence - 1
+	}
+	if msg.Sequence <= state.seq {
+		return false, nil
+	}
+	state.seq = msg.Sequence
+	if !state.versions.apply(event) {
+		logrus.WithFields(logrus.Fields{
+			"aggregate":     event.Aggregate.Format(),
+			"nats.Subject":  msg.Subject,
+			"nats.Sequence": msg.Sequence,
+		}).Debug("Ignoring event of which the append condition did not hold.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// get returns the state of the subject, creating it if it does not exist yet.
+func (ss *subjectStates) get(subject string) *subjectState {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	state, ok := ss.states[subject]
+	if !ok {
+		state = &subjectState{}
+		ss.states[subject] = state
+	}
+	return state
+}
+
+// prime sets the state of the subject to the versions loaded by CatchUp up to the sequence.
+func (es *EventStore) prime(subject string, seq uint64, vs versions) {
+	es.subjects.lock.Lock()
+	defer es.subjects.lock.Unlock()
+	es.subjects.states[subject] = &subjectState{
+		seq:      seq,
+		versions: vs,
+	}
+}
@@ -0,0 +1,91 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/golang/protobuf/proto"
+	"github.com/nats-io/go-nats-streaming"
+	"github.com/nats-io/go-nats-streaming/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptedEvents(t *testing.T) {
+	parent := fes.NewAggregate("invocation", "wi-1")
+	task := fes.NewAggregate("task", "t-1")
+	newMsg := func(seq uint64, aggregate fes.Aggregate, condition *fes.AppendCondition) *stan.Msg {
+		event, err := fes.NewEvent(aggregate, &fes.DummyEvent{})
+		assert.NoError(t, err)
+		if aggregate != parent {
+			event.Parent = &parent
+		}
+		event.Condition = condition
+		data, err := proto.Marshal(event)
+		assert.NoError(t, err)
+		return &stan.Msg{MsgProto: pb.MsgProto{Sequence: seq, Data: data}}
+	}
+
+	msgs := []*stan.Msg{
+		newMsg(1, parent, &fes.AppendCondition{ExpectedVersion: 0}),
+		// Rejected: a concurrent writer that also expected the parent to be new.
+		newMsg(2, parent, &fes.AppendCondition{ExpectedVersion: 0}),
+		newMsg(3, parent, nil),
+		// The versions of child aggregates are tracked separately from the parent.
+		newMsg(4, task, &fes.AppendCondition{ExpectedVersion: 0}),
+		newMsg(5, parent, &fes.AppendCondition{ExpectedVersion: 2}),
+		// Rejected: the task has already been modified.
+		newMsg(6, task, &fes.AppendCondition{ExpectedVersion: 0}),
+	}
+	vs := versions{}
+	events, err := acceptedEvents(msgs, vs)
+	assert.NoError(t, err)
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.Id)
+	}
+	assert.Equal(t, []string{"1", "3", "4", "5"}, ids)
+	assert.EqualValues(t, 3, vs[versionKey{aggregate: parent}])
+	assert.EqualValues(t, 1, vs[versionKey{aggregate: task, parent: parent}])
+}
+
+func TestAppendState(t *testing.T) {
+	aggregate := fes.NewAggregate("invocation", "wi-1")
+	newMsg := func(seq uint64, id string, condition *fes.AppendCondition, completed bool) *stan.Msg {
+		event, err := fes.NewEvent(aggregate, &fes.DummyEvent{})
+		assert.NoError(t, err)
+		event.Id = id
+		event.Condition = condition
+		if completed {
+			event.Hints = &fes.EventHints{Completed: true}
+		}
+		data, err := proto.Marshal(event)
+		assert.NoError(t, err)
+		return &stan.Msg{MsgProto: pb.MsgProto{Sequence: seq, Data: data}}
+	}
+
+	state := newAppendState()
+	state.pending["accepted"] = true
+	state.pending["rejected"] = true
+	completed, err := state.apply([]*stan.Msg{
+		newMsg(1, "", nil, false),
+		newMsg(2, "accepted", &fes.AppendCondition{ExpectedVersion: 1}, false),
+		// Published by another process, which is not pending in this state.
+		newMsg(3, "other", &fes.AppendCondition{ExpectedVersion: 1}, false),
+		newMsg(4, "rejected", &fes.AppendCondition{ExpectedVersion: 1}, false),
+	})
+	assert.NoError(t, err)
+	assert.False(t, completed)
+	assert.EqualValues(t, 4, state.seq)
+	assert.Len(t, state.results, 2)
+	assert.NoError(t, state.results["accepted"])
+	assert.True(t, fes.IsConflict(state.results["rejected"]))
+
+	// Messages that have already been applied are skipped.
+	completed, err = state.apply([]*stan.Msg{
+		newMsg(4, "rejected", &fes.AppendCondition{ExpectedVersion: 1}, false),
+		newMsg(5, "", &fes.AppendCondition{ExpectedVersion: 2}, true),
+	})
+	assert.NoError(t, err)
+	assert.True(t, completed)
+	assert.EqualValues(t, 3, state.versions[versionKey{aggregate: aggregate}])
+}
@@ -143,8 +143,17 @@ func NewWildcardConn(conn stan.Conn) *WildcardConn {
 }
 
 func (wc *WildcardConn) Subscribe(wildcardSubject string, cb stan.MsgHandler, opts ...stan.SubscriptionOption) (stan.Subscription, error) {
+	return wc.SubscribeFunc(wildcardSubject, cb, func(subject string) []stan.SubscriptionOption {
+		return opts
+	})
+}
+
+// SubscribeFunc subscribes to the subjects matching the wildcard subject, using the subscription options provided by
+// optsFn for each of the subjects. This allows the subscriptions to resume each subject at a different sequence.
+func (wc *WildcardConn) SubscribeFunc(wildcardSubject string, cb stan.MsgHandler,
+	optsFn func(subject string) []stan.SubscriptionOption) (stan.Subscription, error) {
 	if !hasWildcard(wildcardSubject) {
-		return wc.Conn.Subscribe(wildcardSubject, cb, opts...)
+		return wc.Conn.Subscribe(wildcardSubject, cb, optsFn(wildcardSubject)...)
 	}
 
 	ws := &WildcardSub{
@@ -178,9 +187,8 @@ func (wc *WildcardConn) Subscribe(wildcardSubject string, cb stan.MsgHandler, op
 		switch subjectEvent.Type {
 		case noop:
 			// Create a new listener if event is of a new subject
-			// TODO issue: if resumed previous event will be replayed, check if these are ignored by the event store!
 			if _, ok := ws.sources[subject]; !ok {
-				sub, err := wc.Subscribe(subject, cb, opts...)
+				sub, err := wc.Conn.Subscribe(subject, cb, optsFn(subject)...)
 				if err != nil {
 					logrus.Errorf("Failed to subscribe to wildcardSubject '%v': %v", subjectEvent, err)
 				}
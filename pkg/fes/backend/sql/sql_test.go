@@ -0,0 +1,371 @@
+package sql
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(a fes.Aggregate, data []byte) *fes.Event {
+	event, err := fes.NewEvent(a, &wrappers.BytesValue{
+		Value: data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return event
+}
+
+func setup(t *testing.T) (*Backend, func()) {
+	dir, err := ioutil.TempDir("", "fes-sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend, err := Open(Config{
+		Driver: DriverSQLite,
+		DSN:    filepath.Join(dir, "events.sqlite"),
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return backend, func() {
+		backend.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func assertEventsEqual(t *testing.T, expected []*fes.Event, actual []*fes.Event) {
+	assert.Len(t, actual, len(expected))
+	for k := range expected {
+		if k >= len(actual) {
+			return
+		}
+		assert.NotEmpty(t, actual[k].Id)
+		assert.Equal(t, expected[k].Type, actual[k].Type)
+		assert.True(t, proto.Equal(expected[k].Data, actual[k].Data))
+		assert.EqualValues(t, expected[k].Aggregate, actual[k].Aggregate)
+	}
+}
+
+func TestOpen_UnsupportedDriver(t *testing.T) {
+	_, err := Open(Config{Driver: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestBackend_Append(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+
+	err := backend.Append(newEvent(fes.NewAggregate("type", "id"), []byte("event 1")))
+	assert.NoError(t, err)
+
+	err = backend.Append(newEvent(fes.Aggregate{}, []byte("event 1")))
+	assert.EqualError(t, err, ErrInvalidAggregate.Error())
+
+	err = backend.Append(newEvent(fes.NewAggregate("type", "id"), []byte("event 2")))
+	assert.NoError(t, err)
+	err = backend.Append(newEvent(fes.NewAggregate("Type", "other"), []byte("event 1")))
+	assert.NoError(t, err)
+
+	aggregates, err := backend.List(func(s string) bool { return true })
+	assert.NoError(t, err)
+	assert.Len(t, aggregates, 2)
+
+	aggregates, err = backend.List(fes.ContainsMatcher("other"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{fes.NewAggregate("Type", "other")}, aggregates)
+
+	events, err := backend.Get(fes.NewAggregate("type", "id"))
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestBackend_AppendChild(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+
+	parent := fes.NewAggregate("parent", "id")
+	child := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	child.Parent = &parent
+	events := []*fes.Event{
+		newEvent(parent, []byte("parent event")),
+		child,
+	}
+	for k := range events {
+		err := backend.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	getEvents, err := backend.Get(parent)
+	assert.NoError(t, err)
+	assertEventsEqual(t, events, getEvents)
+
+	aggregates, err := backend.List(func(s string) bool { return true })
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{parent}, aggregates)
+}
+
+func TestBackend_GetMultiple(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	key := fes.NewAggregate("type", "id")
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+
+	for k := range events {
+		err := backend.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	getEvents, err := backend.Get(key)
+	assert.NoError(t, err)
+	assertEventsEqual(t, events, getEvents)
+}
+
+func TestBackend_GetFrom(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	parent := fes.NewAggregate("parent", "id")
+	child := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	child.Parent = &parent
+	events := []*fes.Event{
+		newEvent(parent, []byte("event 1")),
+		child,
+		newEvent(parent, []byte("event 2")),
+	}
+	for k := range events {
+		err := backend.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	for version := range events {
+		getEvents, err := backend.GetFrom(parent, uint64(version))
+		assert.NoError(t, err)
+		assertEventsEqual(t, events[version:], getEvents)
+	}
+	getEvents, err := backend.GetFrom(parent, uint64(len(events)+1))
+	assert.NoError(t, err)
+	assert.Empty(t, getEvents)
+}
+
+func TestBackend_AppendIfVersion(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	parent := fes.NewAggregate("parent", "id")
+	child := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	child.Parent = &parent
+
+	err := backend.AppendIfVersion(newEvent(parent, []byte("event 1")), 0)
+	assert.NoError(t, err)
+	err = backend.AppendIfVersion(child, 0)
+	assert.NoError(t, err)
+
+	// Events of children should not affect the version of the parent.
+	err = backend.AppendIfVersion(newEvent(parent, []byte("event 2")), 1)
+	assert.NoError(t, err)
+
+	// The version of a child aggregate is scoped to its parent.
+	otherParent := fes.NewAggregate("parent", "other")
+	otherChild := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	otherChild.Parent = &otherParent
+	err = backend.AppendIfVersion(otherChild, 0)
+	assert.NoError(t, err)
+
+	err = backend.AppendIfVersion(newEvent(parent, []byte("event 3")), 1)
+	assert.True(t, fes.IsConflict(err))
+	assert.EqualValues(t, &fes.ConflictError{Aggregate: parent, Expected: 1, Actual: 2}, err)
+
+	events, err := backend.Get(parent)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+}
+
+func TestBackend_Delete(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	parent := fes.NewAggregate("parent", "id")
+	other := fes.NewAggregate("parent", "other")
+	child := newEvent(fes.NewAggregate("child", "id"), []byte("child event"))
+	child.Parent = &parent
+	events := []*fes.Event{
+		newEvent(parent, []byte("event 1")),
+		child,
+		newEvent(other, []byte("event 1")),
+	}
+	for _, event := range events {
+		err := backend.Append(event)
+		assert.NoError(t, err)
+	}
+	err := backend.SaveSnapshot(&fes.Snapshot{Aggregate: &parent, Version: 2})
+	assert.NoError(t, err)
+
+	err = backend.Delete(parent)
+	assert.NoError(t, err)
+	getEvents, err := backend.Get(parent)
+	assert.NoError(t, err)
+	assert.Empty(t, getEvents)
+	_, err = backend.GetSnapshot(parent)
+	assert.Equal(t, fes.ErrNotFound, err)
+	aggregates, err := backend.List(func(s string) bool { return true })
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{other}, aggregates)
+
+	// The versions of the deleted aggregate should have been reset.
+	err = backend.AppendIfVersion(newEvent(parent, []byte("event 1")), 0)
+	assert.NoError(t, err)
+}
+
+func TestBackend_GetNonexistent(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	key := fes.NewAggregate("type", "id")
+	getEvents, err := backend.Get(key)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*fes.Event{}, getEvents)
+}
+
+func TestBackend_Replay(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+
+	events := []*fes.Event{
+		newEvent(fes.NewAggregate("type", "id"), []byte("event 1")),
+		newEvent(fes.NewAggregate("type", "other"), []byte("event 2")),
+		newEvent(fes.NewAggregate("type", "id"), []byte("event 3")),
+	}
+	for k := range events {
+		err := backend.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	var replayedEvents []*fes.Event
+	err := backend.Replay(func(event *fes.Event) error {
+		replayedEvents = append(replayedEvents, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	assertEventsEqual(t, events, replayedEvents)
+}
+
+func TestBackend_Subscribe(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	key := fes.NewAggregate("type", "id")
+
+	// Events appended before opening the backend should not be published.
+	err := backend.Append(newEvent(key, []byte("event 0")))
+	assert.NoError(t, err)
+	reopened, err := Open(backend.Config)
+	assert.NoError(t, err)
+	defer reopened.Close()
+
+	sub := reopened.Subscribe(pubsub.SubscriptionOptions{
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, key.Type),
+	})
+
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+	for k := range events {
+		err := reopened.Append(events[k])
+		assert.NoError(t, err)
+	}
+	err = reopened.Poll()
+	assert.NoError(t, err)
+	reopened.Unsubscribe(sub)
+
+	var receivedEvents []*fes.Event
+	for msg := range sub.Ch {
+		event, ok := msg.(*fes.Event)
+		assert.True(t, ok)
+		receivedEvents = append(receivedEvents, event)
+	}
+	assertEventsEqual(t, events, receivedEvents)
+}
+
+func TestBackend_CatchUp(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	key := fes.NewAggregate("type", "id")
+	reopened, err := Open(backend.Config)
+	assert.NoError(t, err)
+	defer reopened.Close()
+	sub := reopened.Subscribe(pubsub.SubscriptionOptions{
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, key.Type),
+	})
+
+	// Events appended after opening the backend, but before catching up, should only be passed to the handler.
+	caughtUpEvents := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range caughtUpEvents {
+		err := backend.Append(caughtUpEvents[k])
+		assert.NoError(t, err)
+	}
+	var handledEvents []*fes.Event
+	count, err := reopened.CatchUp(func(event *fes.Event) error {
+		handledEvents = append(handledEvents, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, len(caughtUpEvents), count)
+	assertEventsEqual(t, caughtUpEvents, handledEvents)
+
+	// Subsequent events should only be published.
+	events := []*fes.Event{
+		newEvent(key, []byte("event 3")),
+	}
+	for k := range events {
+		err := backend.Append(events[k])
+		assert.NoError(t, err)
+	}
+	err = reopened.Poll()
+	assert.NoError(t, err)
+	reopened.Unsubscribe(sub)
+
+	var receivedEvents []*fes.Event
+	for msg := range sub.Ch {
+		event, ok := msg.(*fes.Event)
+		assert.True(t, ok)
+		receivedEvents = append(receivedEvents, event)
+	}
+	assertEventsEqual(t, events, receivedEvents)
+}
+
+func TestBackend_Snapshot(t *testing.T) {
+	backend, teardown := setup(t)
+	defer teardown()
+	key := fes.NewAggregate("type", "id")
+
+	_, err := backend.GetSnapshot(key)
+	assert.Equal(t, fes.ErrNotFound, err)
+
+	for i := uint64(1); i <= 2; i++ {
+		snapshot := &fes.Snapshot{
+			Aggregate: &key,
+			Version:   i,
+		}
+		err = backend.SaveSnapshot(snapshot)
+		assert.NoError(t, err)
+
+		stored, err := backend.GetSnapshot(key)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(snapshot, stored))
+	}
+}
@@ -18,6 +18,8 @@ const (
 	PubSubLabelEventType     = "event.type"
 	PubSubLabelAggregateType = "aggregate.type"
 	PubSubLabelAggregateID   = "aggregate.id"
+	PubSubLabelParentType    = "parent.type"
+	PubSubLabelParentID      = "parent.id"
 )
 
 var (
@@ -241,6 +243,22 @@ func (uc *SubscribedCache) ApplyEvent(event *Event) error {
 	return err
 }
 
+// Lookup looks up the aggregates in the index of the underlying cache, if the underlying cache maintains indexes.
+func (uc *SubscribedCache) Lookup(index string, key string) ([]Aggregate, error) {
+	if ir, ok := uc.CacheReaderWriter.(IndexReader); ok {
+		return ir.Lookup(index, key)
+	}
+	return nil, ErrIndexNotFound
+}
+
+// LookupRange looks up the aggregates in the index of the underlying cache, if the underlying cache maintains indexes.
+func (uc *SubscribedCache) LookupRange(index string, from string, to string) ([]Aggregate, error) {
+	if ir, ok := uc.CacheReaderWriter.(IndexReader); ok {
+		return ir.LookupRange(index, from, to)
+	}
+	return nil, ErrIndexNotFound
+}
+
 // FallbackCache looks into a backing data store in case there is a cache miss
 type FallbackCache struct {
 	cache  CacheReaderWriter
@@ -259,20 +277,10 @@ func (c *FallbackCache) List() []Aggregate {
 	for _, aggregate := range esAggregates {
 		entity, err := c.cache.GetAggregate(aggregate)
 		if err != nil || entity == nil {
-			events, err := c.client.Get(aggregate)
-			if err != nil {
-				logrus.WithField("err", err).Error("failed to get missed entity from event store")
-				continue
-			}
 			e := c.target()
-			err = Project(e, events...)
+			err = c.getFromEventStore(aggregate, e)
 			if err != nil {
-				logrus.WithField("err", err).Error("failed to project missed entity")
-				continue
-			}
-			err = c.cache.Put(e)
-			if err != nil {
-				logrus.WithField("err", err).Error("failed to store missed entity")
+				logrus.WithField("err", err).Error("failed to get missed entity from event store")
 				continue
 			}
 		}
@@ -308,17 +316,8 @@ func (c *FallbackCache) Get(entity Entity) error {
 }
 
 func (c *FallbackCache) getFromEventStore(aggregate Aggregate, target Entity) error {
-	// Look up relevant events in event store
-	events, err := c.client.Get(aggregate)
-	if err != nil {
-		return err
-	}
-	if len(events) == 0 {
-		return ErrNotFound
-	}
-
-	// Reconstruct target
-	err = Project(target, events...)
+	// Reconstruct target from the latest snapshot (if available) and the relevant events in event store
+	err := Rehydrate(c.client, aggregate, target)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,62 @@
+package fes
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var appendConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "fes",
+	Subsystem: "backend",
+	Name:      "append_conflicts_total",
+	Help:      "Count of conditional appends that were rejected because of a version conflict.",
+}, []string{"aggregateType"})
+
+func init() {
+	prometheus.MustRegister(appendConflicts)
+}
+
+// ConflictError indicates that an event could not be appended, because the aggregate has been modified since the
+// writer last read it. The writer is expected to re-read the aggregate and retry.
+type ConflictError struct {
+	Aggregate Aggregate
+	Expected  uint64
+	Actual    uint64
+}
+
+func NewConflictError(aggregate Aggregate, expected uint64, actual uint64) *ConflictError {
+	appendConflicts.WithLabelValues(aggregate.Type).Inc()
+	return &ConflictError{
+		Aggregate: aggregate,
+		Expected:  expected,
+		Actual:    actual,
+	}
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("version conflict on %v: expected version %d, but was %d", e.Aggregate.Format(), e.Expected,
+		e.Actual)
+}
+
+// IsConflict checks whether the (wrapped) error is a ConflictError.
+func IsConflict(err error) bool {
+	_, ok := errors.Cause(err).(*ConflictError)
+	return ok
+}
+
+// ErrConditionalAppendUnsupported is returned by AppendIfVersion if the backend does not implement
+// ConditionalAppender.
+var ErrConditionalAppendUnsupported = errors.New("backend does not support conditional appends")
+
+// AppendIfVersion appends the event if the current version of the aggregate of the event equals the expected version.
+//
+// Backends that do not implement ConditionalAppender cannot guarantee the condition, so the event is not appended
+// and ErrConditionalAppendUnsupported is returned instead.
+func AppendIfVersion(appender EventAppender, event *Event, expectedVersion uint64) error {
+	if ca, ok := appender.(ConditionalAppender); ok {
+		return ca.AppendIfVersion(event, expectedVersion)
+	}
+	return ErrConditionalAppendUnsupported
+}
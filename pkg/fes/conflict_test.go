@@ -0,0 +1,28 @@
+package fes
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsConflict(t *testing.T) {
+	err := NewConflictError(NewAggregate("type", "id"), 1, 2)
+	assert.True(t, IsConflict(err))
+	assert.True(t, IsConflict(errors.Wrap(err, "failed to append")))
+	assert.False(t, IsConflict(errors.New("other error")))
+	assert.False(t, IsConflict(nil))
+}
+
+func TestAppendIfVersion_Unsupported(t *testing.T) {
+	backend := newSnapshotBackend()
+	aggregate := NewAggregate("snapshot", "1")
+	appendDummyEvents(t, backend, aggregate, 0, 2)
+
+	// Backends without support for conditional appends should not append the event at all.
+	event, err := NewEvent(aggregate, &DummyEvent{Msg: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, ErrConditionalAppendUnsupported, AppendIfVersion(backend, event, 2))
+	assert.Len(t, backend.events[aggregate], 2)
+}
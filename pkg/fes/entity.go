@@ -14,13 +14,22 @@ type BaseEntity struct {
 	// parent is a pointer to the wrapper of this mixin, to allow for reflection-based aggregation.
 	parent Entity
 
-	version uint
+	// version is the number of events of the aggregate that have been applied to the entity.
+	version uint64
 }
 
 func (am *BaseEntity) Aggregate() Aggregate {
 	return am.aggregate
 }
 
+func (am *BaseEntity) Version() uint64 {
+	return am.version
+}
+
+func (am *BaseEntity) setVersion(version uint64) {
+	am.version = version
+}
+
 // UpdateState mutates the current Entity to the new provided Entity.
 //
 // By default it uses reflection to update the fields. For improved performance override this method with a
@@ -50,6 +59,7 @@ func (am BaseEntity) CopyBaseEntity(self Entity) *BaseEntity {
 	return &BaseEntity{
 		aggregate: am.aggregate,
 		parent:    self,
+		version:   am.version,
 	}
 }
 
@@ -0,0 +1,142 @@
+package fes
+
+import (
+	"container/heap"
+	"io"
+	"sort"
+)
+
+// Export writes all events of the backend to w in the format of an EventWriter, which can be imported into another
+// backend with Import.
+//
+// If the backend implements Replayer, the events are exported in the order in which they were appended. Otherwise,
+// the events of each aggregate are exported in order and interleaved with the events of other aggregates based on
+// their timestamps. Export returns the number of exported events.
+func Export(backend Backend, w io.Writer) (int, error) {
+	ew := NewEventWriter(w)
+	var count int
+	write := func(event *Event) error {
+		err := ew.Write(event)
+		if err != nil {
+			return err
+		}
+		count++
+		return nil
+	}
+
+	var err error
+	if replayer, ok := backend.(Replayer); ok {
+		err = replayer.Replay(write)
+	} else {
+		err = exportAggregates(backend, write)
+	}
+	if err != nil {
+		return count, err
+	}
+	return count, ew.Close()
+}
+
+// Import appends all events read from r, as written by Export, to the appender. The events are appended in the order
+// in which they were exported, retaining their timestamps and parents. Import returns the number of imported events.
+func Import(appender EventAppender, r io.Reader) (int, error) {
+	er, err := NewEventReader(r)
+	if err != nil {
+		return 0, err
+	}
+	defer er.Close()
+	var count int
+	for {
+		event, err := er.Read()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		err = appender.Append(event)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+func exportAggregates(backend Backend, handler func(event *Event) error) error {
+	aggregates, err := backend.List(func(s string) bool { return true })
+	if err != nil {
+		return err
+	}
+	// Sort the aggregates to make the export of events with equal timestamps deterministic.
+	sort.Slice(aggregates, func(i, j int) bool {
+		return aggregates[i].Format() < aggregates[j].Format()
+	})
+	streams := &eventStreams{}
+	for _, aggregate := range aggregates {
+		events, err := backend.Get(aggregate)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			streams.add(events)
+		}
+	}
+	heap.Init(streams)
+	for streams.Len() > 0 {
+		stream := (*streams)[0]
+		err := handler(stream.events[0])
+		if err != nil {
+			return err
+		}
+		stream.events = stream.events[1:]
+		if len(stream.events) == 0 {
+			heap.Pop(streams)
+		} else {
+			heap.Fix(streams, 0)
+		}
+	}
+	return nil
+}
+
+type eventStream struct {
+	// seq is the position of the stream, used to order streams of which the heads have equal timestamps.
+	seq    int
+	events []*Event
+}
+
+// eventStreams is a min-heap of event streams ordered by the timestamp of the first event of each stream.
+type eventStreams []*eventStream
+
+func (s *eventStreams) add(events []*Event) {
+	*s = append(*s, &eventStream{seq: len(*s), events: events})
+}
+
+func (s eventStreams) Len() int {
+	return len(s)
+}
+
+func (s eventStreams) Less(i, j int) bool {
+	a, b := s[i].events[0].GetTimestamp(), s[j].events[0].GetTimestamp()
+	if a.GetSeconds() != b.GetSeconds() {
+		return a.GetSeconds() < b.GetSeconds()
+	}
+	if a.GetNanos() != b.GetNanos() {
+		return a.GetNanos() < b.GetNanos()
+	}
+	return s[i].seq < s[j].seq
+}
+
+func (s eventStreams) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s *eventStreams) Push(x interface{}) {
+	*s = append(*s, x.(*eventStream))
+}
+
+func (s *eventStreams) Pop() interface{} {
+	old := *s
+	n := len(old)
+	stream := old[n-1]
+	*s = old[:n-1]
+	return stream
+}
@@ -0,0 +1,84 @@
+package fes
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// replayBackend records the order in which events are appended across aggregates.
+type replayBackend struct {
+	*snapshotBackend
+	appended []*Event
+}
+
+func (b *replayBackend) Append(event *Event) error {
+	b.appended = append(b.appended, event)
+	return b.snapshotBackend.Append(event)
+}
+
+func (b *replayBackend) Replay(handler func(event *Event) error) error {
+	for _, event := range b.appended {
+		if err := handler(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTimedEvent(t *testing.T, aggregate Aggregate, msg string, ts time.Time) *Event {
+	event, err := NewEvent(aggregate, &DummyEvent{Msg: msg})
+	assert.NoError(t, err)
+	event.Timestamp, err = ptypes.TimestampProto(ts)
+	assert.NoError(t, err)
+	return event
+}
+
+func TestExportImport(t *testing.T) {
+	now := time.Now()
+	a := NewAggregate("snapshot", "a")
+	b := NewAggregate("snapshot", "b")
+	child := newTimedEvent(t, NewAggregate("child", "1"), "child", now.Add(2*time.Second))
+	child.Parent = &b
+	events := []*Event{
+		newTimedEvent(t, a, "a0", now),
+		newTimedEvent(t, b, "b0", now.Add(time.Second)),
+		child,
+		newTimedEvent(t, a, "a1", now.Add(3*time.Second)),
+	}
+	source := newSnapshotBackend()
+	for _, event := range events {
+		assert.NoError(t, source.Append(event))
+	}
+
+	// Without a Replayer, the events should be ordered by their timestamps.
+	buf := &bytes.Buffer{}
+	n, err := Export(source, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, len(events), n)
+
+	target := &replayBackend{snapshotBackend: newSnapshotBackend()}
+	n, err = Import(target, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, len(events), n)
+	assert.Len(t, target.appended, len(events))
+	for i := range events {
+		assert.True(t, proto.Equal(events[i], target.appended[i]))
+	}
+
+	// With a Replayer, the events should be exported in order of appending.
+	buf.Reset()
+	n, err = Export(target, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, len(events), n)
+	imported, err := ReadEvents(buf)
+	assert.NoError(t, err)
+	assert.Len(t, imported, len(events))
+	for i := range events {
+		assert.True(t, proto.Equal(events[i], imported[i]))
+	}
+}
@@ -11,7 +11,9 @@ It has these top-level messages:
 	Aggregate
 	Event
 	EventHints
+	Snapshot
 	DummyEvent
+	AppendCondition
 */
 package fes
 
@@ -64,6 +66,13 @@ type Event struct {
 	Data      *google_protobuf1.Any      `protobuf:"bytes,5,opt,name=data" json:"data,omitempty"`
 	Parent    *Aggregate                 `protobuf:"bytes,6,opt,name=parent" json:"parent,omitempty"`
 	Hints     *EventHints                `protobuf:"bytes,7,opt,name=hints" json:"hints,omitempty"`
+	// Version is the schema version of the data of the event. Events written with an older schema version are
+	// transformed into the current schema by the registered upcasters before they are projected.
+	Version uint32 `protobuf:"varint,8,opt,name=version" json:"version,omitempty"`
+	// Condition is set on events that are appended conditionally to backends that cannot reject an event before it
+	// has been persisted, such as NATS. These backends ignore the event if the condition did not hold at the position
+	// of the event in the event stream.
+	Condition *AppendCondition `protobuf:"bytes,9,opt,name=condition" json:"condition,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -120,9 +129,25 @@ func (m *Event) GetHints() *EventHints {
 	return nil
 }
 
+func (m *Event) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Event) GetCondition() *AppendCondition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
 // EventHints is a collection of optional metadata that help components in the event store to improve performance.
 type EventHints struct {
 	Completed bool `protobuf:"varint,1,opt,name=completed" json:"completed,omitempty"`
+	// Reopened indicates that the event reopens a previously completed aggregate.
+	Reopened bool `protobuf:"varint,2,opt,name=reopened" json:"reopened,omitempty"`
 }
 
 func (m *EventHints) Reset()                    { *m = EventHints{} }
@@ -137,6 +162,64 @@ func (m *EventHints) GetCompleted() bool {
 	return false
 }
 
+func (m *EventHints) GetReopened() bool {
+	if m != nil {
+		return m.Reopened
+	}
+	return false
+}
+
+// Snapshot is the state of an entity at a specific version of its event stream.
+type Snapshot struct {
+	Aggregate *Aggregate `protobuf:"bytes,1,opt,name=aggregate" json:"aggregate,omitempty"`
+	// Version is the number of events of the aggregate that have been applied to the state.
+	Version   uint64                     `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Data      *google_protobuf1.Any      `protobuf:"bytes,4,opt,name=data" json:"data,omitempty"`
+	// EntityVersion is the version of the entity in the snapshot, which excludes the events of its children.
+	EntityVersion uint64 `protobuf:"varint,5,opt,name=entityVersion" json:"entityVersion,omitempty"`
+}
+
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Snapshot) GetAggregate() *Aggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return nil
+}
+
+func (m *Snapshot) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Snapshot) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *Snapshot) GetData() *google_protobuf1.Any {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Snapshot) GetEntityVersion() uint64 {
+	if m != nil {
+		return m.EntityVersion
+	}
+	return 0
+}
+
 type DummyEvent struct {
 	Msg string `protobuf:"bytes,1,opt,name=msg" json:"msg,omitempty"`
 }
@@ -144,7 +227,7 @@ type DummyEvent struct {
 func (m *DummyEvent) Reset()                    { *m = DummyEvent{} }
 func (m *DummyEvent) String() string            { return proto.CompactTextString(m) }
 func (*DummyEvent) ProtoMessage()               {}
-func (*DummyEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*DummyEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *DummyEvent) GetMsg() string {
 	if m != nil {
@@ -153,35 +236,63 @@ func (m *DummyEvent) GetMsg() string {
 	return ""
 }
 
+// AppendCondition is the condition under which an event was appended.
+type AppendCondition struct {
+	// ExpectedVersion is the version that the aggregate of the event was expected to have before the event.
+	ExpectedVersion uint64 `protobuf:"varint,1,opt,name=expectedVersion" json:"expectedVersion,omitempty"`
+}
+
+func (m *AppendCondition) Reset()                    { *m = AppendCondition{} }
+func (m *AppendCondition) String() string            { return proto.CompactTextString(m) }
+func (*AppendCondition) ProtoMessage()               {}
+func (*AppendCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *AppendCondition) GetExpectedVersion() uint64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Aggregate)(nil), "fission.workflows.eventstore.Aggregate")
 	proto.RegisterType((*Event)(nil), "fission.workflows.eventstore.Event")
 	proto.RegisterType((*EventHints)(nil), "fission.workflows.eventstore.EventHints")
+	proto.RegisterType((*Snapshot)(nil), "fission.workflows.eventstore.Snapshot")
 	proto.RegisterType((*DummyEvent)(nil), "fission.workflows.eventstore.DummyEvent")
+	proto.RegisterType((*AppendCondition)(nil), "fission.workflows.eventstore.AppendCondition")
 }
 
 func init() { proto.RegisterFile("pkg/fes/fes.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x4f, 0x4b, 0xf3, 0x40,
-	0x10, 0x87, 0x69, 0xda, 0xf4, 0x7d, 0x33, 0x82, 0xe8, 0xe2, 0x61, 0x2d, 0x45, 0x4b, 0x2e, 0x06,
-	0x0f, 0x1b, 0xd0, 0x8b, 0x27, 0xa5, 0x62, 0xc1, 0x73, 0xf0, 0xe4, 0x6d, 0x6b, 0x27, 0x6b, 0x68,
-	0xf7, 0x0f, 0xd9, 0xad, 0x25, 0x9f, 0xd4, 0xaf, 0x23, 0xd9, 0x6d, 0x1b, 0x50, 0x28, 0x7a, 0x08,
-	0x2c, 0x93, 0xe7, 0xd9, 0x9d, 0xdf, 0x0c, 0x9c, 0x9a, 0xa5, 0xc8, 0x4b, 0xb4, 0xed, 0xc7, 0x4c,
-	0xad, 0x9d, 0x26, 0xe3, 0xb2, 0xb2, 0xb6, 0xd2, 0x8a, 0x6d, 0x74, 0xbd, 0x2c, 0x57, 0x7a, 0x63,
-	0x19, 0x7e, 0xa0, 0x72, 0xd6, 0xe9, 0x1a, 0x47, 0x97, 0x42, 0x6b, 0xb1, 0xc2, 0xdc, 0xb3, 0xf3,
-	0x75, 0x99, 0xbb, 0x4a, 0xa2, 0x75, 0x5c, 0x9a, 0xa0, 0x8f, 0xce, 0xbf, 0x03, 0x5c, 0x35, 0xe1,
-	0x57, 0x9a, 0x43, 0x32, 0x15, 0xa2, 0x46, 0xc1, 0x1d, 0x92, 0x63, 0x88, 0xaa, 0x05, 0xed, 0x4d,
-	0x7a, 0x59, 0x52, 0x44, 0xd5, 0x82, 0x10, 0x18, 0xb8, 0xc6, 0x20, 0x8d, 0x7c, 0xc5, 0x9f, 0xd3,
-	0xcf, 0x08, 0xe2, 0x59, 0xfb, 0xf6, 0x6f, 0x68, 0x32, 0x83, 0x84, 0xef, 0xae, 0xa7, 0xfd, 0x49,
-	0x2f, 0x3b, 0xba, 0xb9, 0x62, 0x87, 0xc2, 0xb0, 0x7d, 0x37, 0x45, 0x67, 0x92, 0x3b, 0x48, 0xf6,
-	0x99, 0xe8, 0xc0, 0x5f, 0x33, 0x62, 0x21, 0x14, 0xdb, 0x85, 0x62, 0x2f, 0x3b, 0xa2, 0xe8, 0x60,
-	0x92, 0xc1, 0x60, 0xc1, 0x1d, 0xa7, 0xb1, 0x97, 0xce, 0x7e, 0x48, 0x53, 0xd5, 0x14, 0x9e, 0x20,
-	0x0f, 0x30, 0x34, 0xbc, 0x46, 0xe5, 0xe8, 0xf0, 0x6f, 0x7d, 0x6e, 0x35, 0x72, 0x0f, 0xf1, 0x7b,
-	0xa5, 0x9c, 0xa5, 0xff, 0xbc, 0x9f, 0x1d, 0xf6, 0xfd, 0x0c, 0x9f, 0x5b, 0xbe, 0x08, 0x5a, 0x7a,
-	0x0d, 0xd0, 0x15, 0xc9, 0x18, 0x92, 0x37, 0x2d, 0xcd, 0x0a, 0x1d, 0x86, 0x21, 0xff, 0x2f, 0xba,
-	0x42, 0x7a, 0x01, 0xf0, 0xb4, 0x96, 0xb2, 0x09, 0x9b, 0x38, 0x81, 0xbe, 0xb4, 0x62, 0xbb, 0x8a,
-	0xf6, 0xf8, 0x18, 0xbf, 0xf6, 0x4b, 0xb4, 0xf3, 0xa1, 0xcf, 0x79, 0xfb, 0x15, 0x00, 0x00, 0xff,
-	0xff, 0xdc, 0xbf, 0x40, 0x6a, 0x53, 0x02, 0x00, 0x00,
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcf, 0x6b, 0xd4, 0x40,
+	0x14, 0x26, 0x9b, 0x64, 0x9b, 0x3c, 0xa9, 0xd5, 0xc1, 0xc3, 0xb8, 0x14, 0x5d, 0x82, 0x60, 0x2e,
+	0x26, 0xa0, 0x17, 0x41, 0x50, 0xaa, 0x56, 0x04, 0x6f, 0xa3, 0x78, 0xf0, 0x96, 0x36, 0x2f, 0x69,
+	0xe8, 0x66, 0x66, 0xc8, 0xbc, 0xb6, 0xe6, 0x9f, 0xf3, 0xbf, 0xf2, 0x2e, 0x33, 0xd9, 0x6c, 0xdc,
+	0x15, 0x5a, 0xdb, 0x43, 0x20, 0xf3, 0xe6, 0xfb, 0xbe, 0xf7, 0xbd, 0x1f, 0x03, 0x0f, 0xf5, 0x79,
+	0x9d, 0x57, 0x68, 0xec, 0x97, 0xe9, 0x4e, 0x91, 0x62, 0x87, 0x55, 0x63, 0x4c, 0xa3, 0x64, 0x76,
+	0xa5, 0xba, 0xf3, 0x6a, 0xa5, 0xae, 0x4c, 0x86, 0x97, 0x28, 0xc9, 0x90, 0xea, 0x70, 0xf1, 0xb4,
+	0x56, 0xaa, 0x5e, 0x61, 0xee, 0xb0, 0x27, 0x17, 0x55, 0x4e, 0x4d, 0x8b, 0x86, 0x8a, 0x56, 0x0f,
+	0xf4, 0xc5, 0xe3, 0x5d, 0x40, 0x21, 0xfb, 0xe1, 0x2a, 0xc9, 0x21, 0x3e, 0xaa, 0xeb, 0x0e, 0xeb,
+	0x82, 0x90, 0xdd, 0x87, 0x59, 0x53, 0x72, 0x6f, 0xe9, 0xa5, 0xb1, 0x98, 0x35, 0x25, 0x63, 0x10,
+	0x50, 0xaf, 0x91, 0xcf, 0x5c, 0xc4, 0xfd, 0x27, 0xbf, 0x7c, 0x08, 0x8f, 0x6d, 0xee, 0xff, 0x41,
+	0xb3, 0x63, 0x88, 0x8b, 0x51, 0x9e, 0xfb, 0x4b, 0x2f, 0xbd, 0xf7, 0xf2, 0x79, 0x76, 0x5d, 0x31,
+	0xd9, 0xc6, 0x8d, 0x98, 0x98, 0xec, 0x35, 0xc4, 0x9b, 0x9a, 0x78, 0xe0, 0x64, 0x16, 0xd9, 0x50,
+	0x54, 0x36, 0x16, 0x95, 0x7d, 0x1b, 0x11, 0x62, 0x02, 0xb3, 0x14, 0x82, 0xb2, 0xa0, 0x82, 0x87,
+	0x8e, 0xf4, 0xe8, 0x1f, 0xd2, 0x91, 0xec, 0x85, 0x43, 0xb0, 0x77, 0x30, 0xd7, 0x45, 0x87, 0x92,
+	0xf8, 0xfc, 0x76, 0x3e, 0xd7, 0x34, 0xf6, 0x16, 0xc2, 0xb3, 0x46, 0x92, 0xe1, 0x7b, 0x8e, 0x9f,
+	0x5e, 0xcf, 0x77, 0x3d, 0xfc, 0x6c, 0xf1, 0x62, 0xa0, 0x31, 0x0e, 0x7b, 0x97, 0xd8, 0x59, 0x06,
+	0x8f, 0x96, 0x5e, 0xba, 0x2f, 0xc6, 0x23, 0xfb, 0x02, 0xf1, 0xa9, 0x92, 0x65, 0x43, 0xf6, 0x2e,
+	0x76, 0xea, 0x2f, 0x6e, 0x70, 0xa7, 0x35, 0xca, 0xf2, 0xc3, 0x48, 0x12, 0x13, 0x3f, 0xf9, 0x04,
+	0x30, 0xe5, 0x66, 0x87, 0x56, 0xba, 0xd5, 0x2b, 0x24, 0x1c, 0x66, 0x19, 0x89, 0x29, 0xc0, 0x16,
+	0x10, 0x75, 0xa8, 0x34, 0x4a, 0x2c, 0xdd, 0x58, 0x23, 0xb1, 0x39, 0x27, 0xbf, 0x3d, 0x88, 0xbe,
+	0xca, 0x42, 0x9b, 0x33, 0x45, 0xdb, 0x73, 0xf6, 0xee, 0x3c, 0xe7, 0xbf, 0x5a, 0x60, 0xd3, 0x05,
+	0x53, 0x0b, 0xb6, 0x36, 0xc0, 0xbf, 0xcb, 0x06, 0x04, 0x37, 0x6e, 0xc0, 0x33, 0xd8, 0x47, 0x49,
+	0x0d, 0xf5, 0xdf, 0xd7, 0x1e, 0x42, 0xe7, 0x61, 0x3b, 0x98, 0x3c, 0x01, 0xf8, 0x78, 0xd1, 0xb6,
+	0xfd, 0xf0, 0x08, 0x1e, 0x80, 0xdf, 0x9a, 0x7a, 0xfd, 0x0a, 0xec, 0x6f, 0xf2, 0x06, 0x0e, 0x76,
+	0xba, 0xcf, 0x52, 0x38, 0xc0, 0x9f, 0x1a, 0x4f, 0x09, 0xcb, 0x51, 0xda, 0x73, 0xd2, 0xbb, 0xe1,
+	0xf7, 0xe1, 0x0f, 0xbf, 0x42, 0x73, 0x32, 0x77, 0xee, 0x5e, 0xfd, 0x19, 0x00, 0x67, 0xcc, 0xe0,
+	0x26, 0x0b, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,155 @@
+package fes
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var ErrIndexNotFound = errors.New("could not find index")
+
+// IndexFunc returns the keys under which the entity should be indexed.
+type IndexFunc func(entity Entity) []string
+
+// IndexedCache maintains secondary indexes of the entities that are put into the underlying cache.
+//
+// Entities are only removed from the indexes once they are invalidated. Entities that are evicted by the underlying
+// cache, such as the LRUCache, remain indexed, as these can still be reloaded from the backend. To bound the size of
+// the indexes, the Retention should be configured with the cache, which invalidates the entities that it removes.
+type IndexedCache struct {
+	CacheReaderWriter
+	indexes map[string]*index
+	lock    sync.RWMutex
+}
+
+type index struct {
+	fn      IndexFunc
+	entries map[string]map[Aggregate]struct{} // Map: key -> aggregates
+	keys    []string                          // Sorted keys of the entries, used for range lookups.
+	indexed map[Aggregate][]string            // Map: aggregate -> keys
+}
+
+func NewIndexedCache(cache CacheReaderWriter, indexes map[string]IndexFunc) *IndexedCache {
+	c := &IndexedCache{
+		CacheReaderWriter: cache,
+		indexes:           map[string]*index{},
+	}
+	for name, fn := range indexes {
+		c.indexes[name] = &index{
+			fn:      fn,
+			entries: map[string]map[Aggregate]struct{}{},
+			indexed: map[Aggregate][]string{},
+		}
+	}
+	return c
+}
+
+func (c *IndexedCache) Put(entity Entity) error {
+	err := c.CacheReaderWriter.Put(entity)
+	if err != nil {
+		return err
+	}
+
+	aggregate := entity.Aggregate()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, idx := range c.indexes {
+		keys := idx.fn(entity)
+		if equalKeys(idx.indexed[aggregate], keys) {
+			// Most events do not change the indexed keys, so avoid the cost of re-indexing the entity.
+			continue
+		}
+		idx.remove(aggregate)
+		idx.add(aggregate, keys)
+	}
+	return nil
+}
+
+func (c *IndexedCache) Invalidate(ref *Aggregate) {
+	c.CacheReaderWriter.Invalidate(ref)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, idx := range c.indexes {
+		idx.remove(*ref)
+	}
+}
+
+func (c *IndexedCache) Lookup(index string, key string) ([]Aggregate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	idx, ok := c.indexes[index]
+	if !ok {
+		return nil, ErrIndexNotFound
+	}
+	var results []Aggregate
+	for aggregate := range idx.entries[key] {
+		results = append(results, aggregate)
+	}
+	return results, nil
+}
+
+func (c *IndexedCache) LookupRange(index string, from string, to string) ([]Aggregate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	idx, ok := c.indexes[index]
+	if !ok {
+		return nil, ErrIndexNotFound
+	}
+	var results []Aggregate
+	for i := sort.SearchStrings(idx.keys, from); i < len(idx.keys); i++ {
+		key := idx.keys[i]
+		if len(to) > 0 && key >= to {
+			break
+		}
+		for aggregate := range idx.entries[key] {
+			results = append(results, aggregate)
+		}
+	}
+	return results, nil
+}
+
+func (idx *index) add(aggregate Aggregate, keys []string) {
+	for _, key := range keys {
+		entries, ok := idx.entries[key]
+		if !ok {
+			entries = map[Aggregate]struct{}{}
+			idx.entries[key] = entries
+			i := sort.SearchStrings(idx.keys, key)
+			idx.keys = append(idx.keys, "")
+			copy(idx.keys[i+1:], idx.keys[i:])
+			idx.keys[i] = key
+		}
+		entries[aggregate] = struct{}{}
+	}
+	if len(keys) > 0 {
+		idx.indexed[aggregate] = keys
+	}
+}
+
+func (idx *index) remove(aggregate Aggregate) {
+	for _, key := range idx.indexed[aggregate] {
+		entries := idx.entries[key]
+		delete(entries, aggregate)
+		if len(entries) == 0 {
+			delete(idx.entries, key)
+			i := sort.SearchStrings(idx.keys, key)
+			if i < len(idx.keys) && idx.keys[i] == key {
+				idx.keys = append(idx.keys[:i], idx.keys[i+1:]...)
+			}
+		}
+	}
+	delete(idx.indexed, aggregate)
+}
+
+func equalKeys(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
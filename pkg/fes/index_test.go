@@ -0,0 +1,91 @@
+package fes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestIndexedCache creates a cache that indexes snapshot entities by their messages.
+func newTestIndexedCache() *IndexedCache {
+	return NewIndexedCache(NewMapCache(), map[string]IndexFunc{
+		"msgs": func(entity Entity) []string {
+			return entity.(*snapshotEntity).msgs
+		},
+	})
+}
+
+func TestIndexedCache_Lookup(t *testing.T) {
+	cache := newTestIndexedCache()
+	assert.NoError(t, cache.Put(newTestEntity("1", "a", "b")))
+	assert.NoError(t, cache.Put(newTestEntity("2", "b")))
+
+	as, err := cache.Lookup("msgs", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{NewAggregate("snapshot", "1")}, as)
+
+	as, err = cache.Lookup("msgs", "b")
+	assert.NoError(t, err)
+	assert.Len(t, as, 2)
+
+	as, err = cache.Lookup("msgs", "c")
+	assert.NoError(t, err)
+	assert.Empty(t, as)
+
+	_, err = cache.Lookup("nonexistent", "a")
+	assert.Equal(t, ErrIndexNotFound, err)
+}
+
+func TestIndexedCache_LookupRange(t *testing.T) {
+	cache := newTestIndexedCache()
+	assert.NoError(t, cache.Put(newTestEntity("3", "c")))
+	assert.NoError(t, cache.Put(newTestEntity("1", "a")))
+	assert.NoError(t, cache.Put(newTestEntity("2", "b")))
+
+	as, err := cache.LookupRange("msgs", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{
+		NewAggregate("snapshot", "1"),
+		NewAggregate("snapshot", "2"),
+		NewAggregate("snapshot", "3"),
+	}, as)
+
+	as, err = cache.LookupRange("msgs", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{NewAggregate("snapshot", "2")}, as)
+
+	as, err = cache.LookupRange("msgs", "aa", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{NewAggregate("snapshot", "2"), NewAggregate("snapshot", "3")}, as)
+}
+
+func TestIndexedCache_Update(t *testing.T) {
+	cache := newTestIndexedCache()
+	assert.NoError(t, cache.Put(newTestEntity("1", "a")))
+	assert.NoError(t, cache.Put(newTestEntity("1", "b")))
+
+	// The entity should have been moved to the new key.
+	as, err := cache.Lookup("msgs", "a")
+	assert.NoError(t, err)
+	assert.Empty(t, as)
+	as, err = cache.LookupRange("msgs", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{NewAggregate("snapshot", "1")}, as)
+
+	cache.Invalidate(&Aggregate{Type: "snapshot", Id: "1"})
+	as, err = cache.LookupRange("msgs", "", "")
+	assert.NoError(t, err)
+	assert.Empty(t, as)
+}
+
+func TestIndexedCache_UpdateUnchangedKeys(t *testing.T) {
+	cache := newTestIndexedCache()
+	assert.NoError(t, cache.Put(newTestEntity("1", "a", "b")))
+	assert.NoError(t, cache.Put(newTestEntity("2", "b")))
+	assert.NoError(t, cache.Put(newTestEntity("1", "a", "b")))
+
+	as, err := cache.LookupRange("msgs", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, as, 3)
+	assert.Equal(t, []string{"a", "b"}, cache.indexes["msgs"].keys)
+}
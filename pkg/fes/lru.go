@@ -0,0 +1,288 @@
+package fes
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultCacheSize = 10000
+	DefaultCacheTTL  = time.Hour
+)
+
+var (
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "hits_total",
+		Help:      "Count of cache lookups that were served from the cache.",
+	}, []string{"name"})
+
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "misses_total",
+		Help:      "Count of cache lookups that were not served from the cache.",
+	}, []string{"name"})
+
+	cacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "evictions_total",
+		Help:      "Count of entries that have been evicted from the cache.",
+	}, []string{"name"})
+)
+
+func init() {
+	prometheus.MustRegister(cacheHits, cacheMisses, cacheEvictions)
+}
+
+type LRUCacheConfig struct {
+	Name string
+
+	// MaxSize is the maximum number of entries in the cache. Once exceeded, the least recently used evictable entries
+	// are evicted. If not set, DefaultCacheSize is used.
+	MaxSize int
+
+	// TTL is the duration after which an evictable entry that has not been accessed is evicted. Expired entries are
+	// evicted when entries are added to the cache. If not set, entries are only evicted once the cache exceeds the
+	// MaxSize.
+	TTL time.Duration
+
+	// Evictable determines whether an entity can be evicted from the cache. If not set, all entities are evictable.
+	Evictable func(entity Entity) bool
+}
+
+// LRUCache is a bounded CacheReaderWriter that evicts the least recently used entries, and lazily reloads missing
+// entities from the backend.
+//
+// Only entities that are evictable are evicted, and cached after being reloaded. Evictable entities (e.g. succeeded
+// invocations) should not be expected to change anymore, as a reloaded entity already contains the events that are
+// still to be delivered to a SubscribedCache. The other entities, including finished entities that can be reopened
+// (e.g. failed invocations, which can be retried), are maintained by the SubscribedCache alone.
+//
+// Invalidated entities are not reloaded from the backend, as backends that do not implement Deleter still contain the
+// events of the invalidated entities.
+type LRUCache struct {
+	Name    string
+	backend Backend
+	target  func(id string) Entity
+	maxSize int
+	ttl     time.Duration
+	evictFn func(entity Entity) bool
+	entries map[Aggregate]*list.Element
+	order   *list.List // Most recently used entries are at the front.
+
+	// invalidated contains the invalidated aggregates that should not be reloaded. It is only maintained for
+	// backends that do not implement Deleter; the other backends no longer contain the invalidated aggregates.
+	invalidated map[Aggregate]bool
+	lock        sync.Mutex
+}
+
+type lruEntry struct {
+	aggregate Aggregate
+	entity    Entity
+	accessed  time.Time
+}
+
+func NewLRUCache(backend Backend, target func(id string) Entity, cfg LRUCacheConfig) *LRUCache {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultCacheSize
+	}
+	if cfg.Evictable == nil {
+		cfg.Evictable = func(entity Entity) bool {
+			return true
+		}
+	}
+	return &LRUCache{
+		Name:    cfg.Name,
+		backend: backend,
+		target:  target,
+		maxSize: cfg.MaxSize,
+		ttl:     cfg.TTL,
+		evictFn: cfg.Evictable,
+		entries: map[Aggregate]*list.Element{},
+		order:   list.New(),
+
+		invalidated: map[Aggregate]bool{},
+	}
+}
+
+func (c *LRUCache) Get(entity Entity) error {
+	if entity == nil {
+		return errors.New("entity is nil")
+	}
+
+	ref := entity.Aggregate()
+	err := validateAggregate(ref)
+	if err != nil {
+		return err
+	}
+
+	cached, ok := c.lookup(ref)
+	if !ok {
+		cached, err = c.load(ref)
+		if err != nil {
+			return err
+		}
+	}
+	if cached == nil {
+		return ErrNotFound
+	}
+	return entity.UpdateState(cached)
+}
+
+// GetAggregate returns the cached entity, reloading it from the backend if it is evictable. Similar to the MapCache,
+// it returns nil if the entity could not be found.
+func (c *LRUCache) GetAggregate(aggregate Aggregate) (Entity, error) {
+	err := validateAggregate(aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, ok := c.lookup(aggregate)
+	if ok {
+		return cached, nil
+	}
+	entity, err := c.load(aggregate)
+	if err != nil || entity == nil || !c.evictFn(entity) {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (c *LRUCache) Put(entity Entity) error {
+	ref := entity.Aggregate()
+	err := validateAggregate(ref)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.invalidated, ref)
+	now := time.Now()
+	if el, ok := c.entries[ref]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.entity = entity
+		entry.accessed = now
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[ref] = c.order.PushFront(&lruEntry{
+			aggregate: ref,
+			entity:    entity,
+			accessed:  now,
+		})
+		cacheCount.WithLabelValues(c.Name).Inc()
+	}
+	c.evict(now)
+	return nil
+}
+
+func (c *LRUCache) Invalidate(ref *Aggregate) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if el, ok := c.entries[*ref]; ok {
+		c.remove(el)
+	}
+	if _, ok := c.backend.(Deleter); !ok {
+		c.invalidated[*ref] = true
+	}
+}
+
+// List returns the aggregates of the entities that are currently in the cache; evicted entities are not included.
+func (c *LRUCache) List() []Aggregate {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var results []Aggregate
+	for aggregate := range c.entries {
+		results = append(results, aggregate)
+	}
+	return results
+}
+
+// lookup returns the cached entity, marking it as recently used.
+func (c *LRUCache) lookup(aggregate Aggregate) (Entity, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	el, ok := c.entries[aggregate]
+	if !ok {
+		cacheMisses.WithLabelValues(c.Name).Inc()
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	entry.accessed = time.Now()
+	c.order.MoveToFront(el)
+	cacheHits.WithLabelValues(c.Name).Inc()
+	return entry.entity, true
+}
+
+// load rehydrates the entity from the backend, caching it if it is evictable. It returns nil if the aggregate has no
+// events or has been invalidated.
+func (c *LRUCache) load(aggregate Aggregate) (Entity, error) {
+	c.lock.Lock()
+	invalidated := c.invalidated[aggregate]
+	c.lock.Unlock()
+	if invalidated {
+		return nil, nil
+	}
+	entity := c.target(aggregate.Id)
+	if entity.Aggregate() != aggregate {
+		// The aggregate is of another type, such as a child aggregate.
+		return nil, nil
+	}
+	err := Rehydrate(c.backend, aggregate, entity)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if c.evictFn(entity) {
+		err = c.Put(entity)
+		if err != nil {
+			logrus.WithField("aggregate", aggregate.Format()).Warnf("Failed to cache reloaded entity: %v", err)
+		}
+	}
+	return entity, nil
+}
+
+// evict removes the expired entries, followed by the least recently used entries until the cache is within its
+// bounds. Entries that are not evictable are skipped. The caller should hold the lock.
+func (c *LRUCache) evict(now time.Time) {
+	if c.ttl > 0 {
+		for el := c.order.Back(); el != nil; {
+			entry := el.Value.(*lruEntry)
+			if now.Sub(entry.accessed) < c.ttl {
+				// The remaining entries have been accessed more recently.
+				break
+			}
+			prev := el.Prev()
+			if c.evictFn(entry.entity) {
+				c.remove(el)
+				cacheEvictions.WithLabelValues(c.Name).Inc()
+			}
+			el = prev
+		}
+	}
+
+	for el := c.order.Back(); el != nil && len(c.entries) > c.maxSize; {
+		prev := el.Prev()
+		if c.evictFn(el.Value.(*lruEntry).entity) {
+			c.remove(el)
+			cacheEvictions.WithLabelValues(c.Name).Inc()
+		}
+		el = prev
+	}
+}
+
+func (c *LRUCache) remove(el *list.Element) {
+	entry := c.order.Remove(el).(*lruEntry)
+	delete(c.entries, entry.aggregate)
+	cacheCount.WithLabelValues(c.Name).Dec()
+}
@@ -0,0 +1,114 @@
+package fes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLRUCache creates a cache in which snapshot entities are evictable once they contain at least two events.
+func newTestLRUCache(backend Backend, maxSize int, ttl time.Duration) *LRUCache {
+	return NewLRUCache(backend, func(id string) Entity {
+		return newSnapshotEntity(id)
+	}, LRUCacheConfig{
+		Name:    "test",
+		MaxSize: maxSize,
+		TTL:     ttl,
+		Evictable: func(entity Entity) bool {
+			return len(entity.(*snapshotEntity).msgs) >= 2
+		},
+	})
+}
+
+func newTestEntity(id string, msgs ...string) *snapshotEntity {
+	entity := newSnapshotEntity(id)
+	entity.msgs = msgs
+	return entity
+}
+
+func TestLRUCache_EvictLeastRecentlyUsed(t *testing.T) {
+	cache := newTestLRUCache(newSnapshotBackend(), 2, 0)
+	assert.NoError(t, cache.Put(newTestEntity("1", "0", "1")))
+	assert.NoError(t, cache.Put(newTestEntity("2", "0", "1")))
+
+	// Accessing the first entity should make the second entity the least recently used one.
+	entity, err := cache.GetAggregate(NewAggregate("snapshot", "1"))
+	assert.NoError(t, err)
+	assert.NotNil(t, entity)
+
+	assert.NoError(t, cache.Put(newTestEntity("3", "0", "1")))
+	assert.Len(t, cache.List(), 2)
+	assert.Contains(t, cache.List(), NewAggregate("snapshot", "1"))
+	assert.Contains(t, cache.List(), NewAggregate("snapshot", "3"))
+}
+
+func TestLRUCache_EvictOnlyEvictable(t *testing.T) {
+	cache := newTestLRUCache(newSnapshotBackend(), 1, 0)
+	assert.NoError(t, cache.Put(newTestEntity("1", "0")))
+	assert.NoError(t, cache.Put(newTestEntity("2", "0")))
+	assert.Len(t, cache.List(), 2)
+
+	assert.NoError(t, cache.Put(newTestEntity("3", "0", "1")))
+	assert.Len(t, cache.List(), 2)
+	assert.Contains(t, cache.List(), NewAggregate("snapshot", "1"))
+	assert.Contains(t, cache.List(), NewAggregate("snapshot", "2"))
+}
+
+func TestLRUCache_EvictExpired(t *testing.T) {
+	cache := newTestLRUCache(newSnapshotBackend(), 10, 10*time.Millisecond)
+	assert.NoError(t, cache.Put(newTestEntity("1", "0", "1")))
+	assert.NoError(t, cache.Put(newTestEntity("2", "0")))
+	time.Sleep(20 * time.Millisecond)
+
+	assert.NoError(t, cache.Put(newTestEntity("3", "0", "1")))
+	assert.Len(t, cache.List(), 2)
+	assert.Contains(t, cache.List(), NewAggregate("snapshot", "2"))
+	assert.Contains(t, cache.List(), NewAggregate("snapshot", "3"))
+}
+
+func TestLRUCache_ReloadOnMiss(t *testing.T) {
+	backend := newSnapshotBackend()
+	cache := newTestLRUCache(backend, 10, 0)
+	finished := NewAggregate("snapshot", "finished")
+	active := NewAggregate("snapshot", "active")
+	appendDummyEvents(t, backend, finished, 0, 2)
+	appendDummyEvents(t, backend, active, 0, 1)
+
+	// Evictable entities should be reloaded and cached.
+	entity, err := cache.GetAggregate(finished)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1"}, entity.(*snapshotEntity).msgs)
+	assert.EqualValues(t, 2, entity.Version())
+	assert.Equal(t, []Aggregate{finished}, cache.List())
+
+	// Other entities should only be returned by Get, without caching them.
+	entity, err = cache.GetAggregate(active)
+	assert.NoError(t, err)
+	assert.Nil(t, entity)
+	err = cache.Get(newSnapshotEntity(active.Id))
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{finished}, cache.List())
+
+	err = cache.Get(newSnapshotEntity("nonexistent"))
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLRUCache_NoReloadAfterInvalidate(t *testing.T) {
+	// Hide the Delete method of the backend, as the invalidated events remain in backends that do not support it.
+	backend := newSnapshotBackend()
+	cache := newTestLRUCache(struct{ Backend }{backend}, 10, 0)
+	finished := NewAggregate("snapshot", "finished")
+	appendDummyEvents(t, backend, finished, 0, 2)
+
+	entity, err := cache.GetAggregate(finished)
+	assert.NoError(t, err)
+	assert.NotNil(t, entity)
+
+	cache.Invalidate(&finished)
+	entity, err = cache.GetAggregate(finished)
+	assert.NoError(t, err)
+	assert.Nil(t, entity)
+	assert.Equal(t, ErrNotFound, cache.Get(newSnapshotEntity(finished.Id)))
+	assert.Empty(t, cache.List())
+}
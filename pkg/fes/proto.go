@@ -25,12 +25,12 @@ func (m *Event) Labels() labels.Labels {
 	}
 
 	return labels.Set{
-		"aggregate.id":   m.Aggregate.Id,
-		"aggregate.type": m.Aggregate.Type,
-		"parent.type":    parent.Type,
-		"parent.id":      parent.Id,
-		"event.id":       m.Id,
-		"event.type":     m.Type,
+		PubSubLabelAggregateID:   m.Aggregate.Id,
+		PubSubLabelAggregateType: m.Aggregate.Type,
+		PubSubLabelParentType:    parent.Type,
+		PubSubLabelParentID:      parent.Id,
+		PubSubLabelEventID:       m.Id,
+		PubSubLabelEventType:     m.Type,
 	}
 }
 
@@ -0,0 +1,240 @@
+package fes
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultRetentionInterval = time.Minute
+)
+
+var (
+	retentionArchived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "retention",
+		Name:      "archived_total",
+		Help:      "Count of completed aggregates that have been archived and removed.",
+	}, []string{"aggregateType"})
+
+	retentionPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "fes",
+		Subsystem: "retention",
+		Name:      "pending",
+		Help:      "The current number of completed aggregates that are awaiting archival.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(retentionArchived, retentionPending)
+}
+
+// RetentionPolicy determines how long the events of completed aggregates are retained in the backend.
+type RetentionPolicy struct {
+	// AggregateType is the type of the aggregates to which the policy applies.
+	AggregateType string
+
+	// EventType is the type of the completing event to which the policy applies, which allows aggregates to be
+	// retained differently depending on how they completed (e.g. failed invocations could be retained longer). If
+	// empty, the policy applies regardless of the completing event.
+	EventType string
+
+	// MaxAge is the duration after the completion of an aggregate after which it is archived.
+	MaxAge time.Duration
+}
+
+// Retention archives and removes the aggregates that have been completed for longer than their retention policy
+// allows.
+//
+// An aggregate is considered completed once an event with the completed hint has been appended to it. After the
+// archival of an aggregate, it is removed from the backend (if the backend implements Deleter) and the caches.
+type Retention struct {
+	backend  Backend
+	archiver Archiver
+	policies []RetentionPolicy
+	caches   []CacheWriter
+
+	// expiries contains the time at which each completed aggregate should be archived.
+	expiries map[Aggregate]time.Time
+	lock     sync.Mutex
+}
+
+// NewRetention creates a new retention subsystem. The first matching policy applies to a completed aggregate;
+// aggregates to which no policy applies are retained indefinitely. If archiver is nil, the events of the aggregates
+// are removed without archiving them.
+func NewRetention(backend Backend, archiver Archiver, policies []RetentionPolicy, caches ...CacheWriter) *Retention {
+	return &Retention{
+		backend:  backend,
+		archiver: archiver,
+		policies: policies,
+		caches:   caches,
+		expiries: map[Aggregate]time.Time{},
+	}
+}
+
+// Run tracks the completed aggregates using the events received over the subscription, and archives the expired
+// aggregates at every interval.
+func (r *Retention) Run(ctx context.Context, sub *pubsub.Subscription, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRetentionInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("Retention: listener stopped.")
+			return
+		case msg := <-sub.Ch:
+			event, ok := msg.(*Event)
+			if !ok {
+				logrus.WithField("msg", msg).Error("Received a malformed message. Ignoring.")
+				continue
+			}
+			r.HandleEvent(event)
+		case <-ticker.C:
+			err := r.Expire(time.Now())
+			if err != nil {
+				logrus.Errorf("Failed to archive expired aggregates: %v", err)
+			}
+		}
+	}
+}
+
+// Load schedules the archival of the completed aggregates that are already persisted in the backend, which is needed
+// for backends that do not replay the persisted events to the subscribers.
+func (r *Retention) Load() error {
+	aggregates, err := r.backend.List(func(s string) bool {
+		for _, policy := range r.policies {
+			if strings.HasPrefix(s, policy.AggregateType) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	for _, aggregate := range aggregates {
+		events, err := r.backend.Get(aggregate)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			r.HandleEvent(event)
+		}
+	}
+	return nil
+}
+
+// HandleEvent schedules the archival of the aggregate of the event if the event completes the aggregate, or cancels
+// the archival if the event reopens the aggregate.
+func (r *Retention) HandleEvent(event *Event) {
+	if event.Aggregate == nil {
+		return
+	}
+	aggregate := *event.Aggregate
+	if event.Parent != nil {
+		aggregate = *event.Parent
+	}
+	if event.GetHints().GetReopened() {
+		r.lock.Lock()
+		delete(r.expiries, aggregate)
+		retentionPending.Set(float64(len(r.expiries)))
+		r.lock.Unlock()
+		return
+	}
+	if !event.GetHints().GetCompleted() {
+		return
+	}
+	policy, ok := r.policy(aggregate.Type, event.Type)
+	if !ok {
+		return
+	}
+	completedAt, err := ptypes.Timestamp(event.Timestamp)
+	if err != nil {
+		completedAt = time.Now()
+	}
+
+	r.lock.Lock()
+	r.expiries[aggregate] = completedAt.Add(policy.MaxAge)
+	retentionPending.Set(float64(len(r.expiries)))
+	r.lock.Unlock()
+}
+
+// Expire archives all aggregates that should have been archived at the provided time. Aggregates that fail to be
+// archived remain scheduled for archival; the last encountered error is returned.
+func (r *Retention) Expire(now time.Time) error {
+	var expired []Aggregate
+	r.lock.Lock()
+	for aggregate, expiry := range r.expiries {
+		if !expiry.After(now) {
+			expired = append(expired, aggregate)
+		}
+	}
+	r.lock.Unlock()
+
+	var err error
+	for _, aggregate := range expired {
+		archiveErr := r.Archive(aggregate)
+		if archiveErr != nil {
+			// Retry the archival of the aggregate on the next expiration.
+			logrus.WithField("aggregate", aggregate.Format()).Warnf("Failed to archive aggregate: %v", archiveErr)
+			err = archiveErr
+			continue
+		}
+		r.lock.Lock()
+		delete(r.expiries, aggregate)
+		retentionPending.Set(float64(len(r.expiries)))
+		r.lock.Unlock()
+	}
+	return err
+}
+
+// Archive archives the events of the aggregate, after which the aggregate is removed from the backend and caches.
+func (r *Retention) Archive(aggregate Aggregate) error {
+	events, err := r.backend.Get(aggregate)
+	if err != nil {
+		return err
+	}
+	if r.archiver != nil && len(events) > 0 {
+		err = r.archiver.Archive(aggregate, events)
+		if err != nil {
+			return err
+		}
+	}
+	if deleter, ok := r.backend.(Deleter); ok {
+		err = deleter.Delete(aggregate)
+		if err != nil {
+			return err
+		}
+	} else {
+		logrus.WithField("aggregate", aggregate.Format()).
+			Debug("Backend does not support deletion; only removing the archived aggregate from the caches.")
+	}
+	for _, cache := range r.caches {
+		cache.Invalidate(&aggregate)
+	}
+	retentionArchived.WithLabelValues(aggregate.Type).Inc()
+	logrus.WithFields(logrus.Fields{
+		"aggregate": aggregate.Format(),
+		"events":    len(events),
+	}).Debug("Archived aggregate.")
+	return nil
+}
+
+func (r *Retention) policy(aggregateType string, eventType string) (RetentionPolicy, bool) {
+	for _, policy := range r.policies {
+		if policy.AggregateType == aggregateType && (len(policy.EventType) == 0 || policy.EventType == eventType) {
+			return policy, true
+		}
+	}
+	return RetentionPolicy{}, false
+}
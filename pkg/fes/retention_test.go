@@ -0,0 +1,146 @@
+package fes
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func (b *snapshotBackend) Delete(aggregate Aggregate) error {
+	delete(b.events, aggregate)
+	delete(b.snapshots, aggregate)
+	return nil
+}
+
+func appendCompletedEvent(t *testing.T, backend Backend, aggregate Aggregate, completedAt time.Time) {
+	event, err := NewEvent(aggregate, &DummyEvent{Msg: "completed"})
+	assert.NoError(t, err)
+	event.Timestamp, err = ptypes.TimestampProto(completedAt)
+	assert.NoError(t, err)
+	event.Hints = &EventHints{Completed: true}
+	assert.NoError(t, backend.Append(event))
+}
+
+func setupArchiver(t *testing.T) (*FileArchiver, func()) {
+	dir, err := ioutil.TempDir("", "fes-archive")
+	assert.NoError(t, err)
+	archiver, err := NewFileArchiver(dir)
+	assert.NoError(t, err)
+	return archiver, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFileArchiver(t *testing.T) {
+	archiver, teardown := setupArchiver(t)
+	defer teardown()
+	backend := newSnapshotBackend()
+	aggregate := NewAggregate("snapshot", "1")
+	appendDummyEvents(t, backend, aggregate, 0, 3)
+
+	_, err := archiver.Read(aggregate)
+	assert.Equal(t, ErrNotFound, err)
+
+	err = archiver.Archive(aggregate, backend.events[aggregate])
+	assert.NoError(t, err)
+	events, err := archiver.Read(aggregate)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	for i := range events {
+		assert.True(t, proto.Equal(backend.events[aggregate][i], events[i]))
+	}
+}
+
+func TestRetention(t *testing.T) {
+	archiver, teardown := setupArchiver(t)
+	defer teardown()
+	backend := newSnapshotBackend()
+	cache := NewMapCache()
+	now := time.Now()
+	retention := NewRetention(backend, archiver, []RetentionPolicy{
+		{AggregateType: "snapshot", EventType: "Failed", MaxAge: time.Hour},
+		{AggregateType: "snapshot", MaxAge: time.Minute},
+	}, cache)
+
+	active := NewAggregate("snapshot", "active")
+	completed := NewAggregate("snapshot", "completed")
+	appendDummyEvents(t, backend, active, 0, 2)
+	appendDummyEvents(t, backend, completed, 0, 2)
+	appendCompletedEvent(t, backend, completed, now.Add(-2*time.Minute))
+	assert.NoError(t, cache.Put(newSnapshotEntity(completed.Id)))
+	assert.NoError(t, retention.Load())
+
+	// Only the aggregate that has been completed for longer than the max age should be archived.
+	assert.NoError(t, retention.Expire(now))
+	assert.Len(t, backend.events[active], 2)
+	assert.Empty(t, backend.events[completed])
+	cached, err := cache.GetAggregate(completed)
+	assert.NoError(t, err)
+	assert.Nil(t, cached)
+	events, err := archiver.Read(completed)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	// The policy should depend on the completing event
+	failed := NewAggregate("snapshot", "failed")
+	event, err := NewEvent(failed, &DummyEvent{Msg: "failed"})
+	assert.NoError(t, err)
+	event.Type = "Failed"
+	event.Timestamp, _ = ptypes.TimestampProto(now.Add(-2 * time.Minute))
+	event.Hints = &EventHints{Completed: true}
+	assert.NoError(t, backend.Append(event))
+	retention.HandleEvent(event)
+	assert.NoError(t, retention.Expire(now))
+	assert.Len(t, backend.events[failed], 1)
+	assert.NoError(t, retention.Expire(now.Add(time.Hour)))
+	assert.Empty(t, backend.events[failed])
+
+	// Reopened aggregates should no longer be archived
+	reopened := NewAggregate("snapshot", "reopened")
+	appendCompletedEvent(t, backend, reopened, now.Add(-2*time.Minute))
+	event, err = NewEvent(reopened, &DummyEvent{Msg: "reopened"})
+	assert.NoError(t, err)
+	event.Hints = &EventHints{Reopened: true}
+	assert.NoError(t, backend.Append(event))
+	assert.NoError(t, retention.Load())
+	assert.NoError(t, retention.Expire(now))
+	assert.Len(t, backend.events[reopened], 2)
+}
+
+func TestRetention_PrunesIndexes(t *testing.T) {
+	backend := newSnapshotBackend()
+	cache := NewIndexedCache(newTestLRUCache(backend, 1, 0), map[string]IndexFunc{
+		"msgs": func(entity Entity) []string {
+			return entity.(*snapshotEntity).msgs
+		},
+	})
+	retention := NewRetention(backend, nil, []RetentionPolicy{
+		{AggregateType: "snapshot", MaxAge: time.Minute},
+	}, cache)
+	now := time.Now()
+	completed := NewAggregate("snapshot", "completed")
+	appendDummyEvents(t, backend, completed, 0, 1)
+	appendCompletedEvent(t, backend, completed, now.Add(-2*time.Minute))
+	assert.NoError(t, cache.Put(newTestEntity(completed.Id, "0", "completed")))
+
+	// The evicted entity remains indexed until the retention removes it.
+	assert.NoError(t, cache.Put(newTestEntity("other", "0")))
+	assert.Equal(t, []Aggregate{NewAggregate("snapshot", "other")}, cache.List())
+	as, err := cache.Lookup("msgs", "completed")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{completed}, as)
+
+	assert.NoError(t, retention.Load())
+	assert.NoError(t, retention.Expire(now))
+	as, err = cache.Lookup("msgs", "completed")
+	assert.NoError(t, err)
+	assert.Empty(t, as)
+	as, err = cache.Lookup("msgs", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{NewAggregate("snapshot", "other")}, as)
+}
@@ -0,0 +1,272 @@
+package fes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultSnapshotInterval = 100
+)
+
+var (
+	snapshotsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "snapshots",
+		Name:      "created_total",
+		Help:      "Count of created snapshots.",
+	}, []string{"aggregateType"})
+
+	snapshotsRestored = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "snapshots",
+		Name:      "restored_total",
+		Help:      "Count of entities that have been rehydrated from a snapshot.",
+	}, []string{"aggregateType"})
+)
+
+func init() {
+	prometheus.MustRegister(snapshotsCreated, snapshotsRestored)
+}
+
+// Snapshottable is an optional interface for entities that can be stored as a snapshot.
+type Snapshottable interface {
+	Entity
+
+	// SnapshotState returns the current state of the entity.
+	SnapshotState() proto.Message
+
+	// RestoreSnapshotState replaces the state of the entity with the provided state.
+	RestoreSnapshotState(state proto.Message) error
+}
+
+// SnapshotStore is an optional interface for backends that are able to store snapshots of entities.
+type SnapshotStore interface {
+	// SaveSnapshot stores the snapshot, replacing any existing snapshot of the aggregate.
+	SaveSnapshot(snapshot *Snapshot) error
+
+	// GetSnapshot returns the latest snapshot of the aggregate, or ErrNotFound if there is none.
+	GetSnapshot(aggregate Aggregate) (*Snapshot, error)
+}
+
+// RangeReader is an optional interface for backends that are able to read the events of an aggregate from a version
+// onwards. It allows entities to be rehydrated from a snapshot without reading the events that precede the snapshot.
+type RangeReader interface {
+	// GetFrom returns the events of the aggregate (including those of its children, like Get), skipping the first
+	// events up to the version.
+	GetFrom(aggregate Aggregate, version uint64) ([]*Event, error)
+}
+
+// NewSnapshot creates a snapshot of the current state of the entity. The version is the number of events that have been
+// applied to the entity.
+func NewSnapshot(entity Snapshottable, version uint64) (*Snapshot, error) {
+	data, err := ptypes.MarshalAny(entity.SnapshotState())
+	if err != nil {
+		return nil, err
+	}
+	aggregate := entity.Aggregate()
+	return &Snapshot{
+		Aggregate:     &aggregate,
+		Version:       version,
+		Timestamp:     ptypes.TimestampNow(),
+		Data:          data,
+		EntityVersion: entity.Version(),
+	}, nil
+}
+
+// RestoreSnapshot restores the state of the entity to the state in the snapshot.
+func RestoreSnapshot(entity Snapshottable, snapshot *Snapshot) error {
+	state := &ptypes.DynamicAny{}
+	err := ptypes.UnmarshalAny(snapshot.Data, state)
+	if err != nil {
+		return err
+	}
+	return entity.RestoreSnapshotState(state.Message)
+}
+
+// Rehydrate reconstructs the entity from the events of the aggregate in the backend.
+//
+// If both the backend and the entity support snapshots, the entity is restored from the latest snapshot, after which
+// only the events that were appended after the snapshot are applied. If the backend is a RangeReader, the events that
+// precede the snapshot are not read at all. It returns ErrNotFound if the aggregate has no events.
+func Rehydrate(backend Backend, aggregate Aggregate, target Entity) error {
+	_, err := rehydrate(backend, aggregate, target)
+	return err
+}
+
+// rehydrate reconstructs the entity and returns the number of events that the state of the entity is based on.
+func rehydrate(backend Backend, aggregate Aggregate, target Entity) (uint64, error) {
+	store, isStore := backend.(SnapshotStore)
+	entity, isSnapshottable := target.(Snapshottable)
+	if isStore && isSnapshottable {
+		snapshot, err := store.GetSnapshot(aggregate)
+		if err == nil {
+			err = RestoreSnapshot(entity, snapshot)
+			if err == nil {
+				return replayFromSnapshot(backend, snapshot, entity)
+			}
+		}
+		if err != ErrNotFound {
+			// Fallback to a full replay of the events
+			logrus.WithField("aggregate", aggregate.Format()).Warnf("Failed to restore from snapshot: %v", err)
+		}
+	}
+
+	events, err := backend.Get(aggregate)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, ErrNotFound
+	}
+	err = Project(target, events...)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(events)), nil
+}
+
+// replayFromSnapshot applies the events that were appended after the snapshot to the entity restored from the snapshot.
+func replayFromSnapshot(backend Backend, snapshot *Snapshot, entity Snapshottable) (uint64, error) {
+	aggregate := *snapshot.Aggregate
+	var tail []*Event
+	if reader, ok := backend.(RangeReader); ok {
+		events, err := reader.GetFrom(aggregate, snapshot.Version)
+		if err != nil {
+			return 0, err
+		}
+		tail = events
+	} else {
+		events, err := backend.Get(aggregate)
+		if err != nil {
+			return 0, err
+		}
+		if uint64(len(events)) < snapshot.Version {
+			return 0, fmt.Errorf("snapshot of %s at version %d exceeds the %d events of the aggregate",
+				aggregate.Format(), snapshot.Version, len(events))
+		}
+		tail = events[snapshot.Version:]
+	}
+
+	if v, ok := entity.(versioned); ok {
+		v.setVersion(snapshot.EntityVersion)
+	}
+	snapshotsRestored.WithLabelValues(aggregate.Type).Inc()
+	err := Project(entity, tail...)
+	if err != nil {
+		return 0, err
+	}
+	return snapshot.Version + uint64(len(tail)), nil
+}
+
+// Snapshotter periodically stores snapshots of the aggregates in a backend, based on the number of events that have
+// been appended to an aggregate since the last snapshot.
+type Snapshotter struct {
+	backend Backend
+	store   SnapshotStore
+
+	// interval is the number of events after which a new snapshot of the aggregate is created.
+	interval int
+
+	// targets contains the constructors of the snapshottable entities, keyed by the aggregate type.
+	targets map[string]func(id string) Snapshottable
+	counts  map[Aggregate]int
+	lock    sync.Mutex
+}
+
+func NewSnapshotter(backend Backend, store SnapshotStore, interval int,
+	targets map[string]func(id string) Snapshottable) *Snapshotter {
+	if interval <= 0 {
+		interval = DefaultSnapshotInterval
+	}
+	return &Snapshotter{
+		backend:  backend,
+		store:    store,
+		interval: interval,
+		targets:  targets,
+		counts:   map[Aggregate]int{},
+	}
+}
+
+// Run counts the events received over the subscription, creating snapshots once an aggregate exceeds the interval.
+func (s *Snapshotter) Run(ctx context.Context, sub *pubsub.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("Snapshotter: listener stopped.")
+			return
+		case msg := <-sub.Ch:
+			event, ok := msg.(*Event)
+			if !ok {
+				logrus.WithField("msg", msg).Error("Received a malformed message. Ignoring.")
+				continue
+			}
+			err := s.HandleEvent(event)
+			if err != nil {
+				logrus.WithField("event.id", event.Id).Errorf("Failed to snapshot aggregate: %v", err)
+			}
+		}
+	}
+}
+
+// HandleEvent registers the event, and creates a new snapshot of the (parent) aggregate of the event if needed.
+func (s *Snapshotter) HandleEvent(event *Event) error {
+	aggregate := *event.Aggregate
+	if event.Parent != nil {
+		aggregate = *event.Parent
+	}
+	if _, ok := s.targets[aggregate.Type]; !ok {
+		return nil
+	}
+
+	s.lock.Lock()
+	s.counts[aggregate]++
+	count := s.counts[aggregate]
+	if count >= s.interval || event.GetHints().GetCompleted() {
+		delete(s.counts, aggregate)
+	}
+	s.lock.Unlock()
+
+	if event.GetHints().GetCompleted() {
+		// No further events are expected for the aggregate.
+		return nil
+	}
+	if count < s.interval {
+		return nil
+	}
+	return s.Snapshot(aggregate)
+}
+
+// Snapshot creates and stores a new snapshot of the current state of the aggregate.
+func (s *Snapshotter) Snapshot(aggregate Aggregate) error {
+	target, ok := s.targets[aggregate.Type]
+	if !ok {
+		return nil
+	}
+	entity := target(aggregate.Id)
+	version, err := rehydrate(s.backend, aggregate, entity)
+	if err != nil {
+		return err
+	}
+	snapshot, err := NewSnapshot(entity, version)
+	if err != nil {
+		return err
+	}
+	err = s.store.SaveSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	snapshotsCreated.WithLabelValues(aggregate.Type).Inc()
+	logrus.WithFields(logrus.Fields{
+		"aggregate": aggregate.Format(),
+		"version":   version,
+	}).Debug("Created snapshot.")
+	return nil
+}
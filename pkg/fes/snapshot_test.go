@@ -0,0 +1,183 @@
+package fes
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+type snapshotBackend struct {
+	events    map[Aggregate][]*Event
+	snapshots map[Aggregate]*Snapshot
+}
+
+func newSnapshotBackend() *snapshotBackend {
+	return &snapshotBackend{
+		events:    map[Aggregate][]*Event{},
+		snapshots: map[Aggregate]*Snapshot{},
+	}
+}
+
+func (b *snapshotBackend) Append(event *Event) error {
+	b.events[*event.Aggregate] = append(b.events[*event.Aggregate], event)
+	return nil
+}
+
+func (b *snapshotBackend) Get(aggregate Aggregate) ([]*Event, error) {
+	return b.events[aggregate], nil
+}
+
+// rangeBackend is a snapshotBackend that supports range reads, and fails any attempt to read all events.
+type rangeBackend struct {
+	*snapshotBackend
+}
+
+func (b *rangeBackend) Get(aggregate Aggregate) ([]*Event, error) {
+	return nil, fmt.Errorf("unexpected full read of %s", aggregate.Format())
+}
+
+func (b *rangeBackend) GetFrom(aggregate Aggregate, version uint64) ([]*Event, error) {
+	events := b.events[aggregate]
+	if version >= uint64(len(events)) {
+		return nil, nil
+	}
+	return events[version:], nil
+}
+
+func (b *snapshotBackend) List(matcher StringMatcher) ([]Aggregate, error) {
+	var results []Aggregate
+	for aggregate := range b.events {
+		if matcher(aggregate.Type + aggregate.Id) {
+			results = append(results, aggregate)
+		}
+	}
+	return results, nil
+}
+
+func (b *snapshotBackend) SaveSnapshot(snapshot *Snapshot) error {
+	b.snapshots[*snapshot.Aggregate] = snapshot
+	return nil
+}
+
+func (b *snapshotBackend) GetSnapshot(aggregate Aggregate) (*Snapshot, error) {
+	snapshot, ok := b.snapshots[aggregate]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return snapshot, nil
+}
+
+// snapshotEntity keeps track of the messages of the applied events.
+type snapshotEntity struct {
+	*BaseEntity
+	msgs    []string
+	applied int
+}
+
+func newSnapshotEntity(id string) *snapshotEntity {
+	e := &snapshotEntity{}
+	e.BaseEntity = NewBaseEntity(e, NewAggregate("snapshot", id))
+	return e
+}
+
+func (e *snapshotEntity) ApplyEvent(event *Event) error {
+	data, err := UnmarshalEventData(event)
+	if err != nil {
+		return err
+	}
+	e.msgs = append(e.msgs, data.(*DummyEvent).Msg)
+	e.applied++
+	return nil
+}
+
+func (e *snapshotEntity) GenericCopy() Entity {
+	panic("implement me")
+}
+
+func (e *snapshotEntity) SnapshotState() proto.Message {
+	return &DummyEvent{Msg: strings.Join(e.msgs, ",")}
+}
+
+func (e *snapshotEntity) RestoreSnapshotState(state proto.Message) error {
+	e.msgs = strings.Split(state.(*DummyEvent).Msg, ",")
+	return nil
+}
+
+func appendDummyEvents(t *testing.T, backend Backend, aggregate Aggregate, from int, to int) {
+	for i := from; i < to; i++ {
+		event, err := NewEvent(aggregate, &DummyEvent{Msg: fmt.Sprintf("%d", i)})
+		assert.NoError(t, err)
+		assert.NoError(t, backend.Append(event))
+	}
+}
+
+func TestRehydrate_WithoutSnapshot(t *testing.T) {
+	backend := newSnapshotBackend()
+	aggregate := NewAggregate("snapshot", "1")
+	appendDummyEvents(t, backend, aggregate, 0, 3)
+
+	entity := newSnapshotEntity("1")
+	err := Rehydrate(backend, aggregate, entity)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "2"}, entity.msgs)
+	assert.Equal(t, 3, entity.applied)
+	assert.EqualValues(t, 3, entity.Version())
+}
+
+func TestRehydrate_Nonexistent(t *testing.T) {
+	backend := newSnapshotBackend()
+	err := Rehydrate(backend, NewAggregate("snapshot", "1"), newSnapshotEntity("1"))
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestSnapshotter(t *testing.T) {
+	backend := newSnapshotBackend()
+	aggregate := NewAggregate("snapshot", "1")
+	snapshotter := NewSnapshotter(backend, backend, 3, map[string]func(id string) Snapshottable{
+		"snapshot": func(id string) Snapshottable {
+			return newSnapshotEntity(id)
+		},
+	})
+
+	// Snapshot should only be created once the interval is reached
+	appendDummyEvents(t, backend, aggregate, 0, 4)
+	for _, event := range backend.events[aggregate][:2] {
+		assert.NoError(t, snapshotter.HandleEvent(event))
+	}
+	assert.Empty(t, backend.snapshots)
+	assert.NoError(t, snapshotter.HandleEvent(backend.events[aggregate][2]))
+	assert.Len(t, backend.snapshots, 1)
+	assert.EqualValues(t, 4, backend.snapshots[aggregate].Version)
+
+	// Rehydration should only apply the events after the snapshot
+	appendDummyEvents(t, backend, aggregate, 4, 6)
+	entity := newSnapshotEntity("1")
+	err := Rehydrate(backend, aggregate, entity)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5"}, entity.msgs)
+	assert.Equal(t, 2, entity.applied)
+	assert.EqualValues(t, 6, entity.Version())
+}
+
+func TestRehydrate_RangeReader(t *testing.T) {
+	backend := newSnapshotBackend()
+	aggregate := NewAggregate("snapshot", "1")
+	appendDummyEvents(t, backend, aggregate, 0, 4)
+	entity := newSnapshotEntity("1")
+	assert.NoError(t, Project(entity, backend.events[aggregate]...))
+	snapshot, err := NewSnapshot(entity, 4)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.SaveSnapshot(snapshot))
+	appendDummyEvents(t, backend, aggregate, 4, 6)
+
+	// Only the events after the snapshot should be read from the backend.
+	entity = newSnapshotEntity("1")
+	err = Rehydrate(&rangeBackend{backend}, aggregate, entity)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5"}, entity.msgs)
+	assert.Equal(t, 2, entity.applied)
+	assert.EqualValues(t, 6, entity.Version())
+}
@@ -3,7 +3,6 @@ package fes
 import "github.com/fission/fission-workflows/pkg/util/pubsub"
 
 // Entity is a entity that can be updated
-// TODO we need to keep more event-related information (such as current index)
 type Entity interface {
 
 	// Entity-specific
@@ -21,12 +20,40 @@ type Entity interface {
 
 	// Copy copies the actual wrapped object. This is useful to get a snapshot of the state.
 	GenericCopy() Entity
+
+	// Version returns the number of events of the aggregate that have been applied to the entity.
+	//
+	// This is implemented by BaseEntity
+	Version() uint64
 }
 
 type EventAppender interface {
 	Append(event *Event) error
 }
 
+// ConditionalAppender is an optional interface for backends that support optimistic concurrency control.
+type ConditionalAppender interface {
+	// AppendIfVersion appends the event only if the current version of the aggregate of the event equals the expected
+	// version. Otherwise, it returns a ConflictError.
+	//
+	// The version of an aggregate is the number of events of the aggregate in the backend. As the ids of child
+	// aggregates (such as tasks) are only unique within their parent, the version of a child aggregate only includes
+	// the events with the same parent.
+	AppendIfVersion(event *Event, expectedVersion uint64) error
+}
+
+// Deleter is an optional interface for backends that are able to remove aggregates.
+type Deleter interface {
+	// Delete removes all events of the aggregate, including the events of its children, and its snapshot (if any).
+	Delete(aggregate Aggregate) error
+}
+
+// Replayer is an optional interface for backends that are able to iterate over all persisted events.
+type Replayer interface {
+	// Replay passes all persisted events in order of appending to the handler, without publishing them.
+	Replay(handler func(event *Event) error) error
+}
+
 // Backend is a persistent store for events
 type Backend interface {
 	EventAppender
@@ -57,12 +84,23 @@ type CacheReaderWriter interface {
 	CacheWriter
 }
 
+// IndexReader is an optional interface for caches that maintain secondary indexes of the cached entities.
+type IndexReader interface {
+	// Lookup returns the aggregates that are indexed under the key in the index.
+	Lookup(index string, key string) ([]Aggregate, error)
+
+	// LookupRange returns the aggregates that are indexed under a key in the range [from, to) of the index, ordered by
+	// the key. An empty bound leaves that side of the range unbounded.
+	LookupRange(index string, from string, to string) ([]Aggregate, error)
+}
+
 type StringMatcher func(target string) bool
 
 type Notification struct {
 	*pubsub.EmptyMsg
 	Payload   Entity
 	EventType string
+	Event     *Event
 }
 
 func newNotification(entity Entity, event *Event) *Notification {
@@ -70,5 +108,6 @@ func newNotification(entity Entity, event *Event) *Notification {
 		EmptyMsg:  pubsub.NewEmptyMsg(event.Labels(), event.CreatedAt()),
 		Payload:   entity,
 		EventType: event.Type,
+		Event:     event,
 	}
 }
@@ -0,0 +1,89 @@
+package fes
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// DefaultUpcasters contains the upcasters that are applied to events before they are projected.
+var DefaultUpcasters = NewUpcasters()
+
+// Upcaster transforms an event from one schema version to the next schema version of the event type.
+//
+// The upcaster is provided with a copy of the event, which it modifies in place. Besides the data of the event, an
+// upcaster is allowed to change the type of the event, for example when an event has been renamed. The version of the
+// event is incremented after the upcaster has been applied.
+type Upcaster func(event *Event) error
+
+type upcasterKey struct {
+	eventType string
+	version   uint32
+}
+
+// Upcasters is a registry of upcasters, which allows the events written with an older schema version to be replayed
+// after the event data has evolved.
+type Upcasters struct {
+	upcasters map[upcasterKey]Upcaster
+	versions  map[string]uint32
+	lock      sync.RWMutex
+}
+
+func NewUpcasters() *Upcasters {
+	return &Upcasters{
+		upcasters: map[upcasterKey]Upcaster{},
+		versions:  map[string]uint32{},
+	}
+}
+
+// Register adds the upcaster that transforms events of the event type from the provided schema version to the next
+// version. It panics if an upcaster has already been registered for the event type and version.
+func (u *Upcasters) Register(eventType string, version uint32, upcaster Upcaster) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	key := upcasterKey{eventType, version}
+	if _, ok := u.upcasters[key]; ok {
+		panic(fmt.Sprintf("upcaster already registered for event type %s version %d", eventType, version))
+	}
+	u.upcasters[key] = upcaster
+	if u.versions[eventType] <= version {
+		u.versions[eventType] = version + 1
+	}
+}
+
+// Version returns the current schema version of the event type, which is the version following the latest registered
+// upcaster of the event type, or 0 if there are none.
+func (u *Upcasters) Version(eventType string) uint32 {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	return u.versions[eventType]
+}
+
+// Upcast transforms the event into the current schema version by applying the registered upcasters in order. If no
+// upcasters apply to the event, the event itself is returned; otherwise, the upcasted copy of the event is returned.
+func (u *Upcasters) Upcast(event *Event) (*Event, error) {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	upcasted := event
+	for {
+		upcaster, ok := u.upcasters[upcasterKey{upcasted.GetType(), upcasted.GetVersion()}]
+		if !ok {
+			return upcasted, nil
+		}
+		if upcasted == event {
+			upcasted = proto.Clone(event).(*Event)
+		}
+		err := upcaster(upcasted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upcast event %s (%s, version %d): %v", event.GetId(), event.GetType(),
+				upcasted.GetVersion(), err)
+		}
+		upcasted.Version++
+	}
+}
+
+// RegisterUpcaster registers the upcaster in the DefaultUpcasters.
+func RegisterUpcaster(eventType string, version uint32, upcaster Upcaster) {
+	DefaultUpcasters.Register(eventType, version, upcaster)
+}
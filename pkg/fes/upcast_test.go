@@ -0,0 +1,96 @@
+package fes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLegacyEvent creates an event of which the data has been stored as a string value, prior to the introduction of
+// the DummyEvent.
+func newLegacyEvent(t *testing.T, msg string) *Event {
+	event, err := NewEvent(NewAggregate("snapshot", "1"), &wrappers.StringValue{Value: msg})
+	assert.NoError(t, err)
+	event.Type = "LegacyEvent"
+	event.Version = 0
+	return event
+}
+
+// newTestUpcasters registers the upcasters from the legacy event to the DummyEvent (version 0), and from the DummyEvent
+// to the DummyEvent with an uppercase message (version 1).
+func newTestUpcasters() *Upcasters {
+	upcasters := NewUpcasters()
+	upcasters.Register("LegacyEvent", 0, func(event *Event) error {
+		msg := &wrappers.StringValue{}
+		err := ptypes.UnmarshalAny(event.Data, msg)
+		if err != nil {
+			return err
+		}
+		event.Type = "DummyEvent"
+		event.Data, err = ptypes.MarshalAny(&DummyEvent{Msg: msg.Value})
+		return err
+	})
+	upcasters.Register("DummyEvent", 1, func(event *Event) error {
+		msg := &DummyEvent{}
+		err := ptypes.UnmarshalAny(event.Data, msg)
+		if err != nil {
+			return err
+		}
+		event.Data, err = ptypes.MarshalAny(&DummyEvent{Msg: strings.ToUpper(msg.Msg)})
+		return err
+	})
+	return upcasters
+}
+
+func TestUpcasters_Upcast(t *testing.T) {
+	upcasters := newTestUpcasters()
+	assert.EqualValues(t, 1, upcasters.Version("LegacyEvent"))
+	assert.EqualValues(t, 2, upcasters.Version("DummyEvent"))
+
+	event := newLegacyEvent(t, "foo")
+	original := proto.Clone(event)
+	upcasted, err := upcasters.Upcast(event)
+	assert.NoError(t, err)
+	assert.Equal(t, "DummyEvent", upcasted.Type)
+	assert.EqualValues(t, 2, upcasted.Version)
+	msg := &DummyEvent{}
+	assert.NoError(t, ptypes.UnmarshalAny(upcasted.Data, msg))
+	assert.Equal(t, "FOO", msg.Msg)
+
+	// The original event should not have been modified.
+	assert.True(t, proto.Equal(original, event))
+
+	// Events with the current schema version should be returned as is.
+	current, err := upcasters.Upcast(upcasted)
+	assert.NoError(t, err)
+	assert.True(t, current == upcasted)
+}
+
+func TestUpcasters_RegisterDuplicate(t *testing.T) {
+	upcasters := newTestUpcasters()
+	assert.Panics(t, func() {
+		upcasters.Register("DummyEvent", 1, func(event *Event) error { return nil })
+	})
+}
+
+func TestUnmarshalEventData_Upcast(t *testing.T) {
+	RegisterUpcaster("LegacyUnmarshalEvent", 0, func(event *Event) error {
+		msg := &wrappers.StringValue{}
+		err := ptypes.UnmarshalAny(event.Data, msg)
+		if err != nil {
+			return err
+		}
+		event.Data, err = ptypes.MarshalAny(&DummyEvent{Msg: msg.Value})
+		return err
+	})
+	event := newLegacyEvent(t, "foo")
+	event.Type = "LegacyUnmarshalEvent"
+
+	data, err := UnmarshalEventData(event)
+	assert.NoError(t, err)
+	assert.Equal(t, &DummyEvent{Msg: "foo"}, data)
+}
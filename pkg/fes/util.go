@@ -12,7 +12,14 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// versioned is implemented by BaseEntity to allow Project to keep track of the version of the entity.
+type versioned interface {
+	setVersion(version uint64)
+}
+
 // Project is convenience function to apply events to an entity.
+//
+// For each applied event that belongs to the aggregate of the entity, the version of the entity is incremented.
 func Project(entity Entity, events ...*Event) error {
 	if entity == nil {
 		log.WithField("entity", entity).Warn("Empty entity")
@@ -27,6 +34,10 @@ func Project(entity Entity, events ...*Event) error {
 		if err != nil {
 			return err
 		}
+		// Read the version after applying the event, as creation events reset the entity (including its version).
+		if v, ok := entity.(versioned); ok && event.Aggregate != nil && *event.Aggregate == entity.Aggregate() {
+			v.setVersion(entity.Version() + 1)
+		}
 	}
 	return nil
 }
@@ -69,11 +80,13 @@ func NewEvent(aggregate Aggregate, msg proto.Message) (*Event, error) {
 		return nil, err
 	}
 	data = d
+	eventType := events.TypeOf(msg)
 	return &Event{
 		Aggregate: &aggregate,
 		Data:      data,
 		Timestamp: ptypes.TimestampNow(),
-		Type:      events.TypeOf(msg),
+		Type:      eventType,
+		Version:   DefaultUpcasters.Version(eventType),
 	}, nil
 }
 
@@ -89,9 +102,14 @@ func validateAggregate(aggregate Aggregate) error {
 	return nil
 }
 
+// UnmarshalEventData unmarshals the data of the event, after upcasting the event to the current schema version.
 func UnmarshalEventData(event *Event) (interface{}, error) {
+	event, err := DefaultUpcasters.Upcast(event)
+	if err != nil {
+		return nil, err
+	}
 	d := &ptypes.DynamicAny{}
-	err := ptypes.UnmarshalAny(event.Data, d)
+	err = ptypes.UnmarshalAny(event.Data, d)
 	if err != nil {
 		return nil, err
 	}
@@ -84,8 +84,9 @@ func (fp *Proxy) handleRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	wfSpec := &types.WorkflowInvocationSpec{
-		WorkflowId: fnID,
-		Inputs:     inputs,
+		WorkflowId:     fnID,
+		Inputs:         inputs,
+		IdempotencyKey: r.Header.Get(apiserver.HeaderIdempotencyKey),
 	}
 
 	// Temporary: in case of query header 'X-Async' being present, make request async
@@ -1,6 +1,7 @@
 package fission
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,6 +10,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/httpconv"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -52,6 +54,12 @@ func NewFunctionEnv(executor *executor.Client, routerURL string) *FunctionEnv {
 // It returns the TaskInvocationStatus with a completed (FINISHED, FAILED, ABORTED) status.
 // An error is returned only when error occurs outside of the runtime's control.
 func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus, error) {
+	return fe.InvokeContext(context.Background(), spec)
+}
+
+// InvokeContext executes the task in a blocking way, aborting the request to the function once the context is done.
+func (fe *FunctionEnv) InvokeContext(ctx context.Context, spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus,
+	error) {
 	ctxLog := log.WithField("fn", spec.FnRef)
 	if err := validate.TaskInvocationSpec(spec); err != nil {
 		return nil, err
@@ -70,6 +78,15 @@ func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvoca
 		return nil, err
 	}
 
+	// Abort the request once the task has timed out, to avoid hanging on unresponsive functions.
+	timeout, err := ptypes.Duration(spec.GetTimeout())
+	if err == nil && timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, timeout)
+		defer cancelFn()
+	}
+	req = req.WithContext(ctx)
+
 	// Perform request
 	timeStart := time.Now()
 	fnenv.FnActive.WithLabelValues(Name).Inc()
@@ -78,6 +95,15 @@ func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvoca
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return &types.TaskInvocationStatus{
+				Status: types.TaskInvocationStatus_FAILED,
+				Error: &types.Error{
+					Code:    types.ErrorCodeTimeout,
+					Message: fmt.Sprintf("fission function timed out after %v", timeout),
+				},
+			}, nil
+		}
 		return nil, fmt.Errorf("error for reqUrl '%v': %v", url, err)
 	}
 	defer resp.Body.Close()
@@ -15,6 +15,7 @@
 package fnenv
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -77,6 +78,14 @@ type AsyncRuntime interface {
 	Status(asyncID string) (*types.TaskInvocationStatus, error)
 }
 
+// ContextRuntime is an optional interface for runtimes that are able to abort the execution of a task once the
+// provided context is done, such as when the task has timed out.
+type ContextRuntime interface {
+	// InvokeContext executes the task in a blocking way, similar to Runtime.Invoke. Once the context is done, the
+	// runtime aborts the execution of the task and returns.
+	InvokeContext(ctx context.Context, spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus, error)
+}
+
 // Notifier allows signalling of an incoming function invocation.
 //
 // This allows implementations to prepare for those invocations; performing the necessary
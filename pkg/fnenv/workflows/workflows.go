@@ -25,6 +25,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util/labels"
 	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 )
 
@@ -45,20 +46,35 @@ var terminationEvent = []string{
 type Runtime struct {
 	api          *api.Invocation
 	wfiCache     fes.CacheReader
+	wfCache      fes.CacheReader
 	timeout      time.Duration
 	pollInterval time.Duration
 }
 
-func NewRuntime(api *api.Invocation, wfiCache fes.CacheReader) *Runtime {
+// NewRuntime creates the workflow runtime. Like the invocation controller, the runtime waits for an invocation until
+// the timeout of the invocation or its workflow, or otherwise the provided default timeout. If the default timeout is
+// not set, Timeout is used.
+func NewRuntime(api *api.Invocation, wfiCache fes.CacheReader, wfCache fes.CacheReader,
+	timeout time.Duration) *Runtime {
+	if timeout <= 0 {
+		timeout = Timeout
+	}
 	return &Runtime{
 		api:          api,
 		wfiCache:     wfiCache,
+		wfCache:      wfCache,
 		pollInterval: PollInterval,
-		timeout:      Timeout,
+		timeout:      timeout,
 	}
 }
 
 func (rt *Runtime) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus, error) {
+	return rt.InvokeContext(context.Background(), spec)
+}
+
+// InvokeContext invokes the workflow of the task, canceling the invocation once the context is done.
+func (rt *Runtime) InvokeContext(ctx context.Context, spec *types.TaskInvocationSpec) (*types.TaskInvocationStatus,
+	error) {
 	if err := validate.TaskInvocationSpec(spec); err != nil {
 		return nil, err
 	}
@@ -68,8 +84,7 @@ func (rt *Runtime) Invoke(spec *types.TaskInvocationSpec) (*types.TaskInvocation
 		return nil, err
 	}
 
-	// Note: currently context is not supported in the runtime interface, so we use a background context.
-	wfi, err := rt.InvokeWorkflow(context.Background(), wfSpec)
+	wfi, err := rt.InvokeWorkflow(ctx, wfSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +102,7 @@ func (rt *Runtime) InvokeWorkflow(ctx context.Context, spec *types.WorkflowInvoc
 	defer fnenv.FnActive.WithLabelValues(Name).Dec()
 	defer fnenv.FnCount.WithLabelValues(Name).Inc()
 
+	deadline := rt.deadline(spec)
 	wfiID, err := rt.api.Invoke(spec)
 	if err != nil {
 		logrus.WithField("fnenv", Name).Errorf("Failed to invoke workflow: %v", err)
@@ -94,7 +110,7 @@ func (rt *Runtime) InvokeWorkflow(ctx context.Context, spec *types.WorkflowInvoc
 	}
 	logrus.WithField("fnenv", Name).Infof("Invoked workflow: %s", wfiID)
 
-	timedCtx, cancelFn := context.WithTimeout(ctx, rt.timeout)
+	timedCtx, cancelFn := context.WithDeadline(ctx, deadline)
 	defer cancelFn()
 	if pub, ok := rt.wfiCache.(pubsub.Publisher); ok {
 		sub := pub.Subscribe(pubsub.SubscriptionOptions{
@@ -136,6 +152,29 @@ func (rt *Runtime) InvokeWorkflow(ctx context.Context, spec *types.WorkflowInvoc
 	return rt.pollUntilResult(timedCtx, wfiID)
 }
 
+// deadline returns the time at which an invocation with the spec that is created now times out, which is determined
+// in the same way as by the invocation controller.
+func (rt *Runtime) deadline(spec *types.WorkflowInvocationSpec) time.Time {
+	wfi := &types.WorkflowInvocation{
+		Metadata: &types.ObjectMetadata{
+			CreatedAt: ptypes.TimestampNow(),
+		},
+		Spec: spec,
+	}
+	wf := aggregates.NewWorkflow(spec.GetWorkflowId())
+	if rt.wfCache != nil {
+		err := rt.wfCache.Get(wf)
+		if err != nil {
+			logrus.WithField("fnenv", Name).Debugf("Failed to read workflow %v from cache: %v", spec.GetWorkflowId(), err)
+		}
+	}
+	deadline, err := wfi.Deadline(wf.Workflow, rt.timeout)
+	if err != nil {
+		return time.Now().Add(rt.timeout)
+	}
+	return deadline
+}
+
 // checkForResult checks if the invocation with the specified ID has completed yet.
 // If so it will return the workflow invocation object, otherwise it will return nil.
 func (rt *Runtime) checkForResult(wfiID string) *types.WorkflowInvocation {
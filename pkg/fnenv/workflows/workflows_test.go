@@ -14,6 +14,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,6 +26,24 @@ func TestRuntime_InvokeWorkflow_SubTimeout(t *testing.T) {
 	assert.Equal(t, api.ErrInvocationCanceled, err.Error())
 }
 
+func TestRuntime_InvokeWorkflow_WorkflowTimeout(t *testing.T) {
+	runtime, _, _, _ := setup()
+	wfCache := fes.NewMapCache()
+	assert.NoError(t, wfCache.Put(aggregates.NewWorkflow("123", &types.Workflow{
+		Metadata: &types.ObjectMetadata{Id: "123"},
+		Spec: &types.WorkflowSpec{
+			Timeout: ptypes.DurationProto(10 * time.Millisecond),
+		},
+	})))
+	runtime.wfCache = wfCache
+
+	// The timeout of the workflow should take precedence over the default timeout of the runtime.
+	start := time.Now()
+	_, err := runtime.InvokeWorkflow(context.Background(), types.NewWorkflowInvocationSpec("123"))
+	assert.Equal(t, api.ErrInvocationCanceled, err.Error())
+	assert.True(t, time.Since(start) < runtime.timeout)
+}
+
 func TestRuntime_InvokeWorkflow_PollTimeout(t *testing.T) {
 	runtime, _, _, _ := setup()
 	runtime.wfiCache = fes.NewMapCache() // ensure that cache does not support pubsub
@@ -157,7 +176,6 @@ func setup() (*Runtime, *api.Invocation, *mem.Backend, fes.CacheReaderWriter) {
 	cache := fes.NewSubscribedCache(context.Background(), fes.NewMapCache(), func() fes.Entity {
 		return aggregates.NewWorkflowInvocation("")
 	}, backend.Subscribe())
-	runtime := NewRuntime(invocationAPI, cache)
-	runtime.timeout = 5 * time.Second
+	runtime := NewRuntime(invocationAPI, cache, nil, 5*time.Second)
 	return runtime, invocationAPI, backend, cache
 }
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
@@ -67,11 +70,19 @@ func parseWorkflow(def *workflowSpec) (*types.WorkflowSpec, error) {
 		tasks[id] = p
 	}
 
-	return &types.WorkflowSpec{
+	spec := &types.WorkflowSpec{
 		ApiVersion: def.APIVersion,
 		OutputTask: def.Output,
 		Tasks:      tasks,
-	}, nil
+	}
+	if len(def.Timeout) > 0 {
+		timeout, err := time.ParseDuration(def.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout '%s': %v", def.Timeout, err)
+		}
+		spec.Timeout = ptypes.DurationProto(timeout)
+	}
+	return spec, nil
 }
 
 func parseTask(t *taskSpec) (*types.TaskSpec, error) {
@@ -79,6 +90,15 @@ func parseTask(t *taskSpec) (*types.TaskSpec, error) {
 	for _, dep := range t.Requires {
 		deps[dep] = &types.TaskDependencyParameters{}
 	}
+	for _, dep := range t.After {
+		deps[dep] = &types.TaskDependencyParameters{
+			Type: types.TaskDependencyParameters_CONTROL,
+		}
+	}
+	await := int32(len(deps))
+	if t.Await > 0 {
+		await = t.Await
+	}
 
 	inputs, err := parseInputs(t.Inputs)
 	if err != nil {
@@ -90,16 +110,112 @@ func parseTask(t *taskSpec) (*types.TaskSpec, error) {
 		fn = defaultFunctionRef
 	}
 
+	retry, err := parseRetryPolicy(t.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	onFailure, err := parseFailurePolicy(t.OnFailure)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &types.TaskSpec{
 		FunctionRef: fn,
 		Requires:    deps,
-		Await:       int32(len(deps)),
+		Await:       await,
 		Inputs:      inputs,
+		Retry:       retry,
+		OnFailure:   onFailure,
+	}
+	if t.When != nil {
+		when, err := parseInput(t.When)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task condition '%v': %v", t.When, err)
+		}
+		result.When = when
+	}
+	if len(t.SkipPolicy) > 0 {
+		policy, ok := types.TaskSpec_SkipPolicy_value[strings.ToUpper(t.SkipPolicy)]
+		if !ok {
+			return nil, fmt.Errorf("unknown skipPolicy '%s'", t.SkipPolicy)
+		}
+		result.SkipPolicy = types.TaskSpec_SkipPolicy(policy)
+	}
+	if len(t.Timeout) > 0 {
+		timeout, err := time.ParseDuration(t.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task timeout '%s': %v", t.Timeout, err)
+		}
+		result.Timeout = ptypes.DurationProto(timeout)
 	}
 
 	return result, nil
 }
 
+func parseRetryPolicy(r *retrySpec) (*types.RetryPolicy, error) {
+	if r == nil {
+		return nil, nil
+	}
+	policy := &types.RetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		RetryOn:     r.RetryOn,
+	}
+	if len(r.Backoff) > 0 {
+		backoff, err := time.ParseDuration(r.Backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry backoff '%s': %v", r.Backoff, err)
+		}
+		policy.Backoff = ptypes.DurationProto(backoff)
+	}
+	if len(r.MaxBackoff) > 0 {
+		maxBackoff, err := time.ParseDuration(r.MaxBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry maxBackoff '%s': %v", r.MaxBackoff, err)
+		}
+		policy.MaxBackoff = ptypes.DurationProto(maxBackoff)
+	}
+	return policy, nil
+}
+
+// parseFailurePolicy parses the failure policy of a task, which is either the action (e.g. continue), or a map
+// containing the action and/or the fallback task.
+func parseFailurePolicy(i interface{}) (*types.FailurePolicy, error) {
+	if i == nil {
+		return nil, nil
+	}
+
+	policy := &types.FailurePolicy{}
+	var action string
+	switch v := i.(type) {
+	case string:
+		action = v
+	case map[interface{}]interface{}:
+		return parseFailurePolicy(convertInterfaceMaps(v))
+	case map[string]interface{}:
+		for key, val := range v {
+			switch key {
+			case "action":
+				action = fmt.Sprintf("%v", val)
+			case "fallback":
+				policy.Fallback = fmt.Sprintf("%v", val)
+			default:
+				return nil, fmt.Errorf("unknown onFailure field '%s'", key)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid onFailure '%v'", i)
+	}
+	if len(action) > 0 {
+		a, ok := types.FailurePolicy_Action_value[strings.ToUpper(action)]
+		if !ok {
+			return nil, fmt.Errorf("unknown onFailure action '%s'", action)
+		}
+		policy.Action = types.FailurePolicy_Action(a)
+	}
+	return policy, nil
+}
+
 // parseInputs parses the inputs of a task. This is typically a map[interface{}]interface{}.
 func parseInputs(i interface{}) (map[string]*types.TypedValue, error) {
 	if i == nil {
@@ -232,12 +348,27 @@ type workflowSpec struct {
 	APIVersion  string
 	Description string
 	Output      string
+	Timeout     string // e.g. 1h30m
 	Tasks       map[string]*taskSpec
 }
 
 type taskSpec struct {
-	ID       string
-	Run      string
-	Inputs   interface{}
-	Requires []string
+	ID         string
+	Run        string
+	Inputs     interface{}
+	Requires   []string
+	After      []string // Control dependencies, which only determine the order of the tasks
+	Await      int32    // e.g. 1 to run the task once the first of its requirements has completed
+	Retry      *retrySpec
+	Timeout    string      // e.g. 30s
+	OnFailure  interface{} `yaml:"onFailure"` // e.g. continue, or {fallback: taskId}
+	When       interface{} // e.g. "{ $.Tasks.foo.Output > 0 }"
+	SkipPolicy string      `yaml:"skipPolicy"` // e.g. any, all or none
+}
+
+type retrySpec struct {
+	MaxAttempts int32    `yaml:"maxAttempts"`
+	Backoff     string   // e.g. 100ms
+	MaxBackoff  string   `yaml:"maxBackoff"`
+	RetryOn     []string `yaml:"retryOn"`
 }
@@ -3,10 +3,13 @@ package yaml
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"fmt"
 
+	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -160,3 +163,140 @@ tasks:
 	assert.NoError(t, err)
 	assert.NotNil(t, wf)
 }
+
+func TestParseTimeout(t *testing.T) {
+	data := `
+output: foo
+timeout: 1h30m
+tasks:
+  foo:
+    run: noop
+    timeout: 30s
+`
+	wf, err := Parse(strings.NewReader(strings.TrimSpace(data)))
+	assert.NoError(t, err)
+	timeout, err := ptypes.Duration(wf.GetTimeout())
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, timeout)
+	taskTimeout, err := ptypes.Duration(wf.Tasks["foo"].GetTimeout())
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, taskTimeout)
+
+	_, err = Parse(strings.NewReader(strings.Replace(data, "1h30m", "forever", 1)))
+	assert.Error(t, err)
+	_, err = Parse(strings.NewReader(strings.Replace(data, "30s", "forever", 1)))
+	assert.Error(t, err)
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	data := `
+output: foo
+tasks:
+  foo:
+    run: noop
+    retry:
+      maxAttempts: 3
+      backoff: 100ms
+      maxBackoff: 1s
+      retryOn:
+      - runtime
+`
+	wf, err := Parse(strings.NewReader(strings.TrimSpace(data)))
+	assert.NoError(t, err)
+	retry := wf.Tasks["foo"].GetRetry()
+	assert.EqualValues(t, 3, retry.GetMaxAttempts())
+	assert.Equal(t, []string{"runtime"}, retry.GetRetryOn())
+	backoff, err := ptypes.Duration(retry.GetBackoff())
+	assert.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, backoff)
+	maxBackoff, err := ptypes.Duration(retry.GetMaxBackoff())
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, maxBackoff)
+
+	_, err = Parse(strings.NewReader(strings.Replace(data, "100ms", "soon", 1)))
+	assert.Error(t, err)
+}
+
+func TestParseFailurePolicy(t *testing.T) {
+	data := `
+output: bar
+tasks:
+  foo:
+    run: noop
+    onFailure: continue
+  bar:
+    run: noop
+    requires:
+    - foo
+    onFailure:
+      fallback: baz
+  baz:
+    run: noop
+    requires:
+    - bar
+`
+	wf, err := Parse(strings.NewReader(strings.TrimSpace(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, types.FailurePolicy_CONTINUE, wf.Tasks["foo"].GetOnFailure().GetAction())
+	assert.Equal(t, types.FailurePolicy_ABORT, wf.Tasks["bar"].GetOnFailure().GetAction())
+	assert.Equal(t, "baz", wf.Tasks["bar"].GetOnFailure().GetFallback())
+	assert.Nil(t, wf.Tasks["baz"].GetOnFailure())
+
+	_, err = Parse(strings.NewReader(strings.Replace(data, "continue", "ignore", 1)))
+	assert.Error(t, err)
+}
+
+func TestParseCondition(t *testing.T) {
+	data := `
+output: bar
+tasks:
+  foo:
+    run: noop
+    when: "{ $.Invocation.Inputs.default > 0 }"
+  bar:
+    run: noop
+    requires:
+    - foo
+    when: false
+    skipPolicy: none
+`
+	wf, err := Parse(strings.NewReader(strings.TrimSpace(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, typedvalues.TypeExpression, wf.Tasks["foo"].GetWhen().GetType())
+	assert.Equal(t, types.TaskSpec_ANY, wf.Tasks["foo"].GetSkipPolicy())
+	when, err := typedvalues.FormatBool(wf.Tasks["bar"].GetWhen())
+	assert.NoError(t, err)
+	assert.False(t, when)
+	assert.Equal(t, types.TaskSpec_NONE, wf.Tasks["bar"].GetSkipPolicy())
+
+	_, err = Parse(strings.NewReader(strings.Replace(data, "none", "some", 1)))
+	assert.Error(t, err)
+}
+
+func TestParseDependencies(t *testing.T) {
+	data := `
+output: baz
+tasks:
+  foo:
+    run: noop
+  bar:
+    run: noop
+  baz:
+    run: noop
+    requires:
+    - foo
+    - bar
+    after:
+    - setup
+    await: 1
+  setup:
+    run: noop
+`
+	wf, err := Parse(strings.NewReader(strings.TrimSpace(data)))
+	assert.NoError(t, err)
+	task := wf.Tasks["baz"]
+	assert.Equal(t, int32(1), task.GetAwait())
+	assert.Len(t, task.GetRequires(), 3)
+	assert.Equal(t, types.TaskDependencyParameters_DATA, task.GetRequires()["foo"].GetType())
+	assert.Equal(t, types.TaskDependencyParameters_CONTROL, task.GetRequires()["setup"].GetType())
+}
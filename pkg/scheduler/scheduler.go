@@ -55,14 +55,27 @@ func (ws *WorkflowScheduler) Evaluate(request *ScheduleRequest) (*Schedule, erro
 	ctxLog.Debug("Scheduler evaluating...")
 	cwf := types.GetTaskContainers(request.Workflow, request.Invocation)
 
+	// Map the fallback tasks to the tasks of which they handle the failure
+	fallbacks := map[string]string{}
+	for id, t := range cwf {
+		if fallback := t.Task.GetSpec().GetOnFailure().GetFallback(); len(fallback) > 0 {
+			if _, ok := cwf[fallback]; ok {
+				fallbacks[fallback] = id
+			}
+		}
+	}
+
 	// Fill open tasks
+	// Tasks that are in progress or that will be retried are included, as their dependents should not be scheduled yet.
 	openTasks := map[string]*types.TaskInstance{}
 	for id, t := range cwf {
-		if t.Invocation == nil || t.Invocation.Status.Status == types.TaskInvocationStatus_UNKNOWN {
+		if t.Invocation == nil || t.Invocation.Status.Status == types.TaskInvocationStatus_UNKNOWN ||
+			t.Invocation.Status.Status == types.TaskInvocationStatus_IN_PROGRESS ||
+			t.Task.GetSpec().GetRetry().ShouldRetry(t.Invocation.GetStatus()) {
 			openTasks[id] = t
 			continue
 		}
-		if t.Invocation.Status.Status == types.TaskInvocationStatus_FAILED {
+		if t.Invocation.Status.Status == types.TaskInvocationStatus_FAILED && !handlesFailure(t, cwf) {
 
 			msg := fmt.Sprintf("Task '%v' failed", t.Invocation.ID())
 			if err := t.Invocation.GetStatus().GetError(); err != nil {
@@ -85,11 +98,48 @@ func (ws *WorkflowScheduler) Evaluate(request *ScheduleRequest) (*Schedule, erro
 	}
 
 	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
+	horizon := graph.Ready(depGraph)
 
 	// Determine schedule nodes
+	now := time.Now()
+	outputTask := request.Workflow.GetSpec().GetOutputTask()
+	invokes := map[string]*Action{}
+	skips := map[string]bool{}
 	for _, node := range horizon {
 		taskDef := node.(*graph.TaskInstanceNode)
+		if taskDef.Invocation != nil && taskDef.Invocation.Status.Status == types.TaskInvocationStatus_IN_PROGRESS {
+			// The task has already been started.
+			continue
+		}
+		if waitsOnFallback(taskDef.TaskInstance, cwf, openTasks) {
+			continue
+		}
+		if taskID, ok := fallbacks[taskDef.Task.ID()]; ok &&
+			cwf[taskID].Invocation.GetStatus().GetStatus() != types.TaskInvocationStatus_FAILED {
+			// The task is the fallback of a task that did not fail.
+			skips[taskDef.Task.ID()] = true
+			continue
+		}
+		if skippedByDependencies(taskDef.TaskInstance, cwf, fallbacks) {
+			// The task is skipped, because of the skipped dependencies.
+			skips[taskDef.Task.ID()] = true
+			continue
+		}
+		if taskDef.Invocation != nil && taskDef.Invocation.Status.Status == types.TaskInvocationStatus_FAILED {
+			// The task failed, but will be retried once its backoff has passed.
+			retryAt := taskDef.Task.GetSpec().GetRetry().RetryAt(taskDef.Invocation.GetStatus())
+			if now.Before(retryAt) {
+				ctxLog.WithField("task", taskDef.Task.ID()).Debugf("Delaying retry of task until %v", retryAt)
+				continue
+			}
+		}
+
+		// In case of a partial join, the data dependencies that are still open have lost the race.
+		for _, loser := range losingDependencies(taskDef.TaskInstance, openTasks, outputTask) {
+			ctxLog.WithField("task", loser).Debugf("Skipping task that lost the race to '%v'", taskDef.Task.ID())
+			skips[loser] = true
+		}
+
 		// Fetch input
 		// TODO might be Status.Inputs instead of Spec.Inputs
 		inputs := taskDef.Task.Spec.Inputs
@@ -98,12 +148,117 @@ func (ws *WorkflowScheduler) Evaluate(request *ScheduleRequest) (*Schedule, erro
 			Inputs: inputs,
 		})
 
-		schedule.Actions = append(schedule.Actions, &Action{
+		invokes[taskDef.Task.ID()] = &Action{
 			Type:    ActionType_INVOKE_TASK,
 			Payload: invokeTaskAction,
+		}
+	}
+	for id, action := range invokes {
+		if !skips[id] {
+			schedule.Actions = append(schedule.Actions, action)
+		}
+	}
+	for id := range skips {
+		skipTaskAction, _ := ptypes.MarshalAny(&SkipTaskAction{
+			Id: id,
+		})
+		schedule.Actions = append(schedule.Actions, &Action{
+			Type:    ActionType_SKIP_TASK,
+			Payload: skipTaskAction,
 		})
 	}
 
 	ctxLog.WithField("schedule", len(schedule.Actions)).Info("Determined schedule")
 	return schedule, nil
 }
+
+// handlesFailure returns true if the failure of the task is handled by its failure policy, rather than aborting the
+// invocation.
+func handlesFailure(t *types.TaskInstance, tasks map[string]*types.TaskInstance) bool {
+	policy := t.Task.GetSpec().GetOnFailure()
+	if fallback := policy.GetFallback(); len(fallback) > 0 {
+		_, ok := tasks[fallback]
+		return ok
+	}
+	return policy.HandlesFailure()
+}
+
+// waitsOnFallback returns true if the task depends on a failed task of which the fallback task has not completed yet.
+func waitsOnFallback(t *types.TaskInstance, tasks map[string]*types.TaskInstance,
+	openTasks map[string]*types.TaskInstance) bool {
+	for dep := range t.Task.GetSpec().GetRequires() {
+		depTask, ok := tasks[dep]
+		if !ok || depTask.Invocation.GetStatus().GetStatus() != types.TaskInvocationStatus_FAILED {
+			continue
+		}
+		fallback := depTask.Task.GetSpec().GetOnFailure().GetFallback()
+		if _, ok := openTasks[fallback]; ok && fallback != t.Task.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedByDependencies returns true if the task should be skipped according to its skip policy, given the skipped
+// data dependencies of the task. Fallback tasks that were skipped, because the task they guard did not fail, are not
+// taken into account.
+func skippedByDependencies(t *types.TaskInstance, tasks map[string]*types.TaskInstance,
+	fallbacks map[string]string) bool {
+	var deps, skipped int
+	for dep, params := range t.Task.GetSpec().GetRequires() {
+		depTask, ok := tasks[dep]
+		if !ok || params.GetType() == types.TaskDependencyParameters_CONTROL {
+			continue
+		}
+		if _, ok := fallbacks[dep]; ok {
+			continue
+		}
+		deps++
+		if depTask.Invocation.GetStatus().GetStatus() == types.TaskInvocationStatus_SKIPPED {
+			skipped++
+		}
+	}
+	switch t.Task.GetSpec().GetSkipPolicy() {
+	case types.TaskSpec_ANY:
+		return skipped > 0
+	case types.TaskSpec_ALL:
+		return skipped > 0 && skipped == deps
+	default:
+		return false
+	}
+}
+
+// losingDependencies returns the open tasks that lost the race to a task that awaits only a part of its data
+// dependencies. These are the open data dependencies of the task, and the dynamic tasks of its data dependencies,
+// unless other tasks still depend on them.
+func losingDependencies(t *types.TaskInstance, openTasks map[string]*types.TaskInstance, outputTask string) []string {
+	requires := t.Task.GetSpec().GetRequires()
+	isRacing := func(id string) bool {
+		params, ok := requires[id]
+		if !ok || params.GetType() != types.TaskDependencyParameters_DATA || id == outputTask {
+			return false
+		}
+		for openID, openTask := range openTasks {
+			if openID == t.Task.ID() {
+				continue
+			}
+			if params, ok := openTask.Task.GetSpec().GetRequires()[id]; ok &&
+				params.GetType() != types.TaskDependencyParameters_DYNAMIC_OUTPUT {
+				return false
+			}
+		}
+		return true
+	}
+
+	var losers []string
+	for id, openTask := range openTasks {
+		if _, ok := requires[id]; ok && isRacing(id) {
+			losers = append(losers, id)
+			continue
+		}
+		if parent, ok := openTask.Task.GetSpec().Parent(); ok && isRacing(parent) {
+			losers = append(losers, id)
+		}
+	}
+	return losers
+}
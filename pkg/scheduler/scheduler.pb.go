@@ -14,6 +14,7 @@ It has these top-level messages:
 	AbortAction
 	InvokeTaskAction
 	NotifyTaskAction
+	SkipTaskAction
 */
 package scheduler
 
@@ -54,17 +55,21 @@ const (
 	ActionType_ABORT ActionType = 1
 	// Notify a task
 	ActionType_NOTIFY_TASK ActionType = 2
+	// Skips a task that should not be run
+	ActionType_SKIP_TASK ActionType = 3
 )
 
 var ActionType_name = map[int32]string{
 	0: "INVOKE_TASK",
 	1: "ABORT",
 	2: "NOTIFY_TASK",
+	3: "SKIP_TASK",
 }
 var ActionType_value = map[string]int32{
 	"INVOKE_TASK": 0,
 	"ABORT":       1,
 	"NOTIFY_TASK": 2,
+	"SKIP_TASK":   3,
 }
 
 func (x ActionType) String() string {
@@ -219,6 +224,23 @@ func (m *NotifyTaskAction) GetExpectedAt() *google_protobuf.Timestamp {
 	return nil
 }
 
+type SkipTaskAction struct {
+	// Id of the task in the workflow
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *SkipTaskAction) Reset()                    { *m = SkipTaskAction{} }
+func (m *SkipTaskAction) String() string            { return proto.CompactTextString(m) }
+func (*SkipTaskAction) ProtoMessage()               {}
+func (*SkipTaskAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SkipTaskAction) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Schedule)(nil), "fission.workflows.scheduler.Schedule")
 	proto.RegisterType((*ScheduleRequest)(nil), "fission.workflows.scheduler.ScheduleRequest")
@@ -226,6 +248,7 @@ func init() {
 	proto.RegisterType((*AbortAction)(nil), "fission.workflows.scheduler.AbortAction")
 	proto.RegisterType((*InvokeTaskAction)(nil), "fission.workflows.scheduler.InvokeTaskAction")
 	proto.RegisterType((*NotifyTaskAction)(nil), "fission.workflows.scheduler.NotifyTaskAction")
+	proto.RegisterType((*SkipTaskAction)(nil), "fission.workflows.scheduler.SkipTaskAction")
 	proto.RegisterEnum("fission.workflows.scheduler.ActionType", ActionType_name, ActionType_value)
 }
 
@@ -304,39 +327,40 @@ var _Scheduler_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/scheduler/scheduler.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 537 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x5d, 0x6f, 0xd3, 0x30,
-	0x14, 0x5d, 0xd2, 0xad, 0x6b, 0x6f, 0xd0, 0x56, 0x59, 0x08, 0x95, 0x22, 0x44, 0xc9, 0x34, 0x51,
-	0xf1, 0xe1, 0x4a, 0xe5, 0x65, 0x2d, 0xe2, 0x21, 0x93, 0x86, 0x54, 0x55, 0x6a, 0x85, 0x17, 0x0d,
-	0xc1, 0xc3, 0x90, 0x9b, 0xb8, 0x5d, 0xd4, 0x36, 0x0e, 0xb1, 0xb3, 0x91, 0x1f, 0xc3, 0x33, 0xbf,
-	0x88, 0xff, 0x83, 0x9c, 0x38, 0x6d, 0x29, 0xd0, 0xf5, 0x25, 0xf1, 0xc7, 0x39, 0xc7, 0xe7, 0x9e,
-	0x7b, 0xe1, 0x69, 0x34, 0x9b, 0xb6, 0x85, 0x77, 0xc3, 0xfc, 0x64, 0xce, 0xe2, 0xd5, 0x0a, 0x47,
-	0x31, 0x97, 0x1c, 0x3d, 0x99, 0x04, 0x42, 0x04, 0x3c, 0xc4, 0x77, 0x3c, 0x9e, 0x4d, 0xe6, 0xfc,
-	0x4e, 0xe0, 0x25, 0xa4, 0xd1, 0x9b, 0x06, 0xf2, 0x26, 0x19, 0x63, 0x8f, 0x2f, 0xda, 0x1a, 0x57,
-	0xfc, 0xdf, 0x2c, 0xf1, 0x6d, 0xf5, 0x80, 0x4c, 0x23, 0x26, 0xf2, 0x6f, 0x2e, 0xdc, 0x78, 0x36,
-	0xe5, 0x7c, 0x3a, 0x67, 0xed, 0x6c, 0x37, 0x4e, 0x26, 0x6d, 0x19, 0x2c, 0x98, 0x90, 0x74, 0x11,
-	0x69, 0xc0, 0xe3, 0x4d, 0x00, 0x0d, 0xd3, 0xfc, 0xca, 0xfe, 0x69, 0x40, 0xe5, 0x52, 0xbb, 0x40,
-	0x36, 0x3c, 0x08, 0xc2, 0x5b, 0xee, 0x51, 0x19, 0xf0, 0xb0, 0xef, 0xd7, 0x8d, 0xa6, 0xd1, 0xaa,
-	0x92, 0x3f, 0xce, 0xd0, 0x19, 0x54, 0xbd, 0x98, 0x51, 0xc9, 0x7c, 0x47, 0xd6, 0xcd, 0xa6, 0xd1,
-	0xb2, 0x3a, 0x0d, 0x9c, 0xeb, 0xe3, 0x42, 0x1f, 0xbb, 0x85, 0x01, 0xb2, 0x02, 0xa3, 0xf7, 0x70,
-	0x48, 0x3d, 0xa5, 0x22, 0xea, 0xa5, 0x66, 0xa9, 0x65, 0x75, 0x4e, 0xf0, 0x96, 0x44, 0xb0, 0x93,
-	0x61, 0x49, 0xc1, 0xb1, 0x7f, 0x18, 0x70, 0x5c, 0x38, 0x25, 0xec, 0x5b, 0xc2, 0x84, 0x92, 0xac,
-	0x14, 0xd4, 0xcc, 0xac, 0xd5, 0x79, 0xfe, 0x0f, 0xcd, 0x3c, 0xab, 0x4f, 0x7a, 0x4f, 0x96, 0x14,
-	0x34, 0x00, 0x58, 0xd5, 0xa6, 0x8b, 0x79, 0x75, 0xaf, 0x40, 0x7f, 0x49, 0x21, 0x6b, 0x74, 0x3b,
-	0x81, 0x72, 0x6e, 0x19, 0xbd, 0x83, 0x7d, 0xc5, 0xc8, 0x1c, 0x1d, 0x75, 0x5e, 0xec, 0x50, 0xa5,
-	0x9b, 0x46, 0x8c, 0x64, 0x24, 0x84, 0xe1, 0x30, 0xa2, 0xe9, 0x9c, 0x53, 0xbf, 0xbe, 0x9f, 0x19,
-	0x7a, 0xf8, 0x57, 0xba, 0x4e, 0x98, 0x92, 0x02, 0x64, 0x9f, 0x82, 0xe5, 0x8c, 0x79, 0x2c, 0xf5,
-	0xdb, 0x8f, 0xa0, 0x1c, 0x33, 0x2a, 0x78, 0xa8, 0x9b, 0xa7, 0x77, 0xf6, 0x2f, 0x03, 0x6a, 0xca,
-	0xf8, 0x8c, 0xb9, 0x54, 0xcc, 0x34, 0xf8, 0x08, 0xcc, 0xa0, 0xe8, 0xb2, 0x19, 0xf8, 0xe8, 0x23,
-	0x94, 0x83, 0x30, 0x4a, 0xa4, 0xa8, 0x9b, 0x59, 0x83, 0xba, 0x5b, 0xad, 0x6f, 0xca, 0xe1, 0x7e,
-	0xc6, 0xbd, 0x08, 0x65, 0x9c, 0x12, 0x2d, 0xd4, 0xb8, 0x06, 0x6b, 0xed, 0x18, 0xd5, 0xa0, 0x34,
-	0x63, 0xa9, 0x7e, 0x52, 0x2d, 0x51, 0x17, 0x0e, 0x6e, 0xe9, 0x3c, 0x61, 0x3a, 0xfe, 0x93, 0xff,
-	0xc6, 0xaf, 0x32, 0xf2, 0xaf, 0x14, 0x94, 0xe4, 0x8c, 0x9e, 0x79, 0x66, 0xd8, 0xd7, 0x50, 0x1b,
-	0x72, 0x19, 0x4c, 0xd2, 0x2d, 0x65, 0xf5, 0x00, 0xd8, 0xf7, 0x88, 0x79, 0xbb, 0xce, 0xec, 0x1a,
-	0xfa, 0x65, 0x17, 0x60, 0xd5, 0x22, 0x74, 0x0c, 0x56, 0x7f, 0x78, 0x35, 0x1a, 0x5c, 0x7c, 0x75,
-	0x9d, 0xcb, 0x41, 0x6d, 0x0f, 0x55, 0xe1, 0xc0, 0x39, 0x1f, 0x11, 0xb7, 0x66, 0xa8, 0xbb, 0xe1,
-	0xc8, 0xed, 0x7f, 0xf8, 0x9c, 0xdf, 0x99, 0x9d, 0x10, 0xaa, 0xc5, 0xbc, 0xc6, 0x88, 0x42, 0x85,
-	0x29, 0xd7, 0x54, 0x32, 0xf4, 0x7a, 0x6b, 0xac, 0x1b, 0x33, 0xde, 0x38, 0xdd, 0x09, 0x6d, 0xef,
-	0x9d, 0x5b, 0x5f, 0xaa, 0xcb, 0xf3, 0x71, 0x39, 0xab, 0xeb, 0xed, 0xef, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x66, 0xe2, 0x84, 0x09, 0x94, 0x04, 0x00, 0x00,
+	// 554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5f, 0x6f, 0xd3, 0x3e,
+	0x14, 0x5d, 0xd2, 0xad, 0x6b, 0x6e, 0x7e, 0xbf, 0xae, 0xb2, 0x10, 0x2a, 0x45, 0x88, 0x92, 0x69,
+	0xa2, 0xe2, 0x4f, 0x2a, 0x95, 0x97, 0xad, 0x88, 0x87, 0x4c, 0x1a, 0x22, 0xaa, 0xd4, 0x42, 0x1a,
+	0x0d, 0xc1, 0xc3, 0x90, 0x9b, 0xb8, 0x9d, 0x95, 0x36, 0x0e, 0xb1, 0xb3, 0x91, 0x0f, 0xc3, 0x33,
+	0x9f, 0x88, 0xef, 0x83, 0x92, 0x38, 0x6d, 0x29, 0xac, 0xf4, 0xa5, 0xf5, 0xb5, 0xcf, 0x39, 0x3e,
+	0xf7, 0x5c, 0x07, 0x1e, 0x45, 0xc1, 0xac, 0xcb, 0xbd, 0x6b, 0xe2, 0x27, 0x73, 0x12, 0xaf, 0x56,
+	0x66, 0x14, 0x33, 0xc1, 0xd0, 0xc3, 0x29, 0xe5, 0x9c, 0xb2, 0xd0, 0xbc, 0x65, 0x71, 0x30, 0x9d,
+	0xb3, 0x5b, 0x6e, 0x2e, 0x21, 0xad, 0xfe, 0x8c, 0x8a, 0xeb, 0x64, 0x62, 0x7a, 0x6c, 0xd1, 0x95,
+	0xb8, 0xf2, 0xff, 0xe5, 0x12, 0xdf, 0xcd, 0x2e, 0x10, 0x69, 0x44, 0x78, 0xf1, 0x5b, 0x08, 0xb7,
+	0x1e, 0xcf, 0x18, 0x9b, 0xcd, 0x49, 0x37, 0xaf, 0x26, 0xc9, 0xb4, 0x2b, 0xe8, 0x82, 0x70, 0x81,
+	0x17, 0x91, 0x04, 0x3c, 0xd8, 0x04, 0xe0, 0x30, 0x2d, 0x8e, 0x8c, 0x1f, 0x0a, 0xd4, 0xc6, 0xd2,
+	0x05, 0x32, 0xe0, 0x3f, 0x1a, 0xde, 0x30, 0x0f, 0x0b, 0xca, 0x42, 0xdb, 0x6f, 0x2a, 0x6d, 0xa5,
+	0xa3, 0x39, 0xbf, 0xed, 0xa1, 0x53, 0xd0, 0xbc, 0x98, 0x60, 0x41, 0x7c, 0x4b, 0x34, 0xd5, 0xb6,
+	0xd2, 0xd1, 0x7b, 0x2d, 0xb3, 0xd0, 0x37, 0x4b, 0x7d, 0xd3, 0x2d, 0x0d, 0x38, 0x2b, 0x30, 0x7a,
+	0x03, 0x87, 0xd8, 0xcb, 0x54, 0x78, 0xb3, 0xd2, 0xae, 0x74, 0xf4, 0xde, 0xb1, 0xb9, 0x25, 0x11,
+	0xd3, 0xca, 0xb1, 0x4e, 0xc9, 0x31, 0xbe, 0x2b, 0x70, 0x54, 0x3a, 0x75, 0xc8, 0xd7, 0x84, 0xf0,
+	0x4c, 0xb2, 0x56, 0x52, 0x73, 0xb3, 0x7a, 0xef, 0xc9, 0x5f, 0x34, 0x8b, 0xac, 0x3e, 0xca, 0xda,
+	0x59, 0x52, 0xd0, 0x00, 0x60, 0xd5, 0x9b, 0x6c, 0xe6, 0xf9, 0x3f, 0x05, 0xec, 0x25, 0xc5, 0x59,
+	0xa3, 0x1b, 0x09, 0x54, 0x0b, 0xcb, 0xe8, 0x35, 0xec, 0x67, 0x8c, 0xdc, 0x51, 0xbd, 0xf7, 0x74,
+	0x87, 0x2e, 0xdd, 0x34, 0x22, 0x4e, 0x4e, 0x42, 0x26, 0x1c, 0x46, 0x38, 0x9d, 0x33, 0xec, 0x37,
+	0xf7, 0x73, 0x43, 0xf7, 0xfe, 0x48, 0xd7, 0x0a, 0x53, 0xa7, 0x04, 0x19, 0x27, 0xa0, 0x5b, 0x13,
+	0x16, 0x0b, 0x79, 0xf7, 0x7d, 0xa8, 0xc6, 0x04, 0x73, 0x16, 0xca, 0xe1, 0xc9, 0xca, 0xf8, 0xa9,
+	0x40, 0x23, 0x33, 0x1e, 0x10, 0x17, 0xf3, 0x40, 0x82, 0xeb, 0xa0, 0xd2, 0x72, 0xca, 0x2a, 0xf5,
+	0xd1, 0x07, 0xa8, 0xd2, 0x30, 0x4a, 0x04, 0x6f, 0xaa, 0xf9, 0x80, 0xce, 0xb6, 0x5a, 0xdf, 0x94,
+	0x33, 0xed, 0x9c, 0x7b, 0x11, 0x8a, 0x38, 0x75, 0xa4, 0x50, 0xeb, 0x0a, 0xf4, 0xb5, 0x6d, 0xd4,
+	0x80, 0x4a, 0x40, 0x52, 0x79, 0x65, 0xb6, 0x44, 0x67, 0x70, 0x70, 0x83, 0xe7, 0x09, 0x91, 0xf1,
+	0x1f, 0xdf, 0x19, 0x7f, 0x96, 0x91, 0x7f, 0x99, 0x41, 0x9d, 0x82, 0xd1, 0x57, 0x4f, 0x15, 0xe3,
+	0x0a, 0x1a, 0x43, 0x26, 0xe8, 0x34, 0xdd, 0xd2, 0x56, 0x1f, 0x80, 0x7c, 0x8b, 0x88, 0xb7, 0xeb,
+	0x9b, 0x5d, 0x43, 0x1b, 0x6d, 0xa8, 0x8f, 0x03, 0x1a, 0xdd, 0xad, 0xfe, 0xec, 0x1d, 0xc0, 0x6a,
+	0x88, 0xe8, 0x08, 0x74, 0x7b, 0x78, 0x39, 0x1a, 0x5c, 0x7c, 0x71, 0xad, 0xf1, 0xa0, 0xb1, 0x87,
+	0x34, 0x38, 0xb0, 0xce, 0x47, 0x8e, 0xdb, 0x50, 0xb2, 0xb3, 0xe1, 0xc8, 0xb5, 0xdf, 0x7e, 0x2a,
+	0xce, 0x54, 0xf4, 0x3f, 0x68, 0xe3, 0x81, 0xfd, 0xbe, 0x28, 0x2b, 0xbd, 0x10, 0xb4, 0xf2, 0x81,
+	0xc7, 0x08, 0x43, 0x8d, 0x64, 0x6d, 0x62, 0x41, 0xd0, 0x8b, 0xad, 0x73, 0xd8, 0xf8, 0x28, 0x5a,
+	0x27, 0x3b, 0xa1, 0x8d, 0xbd, 0x73, 0xfd, 0xb3, 0xb6, 0xdc, 0x9f, 0x54, 0xf3, 0x20, 0x5e, 0xfd,
+	0x1a, 0x00, 0xc9, 0xe4, 0x65, 0x5e, 0xc5, 0x04, 0x00, 0x00,
 }
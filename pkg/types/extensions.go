@@ -3,7 +3,9 @@ package types
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/fission/fission-workflows/pkg/util/backoff"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 )
@@ -21,6 +23,23 @@ const (
 	WorkflowAPIVersion    = "v1"
 )
 
+// Error codes of failed tasks, which can be used by retry policies to select the failures to retry.
+const (
+	// ErrorCodeRuntime indicates that the function runtime failed to execute the function, for example because the
+	// function crashed or could not be reached.
+	ErrorCodeRuntime = "runtime"
+
+	// ErrorCodeFunction indicates that the function was executed, but reported a failure.
+	ErrorCodeFunction = "function"
+
+	// ErrorCodeTimeout indicates that the function did not complete within the timeout of the task.
+	ErrorCodeTimeout = "timeout"
+
+	// ErrorCodeAborted indicates that the attempt of the task was abandoned, because the invocation was retried while
+	// the task was in progress.
+	ErrorCodeAborted = "aborted"
+)
+
 // InvocationEvent
 var invocationFinalStates = []WorkflowInvocationStatus_Status{
 	WorkflowInvocationStatus_ABORTED,
@@ -96,6 +115,107 @@ func (m *WorkflowInvocation) TaskInvocation(id string) (*TaskInvocation, bool) {
 	return ti, ok
 }
 
+// Timeout returns the timeout of the invocation, which is either the timeout specified for the invocation or the
+// timeout of the workflow. It returns false if neither specifies a timeout.
+func (m *WorkflowInvocation) Timeout(wf *Workflow) (time.Duration, bool) {
+	timeout := m.GetSpec().GetTimeout()
+	if timeout == nil {
+		timeout = wf.GetSpec().GetTimeout()
+	}
+	if timeout == nil {
+		return 0, false
+	}
+	d, err := ptypes.Duration(timeout)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// Deadline returns the time at which the invocation times out. The timeout applies from the last retry of the
+// invocation, or otherwise from the time at which the invocation should start. If neither the invocation nor the
+// workflow specify a timeout, the default timeout is used.
+//
+// The clock of the timeout is stopped while the invocation is paused, so the deadline is extended by the duration of
+// the pauses. While the invocation is paused, the deadline keeps moving forward.
+func (m *WorkflowInvocation) Deadline(wf *Workflow, defaultTimeout time.Duration) (time.Time, error) {
+	startedAt, err := m.TimeoutStart()
+	if err != nil {
+		return time.Time{}, err
+	}
+	timeout, ok := m.Timeout(wf)
+	if !ok {
+		timeout = defaultTimeout
+	}
+	paused, err := m.GetStatus().PausedDuration(time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return startedAt.Add(timeout + paused), nil
+}
+
+// TimeoutStart returns the time from which the timeout of the invocation applies, which is the time of the last retry
+// of the invocation, or otherwise the time at which the invocation should start.
+func (m *WorkflowInvocation) TimeoutStart() (time.Time, error) {
+	if retriedAt := m.GetStatus().GetRetriedAt(); retriedAt != nil {
+		return ptypes.Timestamp(retriedAt)
+	}
+	return m.StartAt()
+}
+
+// StartAt returns the time at which the invocation should start, which is either the time specified for the
+// invocation, the time of creation plus the delay of the invocation, or otherwise the time of creation.
+func (m *WorkflowInvocation) StartAt() (time.Time, error) {
+	if startAt := m.GetSpec().GetStartAt(); startAt != nil {
+		return ptypes.Timestamp(startAt)
+	}
+	createdAt, err := ptypes.Timestamp(m.GetMetadata().GetCreatedAt())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if delay := m.GetSpec().GetDelay(); delay != nil {
+		d, err := ptypes.Duration(delay)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return createdAt.Add(d), nil
+	}
+	return createdAt, nil
+}
+
+// PausedDuration returns the total duration that the invocation has been paused at the provided time, since its
+// timeout started to apply.
+func (m *WorkflowInvocationStatus) PausedDuration(now time.Time) (time.Duration, error) {
+	var paused time.Duration
+	if pausedFor := m.GetPausedFor(); pausedFor != nil {
+		d, err := ptypes.Duration(pausedFor)
+		if err != nil {
+			return 0, err
+		}
+		paused = d
+	}
+	if m.GetPausedAt() != nil {
+		pausedAt, err := ptypes.Timestamp(m.GetPausedAt())
+		if err != nil {
+			return 0, err
+		}
+		if now.After(pausedAt) {
+			paused += now.Sub(pausedAt)
+		}
+	}
+	return paused, nil
+}
+
+// Deferred returns true if the invocation should start after its creation.
+func (m *WorkflowInvocation) Deferred() bool {
+	createdAt, err := ptypes.Timestamp(m.GetMetadata().GetCreatedAt())
+	if err != nil {
+		return false
+	}
+	startAt, err := m.StartAt()
+	return err == nil && startAt.After(createdAt)
+}
+
 func (m *WorkflowInvocation) TaskInvocations() []*TaskInvocation {
 	var tasks []*TaskInvocation
 	for id := range m.Status.Tasks {
@@ -117,6 +237,7 @@ func (m *WorkflowInvocationStatus) ToTaskStatus() *TaskInvocationStatus {
 		WorkflowInvocationStatus_SUCCEEDED:   TaskInvocationStatus_SUCCEEDED,
 		WorkflowInvocationStatus_FAILED:      TaskInvocationStatus_FAILED,
 		WorkflowInvocationStatus_ABORTED:     TaskInvocationStatus_ABORTED,
+		WorkflowInvocationStatus_PAUSED:      TaskInvocationStatus_IN_PROGRESS,
 	}
 
 	return &TaskInvocationStatus{
@@ -156,6 +277,7 @@ func (m *TaskInvocationSpec) ToWorkflowSpec() *WorkflowInvocationSpec {
 	return &WorkflowInvocationSpec{
 		WorkflowId: m.FnRef.ID,
 		Inputs:     m.Inputs,
+		Timeout:    m.Timeout,
 	}
 }
 
@@ -196,8 +318,8 @@ func (m *TaskSpec) Input(key string, val *TypedValue) *TaskSpec {
 func (m *TaskSpec) Parent() (string, bool) {
 	var parent string
 	var present bool
-	for id, params := range m.Requires {
-		if params.Type == TaskDependencyParameters_DYNAMIC_OUTPUT {
+	for id, params := range m.GetRequires() {
+		if params.GetType() == TaskDependencyParameters_DYNAMIC_OUTPUT {
 			present = true
 			parent = id
 			break
@@ -301,6 +423,58 @@ func (m *WorkflowSpec) AddTask(id string, task *TaskSpec) *WorkflowSpec {
 	return m
 }
 
+//
+// RetryPolicy
+//
+
+// ShouldRetry returns true if the task should be retried according to the policy, which is the case if the task
+// failed with a retryable error and has not used up its attempts yet.
+func (m *RetryPolicy) ShouldRetry(status *TaskInvocationStatus) bool {
+	if m == nil || status.GetStatus() != TaskInvocationStatus_FAILED || status.GetAttempts() >= m.GetMaxAttempts() {
+		return false
+	}
+	if len(m.RetryOn) == 0 {
+		return true
+	}
+	for _, code := range m.RetryOn {
+		if code == status.GetError().GetCode() {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryAt returns the time at which the failed task should be retried. The delay after the failure increases
+// exponentially with the number of attempts, starting at the backoff of the policy.
+func (m *RetryPolicy) RetryAt(status *TaskInvocationStatus) time.Time {
+	failedAt, err := ptypes.Timestamp(status.GetUpdatedAt())
+	if err != nil {
+		failedAt = time.Now()
+	}
+	alg := *backoff.DefaultBackoffAlgorithm
+	if d, err := ptypes.Duration(m.GetBackoff()); err == nil {
+		alg.Step = d
+	}
+	if d, err := ptypes.Duration(m.GetMaxBackoff()); err == nil {
+		alg.MaxBackoff = d
+	}
+	alg.MinBackoff = alg.Step
+	return alg.Backoff(backoff.Context{
+		Attempts:    int(status.GetAttempts()),
+		LockedUntil: failedAt,
+	}).LockedUntil
+}
+
+//
+// FailurePolicy
+//
+
+// HandlesFailure returns true if the failure of the task is handled by the policy, either by continuing or by running
+// the fallback task, instead of failing the invocation.
+func (m *FailurePolicy) HandlesFailure() bool {
+	return m.GetAction() == FailurePolicy_CONTINUE || len(m.GetFallback()) > 0
+}
+
 //
 // WorkflowStatus
 //
@@ -319,3 +493,31 @@ func (m *WorkflowStatus) AddTaskStatus(id string, t *TaskStatus) {
 	}
 	m.Tasks[id] = t
 }
+
+//
+// Schedule
+//
+
+func (m *Schedule) ID() string {
+	return m.GetMetadata().GetId()
+}
+
+//
+// ScheduleSpec
+//
+
+// Location returns the timezone in which the cron expression of the schedule is interpreted, which defaults to UTC.
+func (m *ScheduleSpec) Location() (*time.Location, error) {
+	if len(m.GetTimezone()) == 0 {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(m.GetTimezone())
+}
+
+//
+// ScheduleStatus
+//
+
+func (m *ScheduleStatus) Deleted() bool {
+	return m.GetStatus() == ScheduleStatus_DELETED
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ptypes.DurationProto(time.Second),
+		MaxBackoff:  ptypes.DurationProto(2 * time.Second),
+		RetryOn:     []string{ErrorCodeRuntime},
+	}
+	failedAt := time.Now()
+	status := &TaskInvocationStatus{
+		Status:    TaskInvocationStatus_FAILED,
+		UpdatedAt: ptypes.TimestampNow(),
+		Error:     &Error{Code: ErrorCodeRuntime},
+		Attempts:  1,
+	}
+	assert.True(t, policy.ShouldRetry(status))
+	assert.WithinDuration(t, failedAt.Add(time.Second), policy.RetryAt(status), 100*time.Millisecond)
+
+	// The backoff should grow with the number of attempts, up to the maximum backoff.
+	status.Attempts = 2
+	assert.True(t, policy.ShouldRetry(status))
+	assert.WithinDuration(t, failedAt.Add(1500*time.Millisecond), policy.RetryAt(status), 100*time.Millisecond)
+	status.Attempts = 3
+	assert.False(t, policy.ShouldRetry(status))
+	assert.WithinDuration(t, failedAt.Add(2*time.Second), policy.RetryAt(status), 100*time.Millisecond)
+
+	// Only failed tasks with a retryable error should be retried.
+	status.Attempts = 1
+	status.Error.Code = ErrorCodeFunction
+	assert.False(t, policy.ShouldRetry(status))
+	status.Error.Code = ErrorCodeRuntime
+	status.Status = TaskInvocationStatus_SUCCEEDED
+	assert.False(t, policy.ShouldRetry(status))
+
+	var noPolicy *RetryPolicy
+	status.Status = TaskInvocationStatus_FAILED
+	assert.False(t, noPolicy.ShouldRetry(status))
+}
+
+func TestPausedDuration(t *testing.T) {
+	now := time.Now()
+	status := &WorkflowInvocationStatus{}
+	paused, err := status.PausedDuration(now)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, paused)
+
+	// An ongoing pause counts up to the provided time.
+	status.PausedAt, _ = ptypes.TimestampProto(now.Add(-time.Minute))
+	paused, err = status.PausedDuration(now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, paused)
+
+	// Previous pauses are added to the ongoing pause.
+	status.PausedFor = ptypes.DurationProto(time.Hour)
+	paused, err = status.PausedDuration(now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour+time.Minute, paused)
+}
@@ -153,6 +153,67 @@ func Roots(g graph.Directed) []graph.Node {
 	return roots
 }
 
+// Ready returns the nodes of the graph that are ready to be run. Besides the roots of the graph, these include the
+// partial joins: nodes that await only a number of their data dependencies, of which sufficient data dependencies have
+// completed. Control dependencies are always awaited.
+func Ready(g graph.Directed) []graph.Node {
+	var ready []graph.Node
+	for _, n := range g.Nodes() {
+		in := g.To(n)
+		if len(in) == 0 || awaited(n, in) {
+			ready = append(ready, n)
+		}
+	}
+	return ready
+}
+
+// awaited returns true if the node awaits only a part of its data dependencies, and sufficient data dependencies have
+// completed, given the nodes that the node still depends on.
+func awaited(n graph.Node, in []graph.Node) bool {
+	_, spec := nodeSpec(n)
+	requires := spec.GetRequires()
+	var data int
+	for _, params := range requires {
+		if params.GetType() == types.TaskDependencyParameters_DATA {
+			data++
+		}
+	}
+	if spec.GetAwait() <= 0 || int(spec.GetAwait()) >= data {
+		return false
+	}
+
+	pending := map[string]bool{}
+	for _, dep := range in {
+		depID, depSpec := nodeSpec(dep)
+		if _, ok := requires[depID]; !ok {
+			// The dependents of a parent task also depend on the dynamic task of the parent.
+			parent, ok := depSpec.Parent()
+			if !ok {
+				return false
+			}
+			if _, ok := requires[parent]; !ok {
+				return false
+			}
+			depID = parent
+		}
+		if requires[depID].GetType() != types.TaskDependencyParameters_DATA {
+			return false
+		}
+		pending[depID] = true
+	}
+	return data-len(pending) >= int(spec.GetAwait())
+}
+
+func nodeSpec(n graph.Node) (string, *types.TaskSpec) {
+	switch v := n.(type) {
+	case *TaskSpecNode:
+		return v.id, v.TaskSpec
+	case *TaskInstanceNode:
+		return v.Task.ID(), v.Task.GetSpec()
+	}
+	return "", nil
+}
+
 func createID(s string) int64 {
 	h := fnv.New64a()
 	h.Write([]byte(s))
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -109,3 +110,33 @@ func TestParseDynamic(t *testing.T) {
 	assert.Equal(t, 1, len(dag.From(nodeD)))
 	assert.Equal(t, 1, len(dag.To(nodeD)))
 }
+
+func TestReady(t *testing.T) {
+	it := NewTaskSpecIterator(map[string]*types.TaskSpec{
+		"a": {},
+		"b": {
+			Requires: types.Require("a"),
+		},
+		"race": {
+			Requires: types.Require("a", "b").Add("c"),
+			Await:    1,
+		},
+		"controlled": {
+			Requires: map[string]*types.TaskDependencyParameters{
+				"a": nil,
+				"b": {
+					Type: types.TaskDependencyParameters_CONTROL,
+				},
+			},
+			Await: 1,
+		},
+	})
+	dag := Parse(it)
+	var ready []string
+	for _, n := range Ready(dag) {
+		ready = append(ready, n.(*TaskSpecNode).id)
+	}
+	// The race only awaits one of its dependencies, of which 'c' is not part of the graph anymore.
+	sort.Strings(ready)
+	assert.Equal(t, []string{"a", "race"}, ready)
+}
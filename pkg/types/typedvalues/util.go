@@ -18,6 +18,15 @@ func ResolveTaskOutput(taskID string, invoc *types.WorkflowInvocation) *types.Ty
 
 	output := val.Status.Output
 	if output == nil {
+		if taskErr := val.Status.GetError(); val.Status.GetStatus() == types.TaskInvocationStatus_FAILED &&
+			taskErr != nil {
+			// The error is the output of a failed task, which is used by the dependents of tasks that continue on
+			// failure.
+			return MustParse(map[string]interface{}{
+				"code":    taskErr.GetCode(),
+				"message": taskErr.GetMessage(),
+			})
+		}
 		return nil
 	}
 
@@ -28,6 +28,11 @@ It has these top-level messages:
 	FnRef
 	TypedValueMap
 	TypedValueList
+	RetryPolicy
+	FailurePolicy
+	Schedule
+	ScheduleSpec
+	ScheduleStatus
 */
 package types
 
@@ -35,6 +40,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/duration"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -84,6 +90,7 @@ const (
 	WorkflowInvocationStatus_SUCCEEDED   WorkflowInvocationStatus_Status = 3
 	WorkflowInvocationStatus_FAILED      WorkflowInvocationStatus_Status = 4
 	WorkflowInvocationStatus_ABORTED     WorkflowInvocationStatus_Status = 5
+	WorkflowInvocationStatus_PAUSED      WorkflowInvocationStatus_Status = 6
 )
 
 var WorkflowInvocationStatus_Status_name = map[int32]string{
@@ -93,6 +100,7 @@ var WorkflowInvocationStatus_Status_name = map[int32]string{
 	3: "SUCCEEDED",
 	4: "FAILED",
 	5: "ABORTED",
+	6: "PAUSED",
 }
 var WorkflowInvocationStatus_Status_value = map[string]int32{
 	"UNKNOWN":     0,
@@ -101,6 +109,7 @@ var WorkflowInvocationStatus_Status_value = map[string]int32{
 	"SUCCEEDED":   3,
 	"FAILED":      4,
 	"ABORTED":     5,
+	"PAUSED":      6,
 }
 
 func (x WorkflowInvocationStatus_Status) String() string {
@@ -110,6 +119,31 @@ func (WorkflowInvocationStatus_Status) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{5, 0}
 }
 
+// SkipPolicy determines whether a task is skipped based on the outcome of its dependencies.
+type TaskSpec_SkipPolicy int32
+
+const (
+	TaskSpec_ANY  TaskSpec_SkipPolicy = 0
+	TaskSpec_ALL  TaskSpec_SkipPolicy = 1
+	TaskSpec_NONE TaskSpec_SkipPolicy = 2
+)
+
+var TaskSpec_SkipPolicy_name = map[int32]string{
+	0: "ANY",
+	1: "ALL",
+	2: "NONE",
+}
+var TaskSpec_SkipPolicy_value = map[string]int32{
+	"ANY":  0,
+	"ALL":  1,
+	"NONE": 2,
+}
+
+func (x TaskSpec_SkipPolicy) String() string {
+	return proto.EnumName(TaskSpec_SkipPolicy_name, int32(x))
+}
+func (TaskSpec_SkipPolicy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8, 0} }
+
 type TaskStatus_Status int32
 
 const (
@@ -137,8 +171,11 @@ func (TaskStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescripto
 type TaskDependencyParameters_DependencyType int32
 
 const (
-	TaskDependencyParameters_DATA           TaskDependencyParameters_DependencyType = 0
-	TaskDependencyParameters_CONTROL        TaskDependencyParameters_DependencyType = 1
+	// The task uses the output of the dependency
+	TaskDependencyParameters_DATA TaskDependencyParameters_DependencyType = 0
+	// The task only runs after the dependency, which is always awaited and never skips the task
+	TaskDependencyParameters_CONTROL TaskDependencyParameters_DependencyType = 1
+	// The task is the dynamic task of the dependency
 	TaskDependencyParameters_DYNAMIC_OUTPUT TaskDependencyParameters_DependencyType = 2
 )
 
@@ -201,6 +238,74 @@ func (TaskInvocationStatus_Status) EnumDescriptor() ([]byte, []int) {
 //
 // Workflow Model
 //
+type FailurePolicy_Action int32
+
+const (
+	FailurePolicy_ABORT    FailurePolicy_Action = 0
+	FailurePolicy_CONTINUE FailurePolicy_Action = 1
+)
+
+var FailurePolicy_Action_name = map[int32]string{
+	0: "ABORT",
+	1: "CONTINUE",
+}
+var FailurePolicy_Action_value = map[string]int32{
+	"ABORT":    0,
+	"CONTINUE": 1,
+}
+
+func (x FailurePolicy_Action) String() string {
+	return proto.EnumName(FailurePolicy_Action_name, int32(x))
+}
+func (FailurePolicy_Action) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{21, 0} }
+
+type ScheduleSpec_ConcurrencyPolicy int32
+
+const (
+	ScheduleSpec_ALLOW   ScheduleSpec_ConcurrencyPolicy = 0
+	ScheduleSpec_FORBID  ScheduleSpec_ConcurrencyPolicy = 1
+	ScheduleSpec_REPLACE ScheduleSpec_ConcurrencyPolicy = 2
+)
+
+var ScheduleSpec_ConcurrencyPolicy_name = map[int32]string{
+	0: "ALLOW",
+	1: "FORBID",
+	2: "REPLACE",
+}
+var ScheduleSpec_ConcurrencyPolicy_value = map[string]int32{
+	"ALLOW":   0,
+	"FORBID":  1,
+	"REPLACE": 2,
+}
+
+func (x ScheduleSpec_ConcurrencyPolicy) String() string {
+	return proto.EnumName(ScheduleSpec_ConcurrencyPolicy_name, int32(x))
+}
+func (ScheduleSpec_ConcurrencyPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{23, 0}
+}
+
+type ScheduleStatus_Status int32
+
+const (
+	ScheduleStatus_ACTIVE  ScheduleStatus_Status = 0
+	ScheduleStatus_DELETED ScheduleStatus_Status = 1
+)
+
+var ScheduleStatus_Status_name = map[int32]string{
+	0: "ACTIVE",
+	1: "DELETED",
+}
+var ScheduleStatus_Status_value = map[string]int32{
+	"ACTIVE":  0,
+	"DELETED": 1,
+}
+
+func (x ScheduleStatus_Status) String() string {
+	return proto.EnumName(ScheduleStatus_Status_name, int32(x))
+}
+func (ScheduleStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{24, 0} }
+
 type Workflow struct {
 	Metadata *ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
 	Spec     *WorkflowSpec   `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
@@ -258,6 +363,9 @@ type WorkflowSpec struct {
 	// Internal indicates whether is a workflow should be visible to a human (default) or not.
 	//
 	Internal bool `protobuf:"varint,7,opt,name=internal" json:"internal,omitempty"`
+	// Timeout is the maximum duration of an invocation of the workflow, after which the invocation is failed.
+	// If not set, the default timeout of the controller is used.
+	Timeout *google_protobuf1.Duration `protobuf:"bytes,8,opt,name=timeout" json:"timeout,omitempty"`
 }
 
 func (m *WorkflowSpec) Reset()                    { *m = WorkflowSpec{} }
@@ -314,6 +422,13 @@ func (m *WorkflowSpec) GetInternal() bool {
 	return false
 }
 
+func (m *WorkflowSpec) GetTimeout() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
 type WorkflowStatus struct {
 	Status    WorkflowStatus_Status      `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.WorkflowStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -398,6 +513,16 @@ type WorkflowInvocationSpec struct {
 	//
 	// This used within the workflow engine; for user-provided workflow invocations the parentId is ignored.
 	ParentId string `protobuf:"bytes,3,opt,name=parentId" json:"parentId,omitempty"`
+	// Timeout overrides the timeout of the workflow for this invocation.
+	Timeout *google_protobuf1.Duration `protobuf:"bytes,5,opt,name=timeout" json:"timeout,omitempty"`
+	// StartAt defers the start of the invocation until the provided time. Until then, the invocation remains
+	// SCHEDULED. Only one of startAt and delay can be specified.
+	StartAt *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=startAt" json:"startAt,omitempty"`
+	// Delay defers the start of the invocation for the provided duration after the creation of the invocation.
+	Delay *google_protobuf1.Duration `protobuf:"bytes,7,opt,name=delay" json:"delay,omitempty"`
+	// IdempotencyKey identifies the request that created the invocation. Invoking a workflow with a key that was
+	// already used within the idempotency window returns the original invocation instead of creating a new one.
+	IdempotencyKey string `protobuf:"bytes,8,opt,name=idempotencyKey" json:"idempotencyKey,omitempty"`
 }
 
 func (m *WorkflowInvocationSpec) Reset()                    { *m = WorkflowInvocationSpec{} }
@@ -426,6 +551,34 @@ func (m *WorkflowInvocationSpec) GetParentId() string {
 	return ""
 }
 
+func (m *WorkflowInvocationSpec) GetTimeout() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationSpec) GetStartAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartAt
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationSpec) GetDelay() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Delay
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationSpec) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type WorkflowInvocationStatus struct {
 	Status    WorkflowInvocationStatus_Status `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp      `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -435,6 +588,13 @@ type WorkflowInvocationStatus struct {
 	// used as an overlay over the static task.
 	DynamicTasks map[string]*Task `protobuf:"bytes,5,rep,name=dynamicTasks" json:"dynamicTasks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Error        *Error           `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	// The time at which the failed invocation was last retried, from which its timeout applies.
+	RetriedAt *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=retriedAt" json:"retriedAt,omitempty"`
+	// The time at which the invocation was paused; only set while the invocation is paused.
+	PausedAt *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=pausedAt" json:"pausedAt,omitempty"`
+	// The total duration that the invocation has been paused since its timeout started to apply. The timeout of the
+	// invocation is extended by this duration.
+	PausedFor *google_protobuf1.Duration `protobuf:"bytes,9,opt,name=pausedFor" json:"pausedFor,omitempty"`
 }
 
 func (m *WorkflowInvocationStatus) Reset()                    { *m = WorkflowInvocationStatus{} }
@@ -484,6 +644,27 @@ func (m *WorkflowInvocationStatus) GetError() *Error {
 	return nil
 }
 
+func (m *WorkflowInvocationStatus) GetRetriedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.RetriedAt
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationStatus) GetPausedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.PausedAt
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationStatus) GetPausedFor() *google_protobuf1.Duration {
+	if m != nil {
+		return m.PausedFor
+	}
+	return nil
+}
+
 type DependencyConfig struct {
 	// Dependencies for this task to execute
 	Requires map[string]*TaskDependencyParameters `protobuf:"bytes,1,rep,name=requires" json:"requires,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -555,10 +736,25 @@ type TaskSpec struct {
 	Inputs      map[string]*TypedValue `protobuf:"bytes,2,rep,name=inputs" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Dependencies for this task to execute
 	Requires map[string]*TaskDependencyParameters `protobuf:"bytes,3,rep,name=requires" json:"requires,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// Number of dependencies to wait for
+	// Number of data dependencies to wait for. Once this number of data dependencies has completed, the task is
+	// started, and the remaining data dependencies that lost the race are skipped, unless other tasks depend on them.
+	// If not set, or if it is not less than the number of data dependencies, the task waits for all dependencies.
 	Await int32 `protobuf:"varint,4,opt,name=await" json:"await,omitempty"`
 	// Transform the output, or override the output with a literal
 	Output *TypedValue `protobuf:"bytes,5,opt,name=output" json:"output,omitempty"`
+	// Retry policy of the task. If not set, a failed task is not retried.
+	Retry *RetryPolicy `protobuf:"bytes,6,opt,name=retry" json:"retry,omitempty"`
+	// Timeout of a single attempt of the task, after which the task fails. If not set, the task can run until the
+	// invocation times out.
+	Timeout *google_protobuf1.Duration `protobuf:"bytes,7,opt,name=timeout" json:"timeout,omitempty"`
+	// Failure policy of the task, which determines how the failure of the task is handled once it will not be retried
+	// anymore. If not set, the failure of the task fails the invocation.
+	OnFailure *FailurePolicy `protobuf:"bytes,8,opt,name=onFailure" json:"onFailure,omitempty"`
+	// When is a condition, evaluated once the dependencies of the task have completed, that determines whether the
+	// task should be run. If it evaluates to false, the task is skipped. If not set, the task is always run.
+	When *TypedValue `protobuf:"bytes,9,opt,name=when" json:"when,omitempty"`
+	// Skip policy of the task, which determines whether the task is skipped when its dependencies have been skipped.
+	SkipPolicy TaskSpec_SkipPolicy `protobuf:"varint,10,opt,name=skipPolicy,enum=fission.workflows.types.TaskSpec_SkipPolicy" json:"skipPolicy,omitempty"`
 }
 
 func (m *TaskSpec) Reset()                    { *m = TaskSpec{} }
@@ -601,6 +797,41 @@ func (m *TaskSpec) GetOutput() *TypedValue {
 	return nil
 }
 
+func (m *TaskSpec) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetTimeout() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetOnFailure() *FailurePolicy {
+	if m != nil {
+		return m.OnFailure
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetWhen() *TypedValue {
+	if m != nil {
+		return m.When
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetSkipPolicy() TaskSpec_SkipPolicy {
+	if m != nil {
+		return m.SkipPolicy
+	}
+	return TaskSpec_ANY
+}
+
 type TaskStatus struct {
 	Status    TaskStatus_Status          `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.TaskStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -709,6 +940,8 @@ type TaskInvocationSpec struct {
 	Inputs map[string]*TypedValue `protobuf:"bytes,3,rep,name=inputs" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	//
 	InvocationId string `protobuf:"bytes,4,opt,name=invocationId" json:"invocationId,omitempty"`
+	// Timeout of the task invocation, after which the runtime should abandon the execution of the function.
+	Timeout *google_protobuf1.Duration `protobuf:"bytes,5,opt,name=timeout" json:"timeout,omitempty"`
 }
 
 func (m *TaskInvocationSpec) Reset()                    { *m = TaskInvocationSpec{} }
@@ -744,11 +977,20 @@ func (m *TaskInvocationSpec) GetInvocationId() string {
 	return ""
 }
 
+func (m *TaskInvocationSpec) GetTimeout() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
 type TaskInvocationStatus struct {
 	Status    TaskInvocationStatus_Status `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.TaskInvocationStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp  `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
 	Output    *TypedValue                 `protobuf:"bytes,3,opt,name=output" json:"output,omitempty"`
 	Error     *Error                      `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	// Number of times that the task has been started, including retries.
+	Attempts int32 `protobuf:"varint,5,opt,name=attempts" json:"attempts,omitempty"`
 }
 
 func (m *TaskInvocationStatus) Reset()                    { *m = TaskInvocationStatus{} }
@@ -784,6 +1026,13 @@ func (m *TaskInvocationStatus) GetError() *Error {
 	return nil
 }
 
+func (m *TaskInvocationStatus) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
 //
 // Common
 //
@@ -845,7 +1094,8 @@ func (m *TypedValue) GetLabels() map[string]string {
 }
 
 type Error struct {
-	//    string code = 1;
+	// Code classifies the error, which allows retry policies to select the errors to retry.
+	Code    string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
 	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
 }
 
@@ -854,6 +1104,13 @@ func (m *Error) String() string            { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()               {}
 func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
+func (m *Error) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
 func (m *Error) GetMessage() string {
 	if m != nil {
 		return m.Message
@@ -933,6 +1190,235 @@ func (m *TypedValueList) GetValue() []*TypedValue {
 	return nil
 }
 
+// RetryPolicy describes how a failed task should be retried.
+type RetryPolicy struct {
+	// Maximum number of attempts of the task, including the first attempt.
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	// Backoff is the base delay between attempts, which increases exponentially with each attempt.
+	Backoff *google_protobuf1.Duration `protobuf:"bytes,2,opt,name=backoff" json:"backoff,omitempty"`
+	// MaxBackoff limits the delay between attempts.
+	MaxBackoff *google_protobuf1.Duration `protobuf:"bytes,3,opt,name=maxBackoff" json:"maxBackoff,omitempty"`
+	// RetryOn lists the error codes that should be retried. If empty, all errors are retried.
+	RetryOn []string `protobuf:"bytes,4,rep,name=retryOn" json:"retryOn,omitempty"`
+}
+
+func (m *RetryPolicy) Reset()                    { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()               {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoff() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Backoff
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetMaxBackoff() *google_protobuf1.Duration {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetRetryOn() []string {
+	if m != nil {
+		return m.RetryOn
+	}
+	return nil
+}
+
+// FailurePolicy describes how the failure of a task should be handled.
+type FailurePolicy struct {
+	Action FailurePolicy_Action `protobuf:"varint,1,opt,name=action,enum=fission.workflows.types.FailurePolicy_Action" json:"action,omitempty"`
+	// Fallback is the id of a task in the workflow that is run when the task fails, and skipped otherwise. The
+	// fallback task should depend on the task. The dependents of the failed task wait on the fallback task to complete.
+	Fallback string `protobuf:"bytes,2,opt,name=fallback" json:"fallback,omitempty"`
+}
+
+func (m *FailurePolicy) Reset()                    { *m = FailurePolicy{} }
+func (m *FailurePolicy) String() string            { return proto.CompactTextString(m) }
+func (*FailurePolicy) ProtoMessage()               {}
+func (*FailurePolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *FailurePolicy) GetAction() FailurePolicy_Action {
+	if m != nil {
+		return m.Action
+	}
+	return FailurePolicy_ABORT
+}
+
+func (m *FailurePolicy) GetFallback() string {
+	if m != nil {
+		return m.Fallback
+	}
+	return ""
+}
+
+// Schedule invokes a workflow periodically, according to a cron expression.
+type Schedule struct {
+	Metadata *ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Spec     *ScheduleSpec   `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+	Status   *ScheduleStatus `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *Schedule) Reset()                    { *m = Schedule{} }
+func (m *Schedule) String() string            { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()               {}
+func (*Schedule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *Schedule) GetMetadata() *ObjectMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Schedule) GetSpec() *ScheduleSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *Schedule) GetStatus() *ScheduleStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ScheduleSpec struct {
+	// WorkflowId is the id of the workflow that is invoked.
+	WorkflowId string `protobuf:"bytes,1,opt,name=workflowId" json:"workflowId,omitempty"`
+	// Cron is the cron expression of the schedule, consisting of the five standard fields (minute, hour, day of month,
+	// month and day of week), or a descriptor such as @daily or @every 1h30m.
+	Cron string `protobuf:"bytes,2,opt,name=cron" json:"cron,omitempty"`
+	// Inputs are the inputs of the invocations of the workflow.
+	Inputs map[string]*TypedValue `protobuf:"bytes,3,rep,name=inputs" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Timezone is the IANA name of the timezone in which the cron expression is interpreted, such as
+	// Europe/Amsterdam. If not set, UTC is used.
+	Timezone          string                         `protobuf:"bytes,4,opt,name=timezone" json:"timezone,omitempty"`
+	ConcurrencyPolicy ScheduleSpec_ConcurrencyPolicy `protobuf:"varint,5,opt,name=concurrencyPolicy,enum=fission.workflows.types.ScheduleSpec_ConcurrencyPolicy" json:"concurrencyPolicy,omitempty"`
+}
+
+func (m *ScheduleSpec) Reset()                    { *m = ScheduleSpec{} }
+func (m *ScheduleSpec) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleSpec) ProtoMessage()               {}
+func (*ScheduleSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ScheduleSpec) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
+func (m *ScheduleSpec) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *ScheduleSpec) GetInputs() map[string]*TypedValue {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ScheduleSpec) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *ScheduleSpec) GetConcurrencyPolicy() ScheduleSpec_ConcurrencyPolicy {
+	if m != nil {
+		return m.ConcurrencyPolicy
+	}
+	return ScheduleSpec_ALLOW
+}
+
+type ScheduleStatus struct {
+	Status    ScheduleStatus_Status      `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.ScheduleStatus_Status" json:"status,omitempty"`
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// ScheduledUntil is the time up to which the runs of the schedule have been handled, either by invoking the
+	// workflow or by recording the runs as missed. The next run is the first run after this time.
+	ScheduledUntil *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=scheduledUntil" json:"scheduledUntil,omitempty"`
+	// LastInvocationId is the id of the invocation of the last run that invoked the workflow.
+	LastInvocationId string `protobuf:"bytes,4,opt,name=lastInvocationId" json:"lastInvocationId,omitempty"`
+	// MissedRuns is the number of runs that did not invoke the workflow.
+	MissedRuns int64 `protobuf:"varint,5,opt,name=missedRuns" json:"missedRuns,omitempty"`
+	// LastMissedAt is the scheduled time of the last run that did not invoke the workflow.
+	LastMissedAt *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=lastMissedAt" json:"lastMissedAt,omitempty"`
+	// LastMissedReason describes why the last missed run did not invoke the workflow.
+	LastMissedReason string `protobuf:"bytes,7,opt,name=lastMissedReason" json:"lastMissedReason,omitempty"`
+}
+
+func (m *ScheduleStatus) Reset()                    { *m = ScheduleStatus{} }
+func (m *ScheduleStatus) String() string            { return proto.CompactTextString(m) }
+func (*ScheduleStatus) ProtoMessage()               {}
+func (*ScheduleStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ScheduleStatus) GetStatus() ScheduleStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ScheduleStatus_ACTIVE
+}
+
+func (m *ScheduleStatus) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *ScheduleStatus) GetScheduledUntil() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ScheduledUntil
+	}
+	return nil
+}
+
+func (m *ScheduleStatus) GetLastInvocationId() string {
+	if m != nil {
+		return m.LastInvocationId
+	}
+	return ""
+}
+
+func (m *ScheduleStatus) GetMissedRuns() int64 {
+	if m != nil {
+		return m.MissedRuns
+	}
+	return 0
+}
+
+func (m *ScheduleStatus) GetLastMissedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LastMissedAt
+	}
+	return nil
+}
+
+func (m *ScheduleStatus) GetLastMissedReason() string {
+	if m != nil {
+		return m.LastMissedReason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Workflow)(nil), "fission.workflows.types.Workflow")
 	proto.RegisterType((*WorkflowSpec)(nil), "fission.workflows.types.WorkflowSpec")
@@ -954,102 +1440,149 @@ func init() {
 	proto.RegisterType((*FnRef)(nil), "fission.workflows.types.FnRef")
 	proto.RegisterType((*TypedValueMap)(nil), "fission.workflows.types.TypedValueMap")
 	proto.RegisterType((*TypedValueList)(nil), "fission.workflows.types.TypedValueList")
+	proto.RegisterType((*RetryPolicy)(nil), "fission.workflows.types.RetryPolicy")
+	proto.RegisterType((*FailurePolicy)(nil), "fission.workflows.types.FailurePolicy")
+	proto.RegisterType((*Schedule)(nil), "fission.workflows.types.Schedule")
+	proto.RegisterType((*ScheduleSpec)(nil), "fission.workflows.types.ScheduleSpec")
+	proto.RegisterType((*ScheduleStatus)(nil), "fission.workflows.types.ScheduleStatus")
 	proto.RegisterEnum("fission.workflows.types.WorkflowStatus_Status", WorkflowStatus_Status_name, WorkflowStatus_Status_value)
 	proto.RegisterEnum("fission.workflows.types.WorkflowInvocationStatus_Status", WorkflowInvocationStatus_Status_name, WorkflowInvocationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.TaskSpec_SkipPolicy", TaskSpec_SkipPolicy_name, TaskSpec_SkipPolicy_value)
 	proto.RegisterEnum("fission.workflows.types.TaskStatus_Status", TaskStatus_Status_name, TaskStatus_Status_value)
 	proto.RegisterEnum("fission.workflows.types.TaskDependencyParameters_DependencyType", TaskDependencyParameters_DependencyType_name, TaskDependencyParameters_DependencyType_value)
 	proto.RegisterEnum("fission.workflows.types.TaskInvocationStatus_Status", TaskInvocationStatus_Status_name, TaskInvocationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.FailurePolicy_Action", FailurePolicy_Action_name, FailurePolicy_Action_value)
+	proto.RegisterEnum("fission.workflows.types.ScheduleSpec_ConcurrencyPolicy", ScheduleSpec_ConcurrencyPolicy_name, ScheduleSpec_ConcurrencyPolicy_value)
+	proto.RegisterEnum("fission.workflows.types.ScheduleStatus_Status", ScheduleStatus_Status_name, ScheduleStatus_Status_value)
 }
 
 func init() { proto.RegisterFile("pkg/types/types.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1383 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xc1, 0x6e, 0xdb, 0x46,
-	0x13, 0x0e, 0x29, 0x51, 0xb6, 0x46, 0x8e, 0x7e, 0xfd, 0x8b, 0x34, 0x15, 0x84, 0x36, 0x75, 0x58,
-	0x14, 0x09, 0xda, 0x86, 0x6e, 0x9c, 0x14, 0x89, 0xeb, 0x16, 0xa9, 0x22, 0xd2, 0x0e, 0x61, 0x5b,
-	0x12, 0x28, 0x39, 0x41, 0x52, 0x24, 0xc1, 0x5a, 0x5c, 0x19, 0x8c, 0x25, 0x8a, 0x25, 0xa9, 0x04,
-	0x7e, 0x84, 0xbe, 0x47, 0x8f, 0xbd, 0x07, 0xe8, 0xa5, 0x87, 0x1e, 0xfb, 0x0c, 0x45, 0x0f, 0x3d,
-	0xf5, 0xd0, 0x7b, 0x2f, 0x05, 0x8a, 0x5d, 0x2e, 0xc5, 0xa5, 0x2c, 0x59, 0x52, 0xaa, 0xf4, 0x62,
-	0x73, 0x77, 0x67, 0x66, 0x67, 0xbf, 0xf9, 0xe6, 0xdb, 0x15, 0xbc, 0xe3, 0x9d, 0x1c, 0x6f, 0x84,
-	0xa7, 0x1e, 0x09, 0xa2, 0xbf, 0x9a, 0xe7, 0x0f, 0xc2, 0x01, 0x7a, 0xb7, 0xeb, 0x04, 0x81, 0x33,
-	0x70, 0xb5, 0x57, 0x03, 0xff, 0xa4, 0xdb, 0x1b, 0xbc, 0x0a, 0x34, 0xb6, 0x5c, 0xf9, 0xe0, 0x78,
-	0x30, 0x38, 0xee, 0x91, 0x0d, 0x66, 0x76, 0x34, 0xec, 0x6e, 0x84, 0x4e, 0x9f, 0x04, 0x21, 0xee,
-	0x7b, 0x91, 0xa7, 0xfa, 0x8b, 0x04, 0xab, 0x8f, 0xb8, 0x13, 0xaa, 0xc1, 0x6a, 0x9f, 0x84, 0xd8,
-	0xc6, 0x21, 0x2e, 0x4b, 0xeb, 0xd2, 0xf5, 0xc2, 0xe6, 0x35, 0x6d, 0x4a, 0x64, 0xad, 0x71, 0xf4,
-	0x82, 0x74, 0xc2, 0x03, 0x6e, 0x6e, 0x8d, 0x1c, 0xd1, 0x16, 0x64, 0x03, 0x8f, 0x74, 0xca, 0x32,
-	0x0b, 0xf0, 0xd1, 0xd4, 0x00, 0xf1, 0xae, 0x2d, 0x8f, 0x74, 0x2c, 0xe6, 0x82, 0xee, 0x41, 0x2e,
-	0x08, 0x71, 0x38, 0x0c, 0xca, 0x99, 0x19, 0xbb, 0x8f, 0x9c, 0x99, 0xb9, 0xc5, 0xdd, 0xd4, 0xdf,
-	0x64, 0x58, 0x13, 0xe3, 0xa2, 0x2b, 0x00, 0xd8, 0x73, 0x1e, 0x12, 0x9f, 0x46, 0x61, 0x67, 0xca,
-	0x5b, 0xc2, 0x0c, 0xda, 0x01, 0x25, 0xc4, 0xc1, 0x49, 0x50, 0x96, 0xd7, 0x33, 0xd7, 0x0b, 0x9b,
-	0x9f, 0xcd, 0x95, 0xad, 0xd6, 0xa6, 0x2e, 0x86, 0x1b, 0xfa, 0xa7, 0x56, 0xe4, 0x4e, 0xf7, 0x19,
-	0x0c, 0x43, 0x6f, 0x18, 0xd2, 0x25, 0x96, 0x7d, 0xde, 0x12, 0x66, 0xd0, 0x3a, 0x14, 0x6c, 0x12,
-	0x74, 0x7c, 0xc7, 0x0b, 0x69, 0x22, 0x59, 0x66, 0x20, 0x4e, 0xa1, 0x32, 0xac, 0x74, 0x07, 0x7e,
-	0x87, 0x98, 0x76, 0x59, 0x61, 0xab, 0xf1, 0x10, 0x21, 0xc8, 0xba, 0xb8, 0x4f, 0xca, 0x39, 0x36,
-	0xcd, 0xbe, 0x51, 0x05, 0x56, 0x1d, 0x37, 0x24, 0xbe, 0x8b, 0x7b, 0xe5, 0x95, 0x75, 0xe9, 0xfa,
-	0xaa, 0x35, 0x1a, 0x57, 0xbe, 0x01, 0x48, 0x12, 0x44, 0x25, 0xc8, 0x9c, 0x90, 0x53, 0x7e, 0x74,
-	0xfa, 0x89, 0xee, 0x80, 0xf2, 0x12, 0xf7, 0x86, 0x84, 0x57, 0xe8, 0xea, 0xd4, 0x33, 0xd3, 0x28,
-	0xac, 0x3a, 0x91, 0xfd, 0x17, 0xf2, 0x5d, 0x49, 0xfd, 0x21, 0x03, 0xc5, 0x34, 0xf8, 0x68, 0x67,
-	0x54, 0x35, 0xba, 0x49, 0x71, 0x53, 0x9b, 0xb3, 0x6a, 0x5a, 0xba, 0x78, 0xe8, 0x2e, 0xe4, 0x87,
-	0x9e, 0x8d, 0x43, 0x62, 0x57, 0x43, 0x9e, 0x5b, 0x45, 0x8b, 0xf8, 0xab, 0xc5, 0xfc, 0xd5, 0xda,
-	0x31, 0x7f, 0xad, 0xc4, 0x18, 0x3d, 0x88, 0xab, 0x98, 0x61, 0x55, 0xdc, 0x9c, 0x37, 0x81, 0xb3,
-	0x75, 0xbc, 0x0d, 0x0a, 0xf1, 0xfd, 0x81, 0xcf, 0x2a, 0x54, 0xd8, 0xbc, 0x32, 0x35, 0x92, 0x41,
-	0xad, 0xac, 0xc8, 0xb8, 0xf2, 0x74, 0x06, 0xe2, 0x5b, 0x69, 0xc4, 0x3f, 0x3c, 0x1f, 0xf1, 0x08,
-	0x15, 0x01, 0xf3, 0x2d, 0xc8, 0x71, 0xa8, 0x0b, 0xb0, 0xd2, 0x34, 0xea, 0xba, 0x59, 0xdf, 0x2d,
-	0x5d, 0x40, 0x79, 0x50, 0x2c, 0xa3, 0xaa, 0x3f, 0x2e, 0xc9, 0x08, 0x20, 0xb7, 0x53, 0x35, 0xf7,
-	0x0d, 0xbd, 0x94, 0xa1, 0x36, 0xba, 0xb1, 0x6f, 0xb4, 0x0d, 0xbd, 0x94, 0x55, 0xff, 0x90, 0x00,
-	0xc5, 0x87, 0x36, 0xdd, 0x97, 0x83, 0x0e, 0x66, 0x64, 0x5b, 0x4a, 0xa3, 0xd7, 0x52, 0x8d, 0xbe,
-	0x31, 0x13, 0xf4, 0x64, 0x7f, 0xa1, 0xe5, 0xcd, 0xb1, 0x96, 0xbf, 0xb9, 0x48, 0x98, 0x74, 0xf3,
-	0x7f, 0x27, 0xc3, 0xe5, 0xc9, 0x7b, 0xd1, 0xf6, 0x8c, 0xc3, 0x99, 0x76, 0x2c, 0x03, 0xc9, 0x0c,
-	0x6a, 0x41, 0xce, 0x71, 0xbd, 0x61, 0x18, 0xeb, 0xc0, 0xf6, 0x82, 0x87, 0xd1, 0x4c, 0xe6, 0x1d,
-	0x51, 0x89, 0x87, 0xa2, 0x3d, 0xea, 0x61, 0x9f, 0xb8, 0xa1, 0x69, 0x73, 0x45, 0x18, 0x8d, 0x2b,
-	0xcf, 0xa0, 0x20, 0xb8, 0xfc, 0x2b, 0xca, 0x9c, 0x7a, 0xc4, 0x7e, 0x48, 0x4d, 0x45, 0xca, 0xfc,
-	0xa5, 0x40, 0x79, 0x1a, 0x60, 0xa8, 0x39, 0xd6, 0xb0, 0x77, 0x17, 0xc6, 0x7c, 0x79, 0xad, 0x6b,
-	0xa5, 0x5b, 0xf7, 0xcb, 0xc5, 0x53, 0x39, 0xdb, 0xc4, 0xdb, 0x90, 0x8b, 0xa4, 0x97, 0x77, 0xf1,
-	0x5c, 0xe0, 0x71, 0x17, 0x74, 0x0c, 0x6b, 0xf6, 0xa9, 0x8b, 0xfb, 0x4e, 0x87, 0x05, 0x2e, 0x2b,
-	0x2c, 0xaf, 0xda, 0xe2, 0x79, 0xe9, 0x42, 0x94, 0x28, 0xbd, 0x54, 0xe0, 0x44, 0x6a, 0x72, 0x8b,
-	0x48, 0x0d, 0x9e, 0x21, 0x35, 0x5f, 0xa5, 0x79, 0x73, 0xed, 0x5c, 0xa9, 0x49, 0x72, 0x16, 0xb8,
-	0x53, 0x79, 0x06, 0xff, 0x3f, 0x93, 0xfb, 0x84, 0x9d, 0x6e, 0xa5, 0x77, 0x7a, 0xff, 0xdc, 0x9d,
-	0x44, 0x6e, 0x3e, 0x15, 0xe5, 0xec, 0xb0, 0xbe, 0x57, 0x6f, 0x3c, 0xaa, 0x97, 0x2e, 0xa0, 0x8b,
-	0x90, 0x6f, 0xd5, 0x1e, 0x18, 0xfa, 0x21, 0x95, 0x31, 0x09, 0xfd, 0x0f, 0x0a, 0x66, 0xfd, 0x79,
-	0xd3, 0x6a, 0xec, 0x5a, 0x46, 0xab, 0x55, 0x92, 0xd9, 0xfa, 0x61, 0xad, 0x66, 0x18, 0x3a, 0x93,
-	0xb9, 0x44, 0xf2, 0xb2, 0x34, 0x4e, 0xf5, 0x7e, 0xc3, 0xa2, 0x92, 0xa7, 0xa8, 0x7f, 0x4a, 0x50,
-	0xd2, 0x89, 0x47, 0x5c, 0x9b, 0xb8, 0x9d, 0xd3, 0xda, 0xc0, 0xed, 0x3a, 0xc7, 0xa8, 0x05, 0xab,
-	0x3e, 0xf9, 0x76, 0xe8, 0xf8, 0x84, 0x92, 0x9e, 0x56, 0xf4, 0xce, 0xd4, 0x7c, 0xc7, 0x9d, 0x35,
-	0x8b, 0x7b, 0x46, 0x55, 0x1c, 0x05, 0x42, 0x97, 0x40, 0xc1, 0xaf, 0xb0, 0x13, 0x31, 0x5e, 0xb1,
-	0xa2, 0x41, 0xc5, 0x85, 0x8b, 0x29, 0x87, 0x09, 0xd0, 0xed, 0xa6, 0xa1, 0xbb, 0x79, 0x2e, 0x74,
-	0x49, 0x3a, 0x4d, 0xec, 0xe3, 0x3e, 0x09, 0x89, 0x9f, 0xba, 0x1d, 0x7e, 0x92, 0x20, 0xcb, 0xde,
-	0x18, 0x4b, 0x11, 0xf5, 0xcf, 0x53, 0xa2, 0x3e, 0xc7, 0xdb, 0x20, 0x92, 0xf1, 0xed, 0x31, 0x19,
-	0x9f, 0xeb, 0x8a, 0x8b, 0x85, 0xfb, 0xf7, 0x0c, 0xac, 0xc6, 0xf1, 0xe8, 0x4b, 0xa9, 0x3b, 0x74,
-	0x3b, 0x8c, 0x94, 0xa4, 0xcb, 0x51, 0x13, 0xa7, 0x90, 0x31, 0x26, 0xd6, 0x37, 0x66, 0x26, 0x39,
-	0x51, 0x9e, 0xf7, 0x04, 0x4a, 0x44, 0xe2, 0xb3, 0x31, 0x3b, 0xd0, 0x4c, 0x2a, 0x64, 0x05, 0x2a,
-	0x08, 0x42, 0xa4, 0x2c, 0x2c, 0x44, 0x6f, 0xfb, 0x8a, 0xf8, 0xcf, 0x79, 0xfa, 0xbd, 0x1c, 0x49,
-	0x17, 0xef, 0xfd, 0xfb, 0x63, 0x97, 0xd0, 0xc7, 0x73, 0x30, 0x66, 0x79, 0xd7, 0xce, 0x6d, 0x50,
-	0xba, 0x8c, 0x5f, 0x99, 0x19, 0xe2, 0xbb, 0x43, 0xad, 0xac, 0xc8, 0xf8, 0xcd, 0x5e, 0x87, 0xea,
-	0xa7, 0xa2, 0xde, 0xb5, 0xda, 0x55, 0xa6, 0x53, 0xc2, 0xf3, 0x4d, 0x12, 0xb4, 0x4c, 0x56, 0x7f,
-	0x96, 0xa0, 0x3c, 0x0d, 0x4e, 0xd4, 0x86, 0x2c, 0xdd, 0x80, 0x43, 0xf6, 0xf5, 0xc2, 0xf5, 0x10,
-	0xb4, 0x8d, 0x92, 0xc2, 0x62, 0xd1, 0x18, 0x79, 0x7b, 0x0e, 0x0e, 0x18, 0x84, 0x79, 0x2b, 0x1a,
-	0xa8, 0xdb, 0x50, 0x4c, 0x5b, 0xa3, 0x55, 0xc8, 0xea, 0xd5, 0x76, 0xb5, 0x74, 0x81, 0x1e, 0xa4,
-	0xd6, 0xa8, 0xb7, 0xad, 0xc6, 0x7e, 0x49, 0x42, 0x08, 0x8a, 0xfa, 0xe3, 0x7a, 0xf5, 0xc0, 0xac,
-	0x3d, 0x6f, 0x1c, 0xb6, 0x9b, 0x87, 0xed, 0x92, 0xac, 0xfe, 0x2a, 0x41, 0x31, 0x7d, 0xc3, 0x2c,
-	0x47, 0x9e, 0xee, 0xa5, 0xe4, 0xe9, 0x93, 0x39, 0x6f, 0x37, 0x41, 0xa8, 0x8c, 0x31, 0xa1, 0xba,
-	0x31, 0x6f, 0x88, 0xb4, 0x64, 0xbd, 0x96, 0x01, 0x9d, 0xdd, 0x23, 0xa1, 0x95, 0xb4, 0x08, 0xad,
-	0x2e, 0x43, 0x8e, 0x3e, 0x5c, 0x4c, 0x9b, 0x17, 0x80, 0x8f, 0x50, 0x63, 0x24, 0x74, 0x99, 0x19,
-	0x57, 0xd6, 0xd9, 0x54, 0x26, 0x4a, 0x9e, 0x0a, 0x6b, 0xce, 0xc8, 0xca, 0xb4, 0xf9, 0xcf, 0xd0,
-	0xd4, 0xdc, 0x5b, 0x7f, 0x99, 0xfe, 0x2d, 0xc3, 0xa5, 0x49, 0xd0, 0xa2, 0xfd, 0x31, 0x41, 0xb8,
-	0xbd, 0x50, 0x65, 0x96, 0x27, 0x0d, 0x89, 0x68, 0x67, 0x16, 0x7f, 0x3d, 0xbe, 0x99, 0x42, 0xbc,
-	0x78, 0xab, 0x2f, 0x22, 0x26, 0x3b, 0x7b, 0x66, 0xb3, 0x69, 0xe8, 0xa5, 0x9c, 0xfa, 0x04, 0x8a,
-	0xe9, 0xee, 0x42, 0x45, 0x90, 0x9d, 0xf8, 0x47, 0x91, 0xec, 0xd8, 0x14, 0xba, 0x8e, 0x4f, 0x38,
-	0x74, 0x99, 0xd9, 0xd0, 0x8d, 0x8c, 0xd5, 0x1f, 0x25, 0x80, 0x04, 0x14, 0x84, 0x04, 0xb5, 0xca,
-	0x27, 0x5a, 0x93, 0xb0, 0x67, 0x8d, 0x13, 0x03, 0xed, 0x42, 0xae, 0x87, 0x8f, 0x48, 0x6f, 0x8e,
-	0x9b, 0x78, 0x14, 0x5e, 0xdb, 0x67, 0x1e, 0x9c, 0xe1, 0x91, 0x7b, 0x65, 0x0b, 0x0a, 0xc2, 0xf4,
-	0x04, 0xf6, 0xa6, 0xf6, 0xcf, 0x8b, 0xc4, 0xbc, 0x0a, 0x0a, 0x2b, 0x0a, 0x2a, 0xc3, 0x4a, 0x9f,
-	0x04, 0x01, 0x3e, 0x8e, 0x8d, 0xe2, 0xa1, 0xda, 0x00, 0x85, 0x35, 0x2e, 0x35, 0xf1, 0x87, 0x6e,
-	0xe8, 0xf4, 0x47, 0x26, 0x7c, 0x88, 0xde, 0x83, 0xbc, 0x8b, 0xfb, 0x24, 0xf0, 0x70, 0x87, 0xf0,
-	0x5f, 0x7d, 0xc9, 0x04, 0x85, 0xda, 0xd4, 0x79, 0xdb, 0xc9, 0xa6, 0xae, 0xbe, 0x96, 0xe0, 0x62,
-	0x72, 0xa2, 0x03, 0xec, 0xd1, 0x2b, 0x97, 0x7d, 0xf3, 0x57, 0xea, 0xcd, 0x39, 0x80, 0x38, 0xc0,
-	0x9e, 0xc6, 0x3e, 0xf8, 0x8f, 0x20, 0xf6, 0x5d, 0x79, 0x0a, 0x90, 0x4c, 0x2e, 0xbf, 0x8d, 0xf7,
-	0xa0, 0x98, 0x2c, 0xec, 0x3b, 0x41, 0x48, 0x03, 0x8a, 0x99, 0xcf, 0x17, 0x90, 0xfd, 0xbb, 0xbf,
-	0xf2, 0x44, 0x61, 0x4b, 0x47, 0x39, 0xc6, 0xaf, 0x5b, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xf8,
-	0x97, 0xf9, 0x94, 0xe7, 0x14, 0x00, 0x00,
+	// 1986 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x5d, 0x93, 0xdb, 0x56,
+	0x19, 0x5e, 0x59, 0x96, 0x3f, 0x5e, 0x6f, 0x8c, 0x72, 0xa6, 0x14, 0xe1, 0x81, 0x90, 0x1a, 0x68,
+	0x33, 0xa5, 0xf1, 0x92, 0x4d, 0xca, 0x66, 0x1b, 0xa0, 0x78, 0x6d, 0x6d, 0xaa, 0x59, 0xaf, 0xed,
+	0x91, 0xbd, 0xc9, 0xa4, 0x0c, 0xed, 0x9c, 0xb5, 0x8e, 0xb7, 0xea, 0xda, 0x92, 0x90, 0xe4, 0xa6,
+	0xe6, 0x77, 0xc0, 0x05, 0xf7, 0xdc, 0x30, 0xc3, 0x0c, 0xc3, 0x15, 0x03, 0x37, 0x5c, 0x70, 0xd9,
+	0xe1, 0x27, 0xf0, 0x03, 0x98, 0x81, 0xff, 0xc0, 0x9c, 0xa3, 0xa3, 0x2f, 0x7b, 0x1d, 0xc9, 0xe9,
+	0x26, 0x37, 0xbb, 0x3a, 0x47, 0xef, 0xd7, 0x79, 0xbf, 0x9e, 0x57, 0xc7, 0xf0, 0x4d, 0xe7, 0xf2,
+	0x62, 0xcf, 0x5f, 0x3a, 0xc4, 0x0b, 0xfe, 0xb6, 0x1c, 0xd7, 0xf6, 0x6d, 0xf4, 0xad, 0xa9, 0xe9,
+	0x79, 0xa6, 0x6d, 0xb5, 0x9e, 0xdb, 0xee, 0xe5, 0x74, 0x66, 0x3f, 0xf7, 0x5a, 0xec, 0x75, 0xe3,
+	0x7b, 0x17, 0xb6, 0x7d, 0x31, 0x23, 0x7b, 0x8c, 0xec, 0x7c, 0x31, 0xdd, 0xf3, 0xcd, 0x39, 0xf1,
+	0x7c, 0x3c, 0x77, 0x02, 0xce, 0xc6, 0xad, 0x55, 0x02, 0x63, 0xe1, 0x62, 0x9f, 0x8a, 0x62, 0x3b,
+	0xcd, 0xaf, 0x04, 0xa8, 0x3c, 0xe5, 0x42, 0x51, 0x07, 0x2a, 0x73, 0xe2, 0x63, 0x03, 0xfb, 0x58,
+	0x11, 0x6e, 0x0b, 0x77, 0x6a, 0xfb, 0xef, 0xb4, 0x36, 0x68, 0x6e, 0x0d, 0xce, 0x3f, 0x27, 0x13,
+	0xff, 0x94, 0x93, 0xeb, 0x11, 0x23, 0x3a, 0x84, 0xa2, 0xe7, 0x90, 0x89, 0x52, 0x60, 0x02, 0x7e,
+	0xb8, 0x51, 0x40, 0xa8, 0x75, 0xe4, 0x90, 0x89, 0xce, 0x58, 0xd0, 0x87, 0x50, 0xf2, 0x7c, 0xec,
+	0x2f, 0x3c, 0x45, 0xcc, 0xd0, 0x1e, 0x31, 0x33, 0x72, 0x9d, 0xb3, 0x35, 0x7f, 0x2b, 0xc2, 0x6e,
+	0x52, 0x2e, 0xba, 0x05, 0x80, 0x1d, 0xf3, 0x09, 0x71, 0xa9, 0x14, 0x76, 0xa6, 0xaa, 0x9e, 0xd8,
+	0x41, 0xc7, 0x20, 0xf9, 0xd8, 0xbb, 0xf4, 0x94, 0xc2, 0x6d, 0xf1, 0x4e, 0x6d, 0xff, 0xc7, 0xb9,
+	0xac, 0x6d, 0x8d, 0x29, 0x8b, 0x6a, 0xf9, 0xee, 0x52, 0x0f, 0xd8, 0xa9, 0x1e, 0x7b, 0xe1, 0x3b,
+	0x0b, 0x9f, 0xbe, 0x62, 0xd6, 0x57, 0xf5, 0xc4, 0x0e, 0xba, 0x0d, 0x35, 0x83, 0x78, 0x13, 0xd7,
+	0x74, 0xa8, 0xef, 0x95, 0x22, 0x23, 0x48, 0x6e, 0x21, 0x05, 0xca, 0x53, 0xdb, 0x9d, 0x10, 0xcd,
+	0x50, 0x24, 0xf6, 0x36, 0x5c, 0x22, 0x04, 0x45, 0x0b, 0xcf, 0x89, 0x52, 0x62, 0xdb, 0xec, 0x19,
+	0x35, 0xa0, 0x62, 0x5a, 0x3e, 0x71, 0x2d, 0x3c, 0x53, 0xca, 0xb7, 0x85, 0x3b, 0x15, 0x3d, 0x5a,
+	0xa3, 0xfb, 0x50, 0xa6, 0x59, 0x60, 0x2f, 0x7c, 0xa5, 0xc2, 0xdc, 0xf8, 0xed, 0x56, 0x90, 0x04,
+	0xad, 0x30, 0x09, 0x5a, 0x5d, 0x9e, 0x04, 0x7a, 0x48, 0xd9, 0xf8, 0x25, 0x40, 0x7c, 0x2a, 0x24,
+	0x83, 0x78, 0x49, 0x96, 0xdc, 0x5f, 0xf4, 0x11, 0x1d, 0x80, 0xf4, 0x05, 0x9e, 0x2d, 0x08, 0x0f,
+	0xeb, 0x5b, 0x1b, 0x1d, 0x45, 0xa5, 0xb0, 0x90, 0x06, 0xf4, 0x1f, 0x14, 0x1e, 0x0a, 0xcd, 0x3f,
+	0x89, 0x50, 0x4f, 0x47, 0x0c, 0x1d, 0x47, 0xa1, 0xa6, 0x4a, 0xea, 0xfb, 0xad, 0x9c, 0xa1, 0x6e,
+	0xa5, 0x23, 0x8e, 0x1e, 0x42, 0x75, 0xe1, 0x18, 0xd8, 0x27, 0x46, 0xdb, 0xe7, 0xb6, 0x35, 0xd6,
+	0x8e, 0x3b, 0x0e, 0x8b, 0x42, 0x8f, 0x89, 0xd1, 0x47, 0x61, 0xe8, 0x45, 0x16, 0xfa, 0xfd, 0xbc,
+	0x06, 0xac, 0x07, 0xff, 0x01, 0x48, 0xc4, 0x75, 0x6d, 0x97, 0x85, 0xb5, 0xb6, 0x7f, 0x6b, 0xa3,
+	0x24, 0x95, 0x52, 0xe9, 0x01, 0x71, 0xe3, 0x57, 0x19, 0x1e, 0x3f, 0x4c, 0x7b, 0xfc, 0xfb, 0x2f,
+	0xf6, 0x78, 0xe0, 0x95, 0x84, 0xcf, 0x0f, 0xa1, 0xc4, 0x5d, 0x5d, 0x83, 0xf2, 0x50, 0xed, 0x77,
+	0xb5, 0xfe, 0x63, 0x79, 0x07, 0x55, 0x41, 0xd2, 0xd5, 0x76, 0xf7, 0x99, 0x5c, 0x40, 0x00, 0xa5,
+	0xe3, 0xb6, 0xd6, 0x53, 0xbb, 0xb2, 0x48, 0x69, 0xba, 0x6a, 0x4f, 0x1d, 0xab, 0x5d, 0xb9, 0xd8,
+	0xfc, 0x8f, 0x00, 0x28, 0x3c, 0xb4, 0x66, 0x7d, 0x61, 0x4f, 0x58, 0xae, 0x5c, 0x4f, 0x77, 0xe8,
+	0xa4, 0xba, 0xc3, 0x5e, 0xa6, 0xd3, 0x63, 0xfd, 0x89, 0x3e, 0xa1, 0xad, 0xf4, 0x89, 0x7b, 0xdb,
+	0x88, 0x49, 0x77, 0x8c, 0x7f, 0x89, 0xf0, 0xe6, 0xd5, 0xba, 0x68, 0x4d, 0x87, 0xe2, 0x34, 0x23,
+	0xec, 0x1d, 0xf1, 0x0e, 0x1a, 0x41, 0xc9, 0xb4, 0x9c, 0x85, 0x1f, 0x36, 0x8f, 0x47, 0x5b, 0x1e,
+	0xa6, 0xa5, 0x31, 0xee, 0x20, 0x95, 0xb8, 0x28, 0x5a, 0xd8, 0x0e, 0x76, 0x89, 0xe5, 0x6b, 0x06,
+	0x6f, 0x23, 0xd1, 0x3a, 0x59, 0xd8, 0x52, 0xde, 0xc2, 0x46, 0x0f, 0xa0, 0xec, 0xf9, 0xd8, 0xf5,
+	0xdb, 0xbe, 0x52, 0xca, 0x2c, 0x8f, 0x90, 0x14, 0xed, 0x81, 0x64, 0x90, 0x19, 0x5e, 0x2a, 0xe5,
+	0x2c, 0x45, 0x01, 0x1d, 0x7a, 0x1b, 0xea, 0xa6, 0x41, 0xe6, 0x8e, 0xed, 0x13, 0x6b, 0xb2, 0x3c,
+	0x21, 0x4b, 0xd6, 0x7b, 0xaa, 0xfa, 0xca, 0x6e, 0xe3, 0x13, 0xa8, 0x25, 0x8e, 0xfd, 0xb5, 0xd2,
+	0x7e, 0xe9, 0x10, 0xe3, 0x09, 0x25, 0x4d, 0xa6, 0xfd, 0x9f, 0xcb, 0xa0, 0x6c, 0x0a, 0x3a, 0x1a,
+	0xae, 0x34, 0x9d, 0x87, 0x5b, 0xe7, 0xcd, 0xf5, 0xb5, 0x1f, 0x3d, 0xdd, 0x7e, 0x7e, 0xba, 0xbd,
+	0x29, 0xeb, 0x8d, 0xe8, 0x11, 0x94, 0x02, 0xcc, 0x51, 0x8a, 0xf9, 0x9d, 0xc7, 0x59, 0xd0, 0x05,
+	0xec, 0x1a, 0x4b, 0x0b, 0xcf, 0xcd, 0x09, 0x13, 0xac, 0x48, 0xcc, 0xae, 0xce, 0xf6, 0x76, 0x75,
+	0x13, 0x52, 0x02, 0xf3, 0x52, 0x82, 0xe3, 0x76, 0x59, 0xda, 0xa2, 0x5d, 0x52, 0x4f, 0xbb, 0xc4,
+	0x77, 0x4d, 0xe6, 0xe9, 0x72, 0xb6, 0xa7, 0x23, 0x62, 0xf4, 0x13, 0x5a, 0x52, 0x0b, 0x8f, 0x31,
+	0x56, 0x32, 0x19, 0x23, 0x5a, 0x74, 0x00, 0xd5, 0xe0, 0xf9, 0xd8, 0x76, 0x95, 0x6a, 0x56, 0x1d,
+	0xc4, 0xb4, 0x0d, 0x9c, 0xd1, 0xd9, 0x7f, 0x96, 0x4e, 0xf1, 0x77, 0x5e, 0xd8, 0xd9, 0x63, 0xf7,
+	0x26, 0xd2, 0xbc, 0xf1, 0x09, 0xdc, 0x5c, 0x73, 0xf3, 0x15, 0x9a, 0xee, 0xa7, 0x35, 0x7d, 0xf7,
+	0x85, 0x9a, 0x92, 0x65, 0x64, 0x26, 0xd1, 0xe3, 0xac, 0x7f, 0xd2, 0x1f, 0x3c, 0xed, 0xcb, 0x3b,
+	0xe8, 0x06, 0x54, 0x47, 0x9d, 0x8f, 0xd4, 0xee, 0x19, 0x45, 0x0d, 0x01, 0x7d, 0x03, 0x6a, 0x5a,
+	0xff, 0xd3, 0xa1, 0x3e, 0x78, 0xac, 0xab, 0xa3, 0x91, 0x5c, 0x60, 0xef, 0xcf, 0x3a, 0x1d, 0x55,
+	0xed, 0x32, 0x54, 0x89, 0x11, 0xa6, 0x48, 0xe5, 0xb4, 0x8f, 0x06, 0x3a, 0x45, 0x18, 0x89, 0xbe,
+	0x18, 0xb6, 0xcf, 0x46, 0x6a, 0x57, 0x2e, 0x35, 0xff, 0x27, 0x80, 0xdc, 0x25, 0x0e, 0xb1, 0x0c,
+	0xda, 0x23, 0x3a, 0xb6, 0x35, 0x35, 0x2f, 0xd0, 0x08, 0x2a, 0x2e, 0xf9, 0xf5, 0xc2, 0x74, 0x09,
+	0xad, 0x55, 0x9a, 0x88, 0x07, 0x1b, 0x6d, 0x5f, 0x65, 0x6e, 0xe9, 0x9c, 0x33, 0x48, 0xbe, 0x48,
+	0x10, 0x7a, 0x03, 0x24, 0xfc, 0x1c, 0x9b, 0x41, 0xa1, 0x4a, 0x7a, 0xb0, 0x68, 0x58, 0x70, 0x23,
+	0xc5, 0x70, 0x85, 0x1b, 0x1f, 0xa7, 0xdd, 0x78, 0xef, 0x85, 0x6e, 0x8c, 0xcd, 0x19, 0x62, 0x17,
+	0xcf, 0x89, 0x4f, 0xdc, 0x14, 0x30, 0xff, 0x43, 0x80, 0x22, 0xa5, 0xbb, 0x1e, 0x3c, 0x7d, 0x3f,
+	0x85, 0xa7, 0x39, 0xc6, 0xb2, 0x00, 0x41, 0x1f, 0xad, 0x20, 0x68, 0xae, 0xe9, 0x22, 0xc4, 0xcc,
+	0xaf, 0x4a, 0x50, 0x09, 0xe5, 0xd1, 0xc9, 0x76, 0xba, 0xb0, 0x26, 0x2c, 0x41, 0xc9, 0x94, 0x7b,
+	0x2d, 0xb9, 0x85, 0xd4, 0x15, 0x9c, 0xbc, 0x9b, 0x69, 0xe4, 0x95, 0xc8, 0x78, 0x92, 0x48, 0x89,
+	0xa0, 0x67, 0xee, 0x65, 0x0b, 0xca, 0x4c, 0x85, 0x62, 0x22, 0x15, 0x12, 0xfd, 0x53, 0xda, 0xbe,
+	0x7f, 0x7e, 0x00, 0x12, 0xed, 0x39, 0x4b, 0xde, 0xd6, 0x7e, 0xb0, 0x91, 0x57, 0xa7, 0x54, 0x43,
+	0x7b, 0x66, 0x4e, 0x96, 0x7a, 0xc0, 0x92, 0x44, 0xf6, 0x72, 0x6e, 0x64, 0xef, 0x42, 0xd5, 0xb6,
+	0x8e, 0xb1, 0x39, 0x5b, 0xb8, 0x84, 0x37, 0xb6, 0xb7, 0x37, 0x2a, 0xe5, 0x74, 0x5c, 0x6d, 0xcc,
+	0x88, 0x0e, 0xa0, 0xf8, 0xfc, 0x33, 0x62, 0x29, 0xd5, 0xfc, 0x27, 0x66, 0x0c, 0xa8, 0x07, 0xe0,
+	0x5d, 0x9a, 0x4e, 0x20, 0x51, 0x01, 0x06, 0xa8, 0xef, 0x65, 0x47, 0x64, 0x14, 0xf1, 0xe8, 0x09,
+	0xfe, 0x57, 0x3d, 0x17, 0xbc, 0xf6, 0x2a, 0xbf, 0x03, 0x10, 0x9f, 0x14, 0x95, 0x41, 0x6c, 0xf7,
+	0x9f, 0xc9, 0x3b, 0xec, 0xa1, 0xd7, 0x93, 0x05, 0x54, 0x81, 0x62, 0x7f, 0xd0, 0x57, 0xe5, 0x42,
+	0xf3, 0x0f, 0x05, 0x80, 0xb8, 0xc8, 0xd0, 0xd1, 0xca, 0x8c, 0xf2, 0x6e, 0x8e, 0xca, 0xbc, 0xbe,
+	0xa9, 0xe4, 0x01, 0x48, 0x53, 0x56, 0xc7, 0x62, 0x06, 0x36, 0x1f, 0x53, 0x2a, 0x3d, 0x20, 0x7e,
+	0xb9, 0x0f, 0xa0, 0xe6, 0x7b, 0x49, 0x8c, 0x19, 0x8d, 0xdb, 0x0c, 0x1b, 0x12, 0x5f, 0x28, 0x42,
+	0x02, 0x3f, 0x0a, 0xcd, 0x7f, 0x0a, 0xa0, 0x6c, 0x72, 0x3c, 0x1a, 0x43, 0x91, 0x2a, 0xe0, 0x2e,
+	0xfb, 0xc5, 0xd6, 0x91, 0x4b, 0x60, 0x08, 0x4d, 0x1f, 0x9d, 0x49, 0x63, 0x4d, 0x62, 0x66, 0x62,
+	0x8f, 0xb9, 0xb0, 0xaa, 0x07, 0x8b, 0xe6, 0x23, 0xa8, 0xa7, 0xa9, 0x69, 0x2c, 0xbb, 0xed, 0x71,
+	0x5b, 0xde, 0xa1, 0x07, 0xe9, 0x0c, 0xfa, 0x63, 0x7d, 0x40, 0x43, 0x8c, 0xa0, 0xde, 0x7d, 0xd6,
+	0x6f, 0x9f, 0x6a, 0x9d, 0x4f, 0x07, 0x67, 0xe3, 0xe1, 0xd9, 0x58, 0x2e, 0x34, 0xff, 0x2d, 0x40,
+	0x3d, 0x8d, 0xea, 0xd7, 0x03, 0x03, 0x1f, 0xa6, 0x60, 0xe0, 0x47, 0x39, 0x27, 0x8a, 0x04, 0x20,
+	0xa8, 0x2b, 0x80, 0x70, 0x37, 0xaf, 0x88, 0x34, 0x34, 0xfc, 0xb7, 0x00, 0x68, 0x5d, 0x47, 0x9c,
+	0x56, 0xc2, 0x36, 0x69, 0xf5, 0x26, 0x94, 0xe8, 0x5c, 0xab, 0x19, 0x3c, 0x00, 0x7c, 0x85, 0x06,
+	0x11, 0xa0, 0x88, 0x19, 0xa3, 0xc1, 0xba, 0x29, 0x57, 0x42, 0x4b, 0x13, 0x76, 0xcd, 0x88, 0x4a,
+	0x33, 0xf8, 0xf5, 0x4c, 0x6a, 0xef, 0xa5, 0x3e, 0xbe, 0x5e, 0xf9, 0xd7, 0xce, 0xef, 0x45, 0x78,
+	0xe3, 0xaa, 0x78, 0xa0, 0xde, 0x4a, 0x17, 0x79, 0xb0, 0x55, 0x38, 0xaf, 0xaf, 0x9f, 0xc4, 0x88,
+	0x2a, 0x6e, 0x8f, 0xa8, 0x2f, 0xd5, 0x56, 0xe8, 0x17, 0x34, 0xf6, 0x7d, 0x32, 0x77, 0x7c, 0x8f,
+	0x45, 0x4a, 0xd2, 0xa3, 0x75, 0xf3, 0xf3, 0x57, 0x3b, 0xd6, 0xd2, 0x3e, 0x76, 0xa2, 0x0d, 0x87,
+	0x6c, 0xae, 0xfd, 0x18, 0xea, 0xe9, 0x72, 0x45, 0x75, 0x28, 0x98, 0xe1, 0x45, 0x42, 0xc1, 0x34,
+	0xa8, 0x5b, 0x27, 0x2e, 0xe1, 0x6e, 0x15, 0xb3, 0xdd, 0x1a, 0x11, 0x37, 0xff, 0x2e, 0x00, 0xc4,
+	0x0e, 0xa3, 0x37, 0x84, 0x51, 0xfb, 0xab, 0xc6, 0xcd, 0x2b, 0xce, 0xac, 0x5d, 0x9e, 0x34, 0xe8,
+	0x31, 0x94, 0x66, 0xf8, 0x9c, 0xcc, 0x72, 0x8c, 0x50, 0x91, 0xf8, 0x56, 0x8f, 0x71, 0xf0, 0x92,
+	0x09, 0xd8, 0x1b, 0x87, 0x50, 0x4b, 0x6c, 0x5f, 0x91, 0xd9, 0x29, 0xfd, 0xd5, 0x64, 0xd2, 0xbe,
+	0x0f, 0x12, 0x0b, 0x18, 0x35, 0x7b, 0x62, 0x1b, 0x91, 0xd9, 0xf4, 0x99, 0x5e, 0x83, 0xce, 0x89,
+	0xe7, 0xe1, 0x8b, 0x90, 0x31, 0x5c, 0x36, 0x07, 0x20, 0xb1, 0xee, 0x40, 0x49, 0xdc, 0x85, 0x45,
+	0x4b, 0x2c, 0x24, 0xe1, 0x4b, 0xf4, 0x1d, 0xa8, 0xd2, 0xdb, 0x51, 0xcf, 0xc1, 0x13, 0xc2, 0x6f,
+	0x4f, 0xe2, 0x0d, 0xea, 0x7e, 0xad, 0xcb, 0x6b, 0xbb, 0xa0, 0x75, 0x9b, 0x7f, 0x15, 0xe0, 0x46,
+	0x7c, 0xca, 0x53, 0xec, 0xd0, 0x09, 0x80, 0x3d, 0xf3, 0x4f, 0x8e, 0x7b, 0x39, 0x9c, 0x73, 0x8a,
+	0x9d, 0x16, 0x7b, 0xe0, 0x1f, 0xe2, 0xec, 0x99, 0xde, 0xed, 0xc5, 0x9b, 0xd7, 0x5f, 0xf6, 0x27,
+	0x50, 0x8f, 0x5f, 0xf4, 0x4c, 0xcf, 0xa7, 0x02, 0x93, 0x96, 0xe7, 0x13, 0xc8, 0xfe, 0x35, 0xff,
+	0x26, 0x40, 0x2d, 0x31, 0x92, 0xd2, 0x81, 0x7e, 0x8e, 0xbf, 0x6c, 0x87, 0x25, 0x24, 0xb0, 0x12,
+	0x4a, 0x6e, 0xd1, 0x56, 0x78, 0x8e, 0x27, 0x97, 0xf6, 0x74, 0xaa, 0x14, 0x32, 0x5b, 0x21, 0xa7,
+	0x44, 0x87, 0x00, 0x73, 0xfc, 0xe5, 0x11, 0xe7, 0x13, 0xb3, 0xf8, 0x12, 0xc4, 0x2c, 0xe0, 0xd4,
+	0xc0, 0x01, 0xbd, 0x38, 0x17, 0x59, 0xc0, 0x83, 0x65, 0xf3, 0x77, 0x02, 0xdc, 0x48, 0x4d, 0xb6,
+	0x14, 0xc7, 0x30, 0xfb, 0xf2, 0xe0, 0x8d, 0xef, 0x6e, 0xbe, 0x89, 0xb8, 0xd5, 0x66, 0x4c, 0x3a,
+	0x67, 0xa6, 0x4d, 0x64, 0x8a, 0x67, 0x33, 0x6a, 0x3c, 0x4f, 0xb2, 0x68, 0xdd, 0x7c, 0x0b, 0x4a,
+	0x01, 0x35, 0x1d, 0x55, 0x58, 0xf1, 0xcb, 0x3b, 0x68, 0x17, 0x2a, 0x14, 0xf9, 0xb5, 0xfe, 0x99,
+	0x2a, 0x0b, 0xec, 0x57, 0x95, 0xd1, 0xe4, 0x33, 0x62, 0x2c, 0x66, 0xe4, 0xf5, 0xfe, 0xaa, 0x12,
+	0x6a, 0x7d, 0xa9, 0x5f, 0x55, 0x22, 0xe6, 0x34, 0xa8, 0xff, 0x51, 0x84, 0xdd, 0xa4, 0xdc, 0xcc,
+	0x9b, 0x51, 0x5a, 0xd8, 0xae, 0x6d, 0x71, 0xcf, 0xb1, 0x67, 0x7a, 0x67, 0x9b, 0x02, 0xed, 0x7b,
+	0xb9, 0x8e, 0xb0, 0xe9, 0x8e, 0x94, 0x96, 0xfb, 0x6f, 0x6c, 0x8b, 0xf0, 0x72, 0x8e, 0xd6, 0x88,
+	0xc0, 0xcd, 0x89, 0x6d, 0x4d, 0x16, 0xae, 0xcb, 0x26, 0xbc, 0xe0, 0xe3, 0x44, 0x62, 0xa9, 0x70,
+	0x90, 0x4f, 0x63, 0x67, 0x95, 0x5d, 0x5f, 0x97, 0xf8, 0xca, 0x81, 0xfd, 0x00, 0x6e, 0xae, 0xd9,
+	0xc1, 0xd2, 0xad, 0xd7, 0x1b, 0x3c, 0x95, 0x77, 0x18, 0x04, 0x0d, 0xf4, 0x23, 0x8d, 0xc2, 0x55,
+	0x0d, 0xca, 0xba, 0x3a, 0xec, 0xb5, 0x3b, 0xf4, 0x6b, 0xe2, 0x2f, 0x22, 0xd4, 0xd3, 0x61, 0xdc,
+	0xe2, 0xa7, 0x96, 0x34, 0xe3, 0xf5, 0x4d, 0x01, 0x47, 0x50, 0xf7, 0xb8, 0x68, 0xe3, 0xcc, 0xf2,
+	0xcd, 0x59, 0x0e, 0xb4, 0x5b, 0xe1, 0x40, 0xef, 0x82, 0x3c, 0xc3, 0x9e, 0xaf, 0xad, 0xcf, 0x69,
+	0x6b, 0xfb, 0x34, 0x3f, 0xe7, 0xa6, 0xe7, 0x11, 0x43, 0x5f, 0x58, 0xc1, 0x10, 0x20, 0xea, 0x89,
+	0x1d, 0xf4, 0x73, 0xd8, 0xa5, 0x3c, 0xa7, 0x6c, 0x27, 0xd7, 0xc5, 0x78, 0x8a, 0x3e, 0xb4, 0x25,
+	0x58, 0xeb, 0x04, 0x7b, 0xb6, 0xa5, 0x94, 0x63, 0x5b, 0x92, 0xfb, 0xb4, 0x5b, 0xf0, 0x38, 0x00,
+	0x94, 0xda, 0x9d, 0xb1, 0xf6, 0x44, 0x95, 0x77, 0x92, 0xbf, 0xb7, 0x08, 0x47, 0xe5, 0x8f, 0x25,
+	0xe6, 0xff, 0xf3, 0x12, 0xd3, 0x7c, 0xff, 0xff, 0x03, 0x00, 0x74, 0x9d, 0x8e, 0x0c, 0x06, 0x1e,
+	0x00, 0x00,
 }
@@ -8,13 +8,20 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/graph"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/robfig/cron"
 	"gonum.org/v1/gonum/graph/topo"
 )
 
+// MaxIdempotencyKeyLength is the maximum length of the idempotency key of an invocation.
+const MaxIdempotencyKeyLength = 255
+
 var (
 	ErrObjectEmpty                  = errors.New("no object provided")
 	ErrInvalidAPIVersion            = errors.New("unknown API version")
@@ -34,6 +41,18 @@ var (
 	ErrNoWorkflow                   = errors.New("workflow id is required")
 	ErrNoID                         = errors.New("id is required")
 	ErrNoStatus                     = errors.New("status is required")
+	ErrInvalidTimeout               = errors.New("timeout should be a positive duration")
+	ErrInvalidRetryPolicy           = errors.New("invalid retry policy")
+	ErrInvalidFailurePolicy         = errors.New("invalid failure policy")
+	ErrInvalidSkipPolicy            = errors.New("invalid skip policy")
+	ErrInvalidAwait                 = errors.New("number of dependencies to await should not be negative")
+	ErrInvalidDependencyType        = errors.New("invalid dependency type")
+	ErrInvalidCron                  = errors.New("invalid cron expression")
+	ErrInvalidTimezone              = errors.New("invalid timezone")
+	ErrInvalidConcurrencyPolicy     = errors.New("invalid concurrency policy")
+	ErrInvalidStart                 = errors.New("invalid start of invocation")
+	ErrIdempotencyKeyTooLong        = fmt.Errorf("idempotency key should not exceed %d characters",
+		MaxIdempotencyKeyLength)
 )
 
 type Error struct {
@@ -149,6 +168,29 @@ func WorkflowSpec(spec *types.WorkflowSpec) error {
 		}
 	}
 
+	// Check the fallback tasks
+	fallbacks := map[string]string{}
+	for taskID, task := range spec.GetTasks() {
+		fallback := task.GetOnFailure().GetFallback()
+		if len(fallback) == 0 {
+			continue
+		}
+		fallbackTask, ok := refTable[fallback]
+		if !ok {
+			errs.append(fmt.Errorf("%v: unknown fallback task '%v->%v'", ErrInvalidFailurePolicy, taskID, fallback))
+			continue
+		}
+		if _, ok := fallbackTask.GetRequires()[taskID]; !ok {
+			errs.append(fmt.Errorf("%v: fallback task '%v' should depend on '%v'", ErrInvalidFailurePolicy, fallback,
+				taskID))
+		}
+		if other, ok := fallbacks[fallback]; ok {
+			errs.append(fmt.Errorf("%v: task '%v' is the fallback of both '%v' and '%v'", ErrInvalidFailurePolicy,
+				fallback, other, taskID))
+		}
+		fallbacks[fallback] = taskID
+	}
+
 	// Check for circular dependencies
 	dg := graph.Parse(graph.NewTaskSpecIterator(spec.Tasks))
 	if len(topo.DirectedCyclesIn(dg)) > 0 {
@@ -160,6 +202,8 @@ func WorkflowSpec(spec *types.WorkflowSpec) error {
 		errs.append(ErrWorkflowWithoutStartTasks)
 	}
 
+	errs.append(timeout(spec.GetTimeout()))
+
 	return errs.getOrNil()
 }
 
@@ -175,9 +219,64 @@ func TaskSpec(spec *types.TaskSpec) error {
 		errs.append(ErrTaskRequiresFnRef)
 	}
 
+	if spec.Await < 0 {
+		errs.append(ErrInvalidAwait)
+	}
+	for dep, params := range spec.GetRequires() {
+		if _, ok := types.TaskDependencyParameters_DependencyType_name[int32(params.GetType())]; !ok {
+			errs.append(fmt.Errorf("%v: unknown type %d of dependency '%v'", ErrInvalidDependencyType,
+				params.GetType(), dep))
+		}
+	}
+
+	errs.append(retryPolicy(spec.GetRetry()))
+	errs.append(timeout(spec.GetTimeout()))
+	errs.append(failurePolicy(spec.GetOnFailure()))
+	if _, ok := types.TaskSpec_SkipPolicy_name[int32(spec.SkipPolicy)]; !ok {
+		errs.append(fmt.Errorf("%v: unknown policy %d", ErrInvalidSkipPolicy, spec.SkipPolicy))
+	}
+
 	return errs.getOrNil()
 }
 
+func retryPolicy(policy *types.RetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.MaxAttempts < 1 {
+		return fmt.Errorf("%v: maxAttempts should be at least 1, but was %d", ErrInvalidRetryPolicy,
+			policy.MaxAttempts)
+	}
+	var backoff, maxBackoff time.Duration
+	var err error
+	if policy.Backoff != nil {
+		backoff, err = ptypes.Duration(policy.Backoff)
+		if err != nil || backoff <= 0 {
+			return fmt.Errorf("%v: backoff should be a positive duration", ErrInvalidRetryPolicy)
+		}
+	}
+	if policy.MaxBackoff != nil {
+		maxBackoff, err = ptypes.Duration(policy.MaxBackoff)
+		if err != nil || maxBackoff <= 0 {
+			return fmt.Errorf("%v: maxBackoff should be a positive duration", ErrInvalidRetryPolicy)
+		}
+		if maxBackoff < backoff {
+			return fmt.Errorf("%v: maxBackoff should not be less than the backoff", ErrInvalidRetryPolicy)
+		}
+	}
+	return nil
+}
+
+func failurePolicy(policy *types.FailurePolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if _, ok := types.FailurePolicy_Action_name[int32(policy.Action)]; !ok {
+		return fmt.Errorf("%v: unknown action %d", ErrInvalidFailurePolicy, policy.Action)
+	}
+	return nil
+}
+
 func DynamicTaskSpec(task *types.TaskSpec) error {
 	err := TaskSpec(task)
 	if err != nil {
@@ -245,9 +344,81 @@ func WorkflowInvocationSpec(spec *types.WorkflowInvocationSpec) error {
 		errs.append(ErrNoWorkflow)
 	}
 
+	errs.append(timeout(spec.GetTimeout()))
+	errs.append(invocationStart(spec))
+
+	if len(spec.GetIdempotencyKey()) > MaxIdempotencyKeyLength {
+		errs.append(ErrIdempotencyKeyTooLong)
+	}
+
+	return errs.getOrNil()
+}
+
+// invocationStart validates the (optional) deferred start of the invocation.
+func invocationStart(spec *types.WorkflowInvocationSpec) error {
+	if spec.GetStartAt() != nil && spec.GetDelay() != nil {
+		return fmt.Errorf("%v: only one of startAt and delay can be specified", ErrInvalidStart)
+	}
+	if spec.GetStartAt() != nil {
+		_, err := ptypes.Timestamp(spec.GetStartAt())
+		if err != nil {
+			return fmt.Errorf("%v: %v", ErrInvalidStart, err)
+		}
+	}
+	if spec.GetDelay() != nil {
+		delay, err := ptypes.Duration(spec.GetDelay())
+		if err != nil {
+			return fmt.Errorf("%v: %v", ErrInvalidStart, err)
+		}
+		if delay < 0 {
+			return fmt.Errorf("%v: delay '%v' should not be negative", ErrInvalidStart, delay)
+		}
+	}
+	return nil
+}
+
+func ScheduleSpec(spec *types.ScheduleSpec) error {
+	errs := Error{subject: "ScheduleSpec"}
+
+	if spec == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if len(spec.WorkflowId) == 0 {
+		errs.append(ErrNoWorkflow)
+	}
+
+	if _, err := cron.ParseStandard(spec.Cron); err != nil {
+		errs.append(fmt.Errorf("%v: %v", ErrInvalidCron, err))
+	}
+
+	if _, err := spec.Location(); err != nil {
+		errs.append(fmt.Errorf("%v: %v", ErrInvalidTimezone, err))
+	}
+
+	if _, ok := types.ScheduleSpec_ConcurrencyPolicy_name[int32(spec.ConcurrencyPolicy)]; !ok {
+		errs.append(fmt.Errorf("%v: '%v'", ErrInvalidConcurrencyPolicy, spec.ConcurrencyPolicy))
+	}
+
 	return errs.getOrNil()
 }
 
+// timeout validates the (optional) timeout.
+func timeout(d *duration.Duration) error {
+	if d == nil {
+		return nil
+	}
+	timeout, err := ptypes.Duration(d)
+	if err != nil {
+		return fmt.Errorf("%v: %v", ErrInvalidTimeout, err)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("%v: '%v'", ErrInvalidTimeout, timeout)
+	}
+	return nil
+}
+
 func TaskInvocationSpec(spec *types.TaskInvocationSpec) error {
 	errs := Error{subject: "TaskInvocationSpec"}
 
@@ -267,6 +438,8 @@ func TaskInvocationSpec(spec *types.TaskInvocationSpec) error {
 	if spec.FnRef == nil {
 		errs.append(ErrNoFnRef)
 	}
+
+	errs.append(timeout(spec.GetTimeout()))
 	return errs.getOrNil()
 }
 
@@ -1,9 +1,12 @@
 package validate
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,3 +68,146 @@ func TestWorkflowSpecInvalidCircularDependency(t *testing.T) {
 	spec.Tasks["first"].Require("last")
 	assert.Error(t, WorkflowSpec(spec))
 }
+
+func TestWorkflowSpecInvalidTimeout(t *testing.T) {
+	spec := validSpec()
+	spec.Timeout = ptypes.DurationProto(-time.Second)
+	assert.Error(t, WorkflowSpec(spec))
+
+	spec.Timeout = ptypes.DurationProto(time.Hour)
+	assert.NoError(t, WorkflowSpec(spec))
+}
+
+func TestWorkflowSpecInvalidFallback(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["first"].OnFailure = &types.FailurePolicy{Fallback: "nonExistent"}
+	assert.Error(t, WorkflowSpec(spec))
+
+	// The fallback task should depend on the task of which it handles the failure.
+	spec.Tasks["first"].OnFailure.Fallback = "last"
+	assert.Error(t, WorkflowSpec(spec))
+
+	spec.Tasks["first"].OnFailure.Fallback = "middle"
+	assert.NoError(t, WorkflowSpec(spec))
+}
+
+func TestTaskSpecInvalidRetryPolicy(t *testing.T) {
+	spec := &types.TaskSpec{
+		FunctionRef: "fn",
+		Retry:       &types.RetryPolicy{},
+	}
+	assert.Error(t, TaskSpec(spec))
+
+	spec.Retry.MaxAttempts = 3
+	spec.Retry.Backoff = ptypes.DurationProto(time.Minute)
+	spec.Retry.MaxBackoff = ptypes.DurationProto(time.Second)
+	assert.Error(t, TaskSpec(spec))
+
+	spec.Retry.MaxBackoff = ptypes.DurationProto(time.Hour)
+	assert.NoError(t, TaskSpec(spec))
+}
+
+func TestTaskSpecInvalidTimeout(t *testing.T) {
+	spec := &types.TaskSpec{
+		FunctionRef: "fn",
+		Timeout:     ptypes.DurationProto(0),
+	}
+	assert.Error(t, TaskSpec(spec))
+
+	spec.Timeout = ptypes.DurationProto(time.Second)
+	assert.NoError(t, TaskSpec(spec))
+}
+
+func TestTaskSpecInvalidFailurePolicy(t *testing.T) {
+	spec := &types.TaskSpec{
+		FunctionRef: "fn",
+		OnFailure:   &types.FailurePolicy{Action: 42},
+	}
+	assert.Error(t, TaskSpec(spec))
+
+	spec.OnFailure.Action = types.FailurePolicy_CONTINUE
+	assert.NoError(t, TaskSpec(spec))
+}
+
+func TestTaskSpecInvalidSkipPolicy(t *testing.T) {
+	spec := &types.TaskSpec{
+		FunctionRef: "fn",
+		SkipPolicy:  42,
+	}
+	assert.Error(t, TaskSpec(spec))
+
+	spec.SkipPolicy = types.TaskSpec_NONE
+	assert.NoError(t, TaskSpec(spec))
+}
+
+func TestTaskSpecInvalidDependencies(t *testing.T) {
+	spec := &types.TaskSpec{
+		FunctionRef: "fn",
+		Requires: map[string]*types.TaskDependencyParameters{
+			"foo": {Type: 42},
+			"bar": nil,
+		},
+		Await: -1,
+	}
+	assert.Error(t, TaskSpec(spec))
+
+	spec.Await = 1
+	assert.Error(t, TaskSpec(spec))
+
+	spec.Requires["foo"].Type = types.TaskDependencyParameters_CONTROL
+	assert.NoError(t, TaskSpec(spec))
+}
+
+func TestScheduleSpec(t *testing.T) {
+	spec := &types.ScheduleSpec{
+		WorkflowId:        "wf-123",
+		Cron:              "0 2 * * *",
+		Timezone:          "Europe/Amsterdam",
+		ConcurrencyPolicy: types.ScheduleSpec_FORBID,
+	}
+	assert.NoError(t, ScheduleSpec(spec))
+
+	spec.Cron = "@every 1h"
+	assert.NoError(t, ScheduleSpec(spec))
+
+	invalid := []*types.ScheduleSpec{
+		nil,
+		{Cron: "@daily"},
+		{WorkflowId: "wf-123", Cron: "* * *"},
+		{WorkflowId: "wf-123", Cron: "@daily", Timezone: "Mars/Olympus_Mons"},
+		{WorkflowId: "wf-123", Cron: "@daily", ConcurrencyPolicy: 42},
+	}
+	for _, spec := range invalid {
+		assert.Error(t, ScheduleSpec(spec), "%v", spec)
+	}
+}
+
+func TestWorkflowInvocationSpecInvalidStart(t *testing.T) {
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId: "wf-123",
+		Delay:      ptypes.DurationProto(-time.Second),
+	}
+	assert.Error(t, WorkflowInvocationSpec(spec))
+
+	spec.Delay = ptypes.DurationProto(time.Minute)
+	assert.NoError(t, WorkflowInvocationSpec(spec))
+
+	spec.StartAt = ptypes.TimestampNow()
+	assert.Error(t, WorkflowInvocationSpec(spec))
+
+	spec.Delay = nil
+	assert.NoError(t, WorkflowInvocationSpec(spec))
+}
+
+func TestWorkflowInvocationSpecIdempotencyKey(t *testing.T) {
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId:     "wf-123",
+		IdempotencyKey: "request-1",
+	}
+	assert.NoError(t, WorkflowInvocationSpec(spec))
+
+	spec.IdempotencyKey = strings.Repeat("a", MaxIdempotencyKeyLength+1)
+	err := WorkflowInvocationSpec(spec)
+	assert.Error(t, err)
+	assert.True(t, err.(Error).Contains(ErrIdempotencyKeyTooLong))
+}
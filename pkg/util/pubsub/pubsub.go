@@ -7,12 +7,24 @@ import (
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
 	defaultSubscriptionBuffer = 10
 )
 
+var messagesDropped = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "workflows",
+	Subsystem: "pubsub",
+	Name:      "messages_dropped_total",
+	Help:      "Count of messages that were dropped, because the buffer of the subscription was full.",
+})
+
+func init() {
+	prometheus.MustRegister(messagesDropped)
+}
+
 type Msg interface {
 	Labels() labels.Labels
 	CreatedAt() time.Time
@@ -136,6 +148,7 @@ func (pu *DefaultPublisher) Publish(msg Msg) error {
 		default:
 			// Drop message if subscribers channel is full
 			// Future: allow subscribers to specify in options what should happen when their channel is full.
+			messagesDropped.Inc()
 		}
 	}
 	return nil
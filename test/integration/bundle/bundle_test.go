@@ -2,7 +2,9 @@
 package bundle
 
 import (
+	"bufio"
 	"context"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -10,20 +12,26 @@ import (
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/apiserver/httpclient"
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util"
 	"github.com/fission/fission-workflows/test/integration"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
-	TestSuiteTimeout = 10 * time.Minute
-	TestTimeout      = time.Minute
-	gRPCAddress      = ":5555"
+	TestSuiteTimeout  = 10 * time.Minute
+	TestTimeout       = time.Minute
+	gRPCAddress       = ":5555"
+	apiGatewayAddress = ":8080"
 )
 
 func TestMain(m *testing.M) {
@@ -153,6 +161,17 @@ func TestWorkflowInvocation(t *testing.T) {
 	assert.Equal(t, wiSpec, invocation.Spec)
 	assert.Equal(t, etv.Value, invocation.Status.Output.Value)
 	assert.True(t, invocation.Status.Successful())
+
+	// Test point-in-time reconstruction of the invocation
+	created, err := wi.GetAt(ctx, &apiserver.InvocationGetAtQuery{Id: wiId, Seq: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, created.GetStatus().GetStatus())
+	assert.Empty(t, created.GetStatus().GetTasks())
+	completed, err := wi.GetAt(ctx, &apiserver.InvocationGetAtQuery{Id: wiId, Timestamp: invocation.Status.UpdatedAt})
+	assert.NoError(t, err)
+	assert.True(t, completed.GetStatus().Successful())
+	_, err = wi.GetAt(ctx, &apiserver.InvocationGetAtQuery{Id: wiId, Timestamp: &timestamp.Timestamp{}})
+	assert.Error(t, err)
 }
 
 func TestDynamicWorkflowInvocation(t *testing.T) {
@@ -403,6 +422,142 @@ func TestWorkflowCancellation(t *testing.T) {
 	assert.False(t, wfi.GetStatus().Successful())
 	assert.True(t, wfi.GetStatus().Finished())
 	assert.Equal(t, api.ErrInvocationCanceled, wfi.GetStatus().GetError().Error())
+
+	// The invocation should be listed under its new status
+	wfis, err = wi.List(ctx, &apiserver.InvocationListQuery{
+		Workflows: []string{wfResp.GetId()},
+		Statuses:  []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_ABORTED},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{wfiID}, wfis.Invocations)
+}
+
+func TestInvocationWatch(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task",
+		Tasks: types.Tasks{
+			"task": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("foo"),
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// Watch the events of the invocations of the workflow over gRPC and HTTP
+	stream, err := wi.Watch(ctx, &apiserver.InvocationWatchQuery{Workflows: []string{wfResp.GetId()}})
+	assert.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost"+apiGatewayAddress+"/invocation/events?workflows="+
+		wfResp.GetId(), nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = wi.Invoke(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+
+	var eventTypes []string
+	for {
+		event, err := stream.Recv()
+		if !assert.NoError(t, err) {
+			break
+		}
+		eventTypes = append(eventTypes, event.GetType())
+		if event.GetType() == "InvocationCompleted" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"InvocationCreated", "TaskStarted", "TaskSucceeded", "InvocationCompleted"}, eventTypes)
+
+	var sseEventTypes []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() && len(sseEventTypes) < len(eventTypes) {
+		if strings.HasPrefix(scanner.Text(), "event: ") {
+			sseEventTypes = append(sseEventTypes, strings.TrimPrefix(scanner.Text(), "event: "))
+		}
+	}
+	assert.Equal(t, eventTypes, sseEventTypes)
+}
+
+func TestWorkflowTimeout(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "longSleep",
+		Timeout:    ptypes.DurationProto(500 * time.Millisecond),
+		Tasks: types.Tasks{
+			"longSleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      typedvalues.Input("3s"),
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// The timeout of the workflow should fail the invocation before the task completes.
+	wfiID, err := wi.Invoke(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	var wfi *types.WorkflowInvocation
+	for i := 0; i < 20; i++ {
+		time.Sleep(100 * time.Millisecond)
+		wfi, err = wi.Get(ctx, wfiID)
+		assert.NoError(t, err)
+		if wfi.GetStatus().Finished() {
+			break
+		}
+	}
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+	assert.Contains(t, wfi.GetStatus().GetError().GetMessage(), "timed out")
+
+	// The timeout of the invocation should override the timeout of the workflow.
+	wiSpec := types.NewWorkflowInvocationSpec(wfResp.GetId())
+	wiSpec.Timeout = ptypes.DurationProto(time.Minute)
+	wfi, err = wi.InvokeSync(ctx, wiSpec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Successful())
+}
+
+func TestTaskTimeout(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "longSleep",
+		Tasks: types.Tasks{
+			"longSleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      typedvalues.Input("3s"),
+				Timeout:     ptypes.DurationProto(200 * time.Millisecond),
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// The task should fail with a timeout error long before the function completes.
+	start := time.Now()
+	wfi, err := wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) < 3*time.Second)
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+	task := wfi.GetStatus().GetTasks()["longSleep"]
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, task.GetStatus().GetStatus())
+	assert.Equal(t, types.ErrorCodeTimeout, task.GetStatus().GetError().GetCode())
 }
 
 func TestInvocationInvalid(t *testing.T) {
@@ -456,6 +611,560 @@ func TestInvocationFailed(t *testing.T) {
 	// TODO generate consistent error report!
 }
 
+func TestTaskRetry(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Fail,
+				Inputs:      typedvalues.Input("expected error"),
+				Retry: &types.RetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     ptypes.DurationProto(50 * time.Millisecond),
+				},
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// The task should be attempted until the retry policy gives up.
+	wfi, err := wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+	task := wfi.GetStatus().GetTasks()["task1"]
+	assert.EqualValues(t, 3, task.GetStatus().GetAttempts())
+	assert.Equal(t, types.ErrorCodeFunction, task.GetStatus().GetError().GetCode())
+
+	// Errors that are not selected by the retry policy should not be retried.
+	wfSpec.Tasks["task1"].Retry.RetryOn = []string{types.ErrorCodeRuntime}
+	wfResp, err = cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+	wfi, err = wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+	assert.EqualValues(t, 1, wfi.GetStatus().GetTasks()["task1"].GetStatus().GetAttempts())
+}
+
+func TestTaskFailurePolicy(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "last",
+		Tasks: types.Tasks{
+			"failing": {
+				FunctionRef: builtin.Fail,
+				Inputs:      typedvalues.Input("expected error"),
+				OnFailure:   &types.FailurePolicy{Action: types.FailurePolicy_CONTINUE},
+			},
+			"fallible": {
+				FunctionRef: builtin.Fail,
+				Inputs:      typedvalues.Input("expected error"),
+				OnFailure:   &types.FailurePolicy{Fallback: "fallback"},
+			},
+			"fallback": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("{ 'handled ' + output('fallible').message }"),
+				Requires:    types.Require("fallible"),
+			},
+			"succeeding": {
+				FunctionRef: builtin.Noop,
+				OnFailure:   &types.FailurePolicy{Fallback: "unused"},
+			},
+			"unused": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("succeeding"),
+			},
+			"last": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("{ output('fallback') + ', ' + output('failing').code }"),
+				Requires:    types.Require("failing", "fallible", "succeeding"),
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// The failures should be handled, with the fallback task only running for the failed task.
+	wfi, err := wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "handled expected error, function", typedvalues.MustFormat(wfi.GetStatus().GetOutput()))
+	tasks := wfi.GetStatus().GetTasks()
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, tasks["failing"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, tasks["fallible"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, tasks["fallback"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, tasks["unused"].GetStatus().GetStatus())
+
+	// Without a failure policy, the failure of the task should still fail the invocation.
+	wfSpec.Tasks["failing"].OnFailure = nil
+	wfResp, err = cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+	wfi, err = wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+}
+
+func TestTaskCondition(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "last",
+		Tasks: types.Tasks{
+			"check": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input(1),
+			},
+			"run": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("ran"),
+				Requires:    types.Require("check"),
+				When:        typedvalues.MustParse("{ output('check') == 1 }"),
+			},
+			"skip": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("check"),
+				When:        typedvalues.MustParse("{ output('check') > 1 }"),
+			},
+			"skipAny": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("run", "skip"),
+			},
+			"runAll": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("run", "skip"),
+				SkipPolicy:  types.TaskSpec_ALL,
+			},
+			"last": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("{ output('run') }"),
+				Requires:    types.Require("skipAny", "runAll"),
+				SkipPolicy:  types.TaskSpec_NONE,
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// Only the task of which the condition evaluates to false, and its dependents, should be skipped.
+	wfi, err := wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "ran", typedvalues.MustFormat(wfi.GetStatus().GetOutput()))
+	tasks := wfi.GetStatus().GetTasks()
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, tasks["run"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, tasks["skip"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, tasks["skipAny"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, tasks["runAll"].GetStatus().GetStatus())
+}
+
+func TestTaskAwait(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "first",
+		Tasks: types.Tasks{
+			"fast": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("fast"),
+			},
+			"slow": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      typedvalues.Input("5s"),
+			},
+			"setup": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      typedvalues.Input("100ms"),
+			},
+			"first": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("{ output('fast') }"),
+				Requires: map[string]*types.TaskDependencyParameters{
+					"fast": nil,
+					"slow": nil,
+					"setup": {
+						Type: types.TaskDependencyParameters_CONTROL,
+					},
+				},
+				Await: 1,
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	// The task should run once the fast task and the control dependency have completed, skipping the slow task.
+	start := time.Now()
+	wfi, err := wi.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "invocation should not have waited on the slow task")
+	assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "fast", typedvalues.MustFormat(wfi.GetStatus().GetOutput()))
+	tasks := wfi.GetStatus().GetTasks()
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, tasks["fast"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, tasks["slow"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, tasks["setup"].GetStatus().GetStatus())
+}
+
+func TestInvocationPause(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "afterSleep",
+		Tasks: types.Tasks{
+			"sleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      typedvalues.Input("500ms"),
+			},
+			"afterSleep": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("done"),
+				Requires:    types.Require("sleep"),
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	wfiID, err := wi.Invoke(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	_, err = wi.Pause(ctx, wfiID)
+	assert.NoError(t, err)
+
+	// An invocation that is already paused cannot be paused again.
+	_, err = wi.Pause(ctx, wfiID)
+	assert.Error(t, err)
+
+	// The paused invocation should not start the next task, even after the running task has completed.
+	time.Sleep(time.Second)
+	wfi, err := wi.Get(ctx, wfiID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_PAUSED, wfi.GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, wfi.GetStatus().GetTasks()["sleep"].GetStatus().GetStatus())
+	assert.NotContains(t, wfi.GetStatus().GetTasks(), "afterSleep")
+
+	_, err = wi.Resume(ctx, wfiID)
+	assert.NoError(t, err)
+	_, err = wi.Resume(ctx, wfiID)
+	assert.Error(t, err)
+	for i := 0; i < 20; i++ {
+		time.Sleep(100 * time.Millisecond)
+		wfi, err = wi.Get(ctx, wfiID)
+		assert.NoError(t, err)
+		if wfi.GetStatus().Finished() {
+			break
+		}
+	}
+	assert.True(t, wfi.GetStatus().Successful())
+	assert.Equal(t, "done", typedvalues.MustFormat(wfi.GetStatus().GetOutput()))
+}
+
+func TestHalt(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	conn, err := grpc.Dial(gRPCAddress, grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+	admin := apiserver.NewAdminAPIClient(conn)
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	_, err = admin.Halt(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+	defer admin.Resume(ctx, &empty.Empty{})
+	health, err := admin.Status(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "HALTED", health.GetStatus())
+
+	// The halted engine should not evaluate the invocation.
+	wfiID, err := wi.Invoke(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	time.Sleep(500 * time.Millisecond)
+	wfi, err := wi.Get(ctx, wfiID)
+	assert.NoError(t, err)
+	assert.False(t, wfi.GetStatus().Finished())
+	assert.Empty(t, wfi.GetStatus().GetTasks())
+
+	// After resuming the engine, the invocation should be picked up again.
+	_, err = admin.Resume(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+	for i := 0; i < 50; i++ {
+		time.Sleep(100 * time.Millisecond)
+		wfi, err = wi.Get(ctx, wfiID)
+		assert.NoError(t, err)
+		if wfi.GetStatus().Finished() {
+			break
+		}
+	}
+	assert.True(t, wfi.GetStatus().Successful())
+}
+
+func TestInvocationRetry(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "last",
+		Tasks: types.Tasks{
+			"first": {
+				FunctionRef: builtin.Noop,
+			},
+			"failing": {
+				FunctionRef: builtin.Fail,
+				Requires:    types.Require("first"),
+			},
+			"last": {
+				FunctionRef: builtin.Noop,
+				Inputs:      typedvalues.Input("done"),
+				Requires:    types.Require("failing"),
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	stream, err := wi.Watch(ctx, &apiserver.InvocationWatchQuery{Workflows: []string{wfResp.GetId()}})
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	// recvUntilFailed returns the events of the invocation, formatted as "<type> <aggregate>", up to and including
+	// the failure of the invocation.
+	recvUntilFailed := func() []string {
+		var events []string
+		for {
+			event, err := stream.Recv()
+			if !assert.NoError(t, err) {
+				return events
+			}
+			events = append(events, event.GetType()+" "+event.GetAggregate().GetId())
+			if event.GetType() == "InvocationFailed" {
+				return events
+			}
+		}
+	}
+
+	wfiID, err := wi.Invoke(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"InvocationCreated " + wfiID.GetId(),
+		"TaskStarted first",
+		"TaskSucceeded first",
+		"TaskStarted failing",
+		"TaskFailed failing",
+		"InvocationFailed " + wfiID.GetId(),
+	}, recvUntilFailed())
+
+	// Only the failed and unstarted tasks should be invoked again.
+	_, err = wi.Retry(ctx, wfiID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"InvocationRetried " + wfiID.GetId(),
+		"TaskStarted failing",
+		"TaskFailed failing",
+		"InvocationFailed " + wfiID.GetId(),
+	}, recvUntilFailed())
+
+	wfi, err := wi.Get(ctx, wfiID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+	assert.EqualValues(t, 1, wfi.GetStatus().GetTasks()["first"].GetStatus().GetAttempts())
+	assert.EqualValues(t, 2, wfi.GetStatus().GetTasks()["failing"].GetStatus().GetAttempts())
+	assert.NotContains(t, wfi.GetStatus().GetTasks(), "last")
+}
+
+func TestInvocationDelayed(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	spec := types.NewWorkflowInvocationSpec(wfResp.GetId())
+	spec.Delay = ptypes.DurationProto(2 * time.Second)
+	wfiID, err := wi.Invoke(ctx, spec)
+	assert.NoError(t, err)
+
+	// The invocation remains scheduled until the delay has passed.
+	time.Sleep(time.Second)
+	wfi, err := wi.Get(ctx, wfiID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_SCHEDULED, wfi.GetStatus().GetStatus())
+	assert.Empty(t, wfi.GetStatus().GetTasks())
+
+	for i := 0; i < 50; i++ {
+		time.Sleep(100 * time.Millisecond)
+		wfi, err = wi.Get(ctx, wfiID)
+		assert.NoError(t, err)
+		if wfi.GetStatus().Finished() {
+			break
+		}
+	}
+	assert.True(t, wfi.GetStatus().Successful())
+	startAt, err := wfi.StartAt()
+	assert.NoError(t, err)
+	updatedAt, err := ptypes.Timestamp(wfi.GetStatus().GetUpdatedAt())
+	assert.NoError(t, err)
+	assert.False(t, updatedAt.Before(startAt))
+}
+
+func TestInvocationIdempotent(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	spec := types.NewWorkflowInvocationSpec(wfResp.GetId())
+	spec.IdempotencyKey = util.UID()
+	wfiID, err := wi.Invoke(ctx, spec)
+	assert.NoError(t, err)
+
+	// Retrying the request should return the original invocation.
+	retriedID, err := wi.Invoke(ctx, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, wfiID.GetId(), retriedID.GetId())
+
+	// The key can also be provided in the metadata of the request, as the HTTP gateway does with the header.
+	mdCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs(apiserver.HeaderIdempotencyKey, spec.IdempotencyKey))
+	mdID, err := wi.Invoke(mdCtx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.Equal(t, wfiID.GetId(), mdID.GetId())
+
+	otherID, err := wi.Invoke(ctx, types.NewWorkflowInvocationSpec(wfResp.GetId()))
+	assert.NoError(t, err)
+	assert.NotEqual(t, wfiID.GetId(), otherID.GetId())
+}
+
+func TestSchedule(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), TestTimeout)
+	defer cancelFn()
+	cl, wi := setup()
+	// The schedules are managed over the HTTP gateway, which maps the spec of updates to the body of the request.
+	sc := httpclient.NewScheduleAPI("http://localhost"+apiGatewayAddress, http.Client{})
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	}
+	wfResp, err := cl.Create(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer cl.Delete(ctx, wfResp)
+
+	_, err = sc.Create(ctx, &types.ScheduleSpec{WorkflowId: wfResp.GetId(), Cron: "not a cron expression"})
+	assert.Error(t, err)
+	scResp, err := sc.Create(ctx, &types.ScheduleSpec{
+		WorkflowId: wfResp.GetId(),
+		Cron:       "@every 1s",
+	})
+	assert.NoError(t, err)
+	list, err := sc.List(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, list.GetSchedules(), scResp.GetId())
+
+	// The schedule should invoke the workflow within a few seconds.
+	var schedule *types.Schedule
+	for i := 0; i < 50; i++ {
+		time.Sleep(100 * time.Millisecond)
+		schedule, err = sc.Get(ctx, scResp.GetId())
+		assert.NoError(t, err)
+		if len(schedule.GetStatus().GetLastInvocationId()) > 0 {
+			break
+		}
+	}
+	invocationID := schedule.GetStatus().GetLastInvocationId()
+	assert.NotEmpty(t, invocationID)
+	wfi, err := wi.Get(ctx, &apiserver.WorkflowInvocationIdentifier{Id: invocationID})
+	assert.NoError(t, err)
+	assert.Equal(t, wfResp.GetId(), wfi.GetSpec().GetWorkflowId())
+
+	// Schedules that do not exist cannot be updated or deleted.
+	err = sc.Update(ctx, "sc-unknown", &types.ScheduleSpec{
+		WorkflowId: wfResp.GetId(),
+		Cron:       "@yearly",
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), http.StatusText(http.StatusNotFound))
+	}
+	err = sc.Delete(ctx, "sc-unknown")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), http.StatusText(http.StatusNotFound))
+	}
+
+	err = sc.Update(ctx, scResp.GetId(), &types.ScheduleSpec{
+		WorkflowId: wfResp.GetId(),
+		Cron:       "@yearly",
+	})
+	assert.NoError(t, err)
+	time.Sleep(500 * time.Millisecond)
+	schedule, err = sc.Get(ctx, scResp.GetId())
+	assert.NoError(t, err)
+	assert.Equal(t, "@yearly", schedule.GetSpec().GetCron())
+
+	err = sc.Delete(ctx, scResp.GetId())
+	assert.NoError(t, err)
+	time.Sleep(500 * time.Millisecond)
+	schedule, err = sc.Get(ctx, scResp.GetId())
+	assert.NoError(t, err)
+	assert.True(t, schedule.GetStatus().Deleted())
+}
+
 func setup() (apiserver.WorkflowAPIClient, apiserver.WorkflowInvocationAPIClient) {
 	conn, err := grpc.Dial(gRPCAddress, grpc.WithInsecure())
 	if err != nil {
@@ -2,13 +2,17 @@ package nats
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/fes/backend/nats"
 	fesnats "github.com/fission/fission-workflows/pkg/fes/backend/nats"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/ory-am/dockertest.v3"
@@ -16,6 +20,7 @@ import (
 
 var (
 	backend fes.Backend
+	cfg     fesnats.Config
 )
 
 // Tests the event store implementation with a live NATS cluster.
@@ -55,7 +60,7 @@ func TestMain(m *testing.M) {
 
 	// exponential backoff-retry, because the application in the container might not be ready to accept connections yet
 	if err := pool.Retry(func() error {
-		cfg := fesnats.Config{
+		cfg = fesnats.Config{
 			Cluster: clusterId,
 			Client:  fmt.Sprintf("client-%s", id),
 			URL:     fmt.Sprintf("nats://%s:%s", "0.0.0.0", resource.GetPort("4222/tcp")),
@@ -113,3 +118,107 @@ func TestNatsBackend_List(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, subjects)
 }
+
+func TestNatsBackend_ResumeFromCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nats-checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	checkpointCfg := cfg
+	checkpointCfg.Client = fmt.Sprintf("checkpoint-client-%s", util.UID())
+	checkpointCfg.CheckpointPath = filepath.Join(dir, "checkpoints.json")
+	key := fes.NewAggregate(fmt.Sprintf("checkpointType%s", util.UID()), "someId")
+	subject := fmt.Sprintf("%s.%s", key.Type, key.Id)
+
+	// Process the first events with a watching event store, which stores the checkpoint on closing.
+	es, err := nats.Connect(checkpointCfg)
+	assert.NoError(t, err)
+	sub := es.Subscribe(pubsub.SubscriptionOptions{Buffer: 10})
+	assert.NoError(t, es.Watch(fes.Aggregate{Type: key.Type}))
+	appendDummyEvents(t, key, 3)
+	processed := receiveEventIDs(t, sub, 3)
+	assert.Equal(t, []string{"1", "2", "3"}, processed)
+	assert.NoError(t, es.Close())
+
+	// The last event might not have been checkpointed yet, but the events before it have.
+	checkpoints, err := fesnats.NewFileCheckpointStore(checkpointCfg.CheckpointPath).Load()
+	assert.NoError(t, err)
+	checkpoint := checkpoints[subject]
+	assert.True(t, checkpoint >= 2, "checkpoint %d should include the processed events", checkpoint)
+
+	// Append events while the event store is down.
+	appendDummyEvents(t, key, 3)
+
+	// After the restart, the events up to the checkpoint should be caught up on, and the watch should resume after it.
+	es, err = nats.Connect(checkpointCfg)
+	assert.NoError(t, err)
+	defer es.Close()
+	var caughtUp []string
+	count, err := es.CatchUp(key.Type, func(event *fes.Event) error {
+		caughtUp = append(caughtUp, event.Id)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, checkpoint, count)
+	sub = es.Subscribe(pubsub.SubscriptionOptions{Buffer: 10})
+	assert.NoError(t, es.Watch(fes.Aggregate{Type: key.Type}))
+	resumed := receiveEventIDs(t, sub, 6-int(checkpoint))
+
+	// Each event should have been received exactly once.
+	assert.Equal(t, []string{"1", "2", "3", "4", "5", "6"}, append(caughtUp, resumed...))
+}
+
+func TestNatsBackend_Broadcast(t *testing.T) {
+	broadcaster := backend.(*fesnats.EventStore).Broadcaster()
+	defer broadcaster.Close()
+	key := fes.NewAggregate(fmt.Sprintf("broadcastType%s", util.UID()), "someId")
+	sub := broadcaster.Subscribe(pubsub.SubscriptionOptions{Buffer: 10})
+	assert.NoError(t, broadcaster.Watch(key.Type))
+
+	dummyEvent := &fes.DummyEvent{Msg: "dummy"}
+	event, err := fes.NewEvent(key, dummyEvent)
+	assert.NoError(t, err)
+	assert.NoError(t, broadcaster.Append(event))
+	select {
+	case msg := <-sub.Ch:
+		data, err := fes.UnmarshalEventData(msg.(*fes.Event))
+		assert.NoError(t, err)
+		assert.Equal(t, dummyEvent, data)
+	case <-time.After(10 * time.Second):
+		t.Fatal("did not receive the broadcasted event")
+	}
+
+	// The broadcasted events are not persisted in NATS Streaming.
+	events, err := backend.Get(key)
+	assert.Error(t, err)
+	assert.Empty(t, events)
+}
+
+func appendDummyEvents(t *testing.T, key fes.Aggregate, n int) {
+	for i := 0; i < n; i++ {
+		event, err := fes.NewEvent(key, &fes.DummyEvent{Msg: fmt.Sprintf("%d", i)})
+		assert.NoError(t, err)
+		assert.NoError(t, backend.Append(event))
+	}
+}
+
+// receiveEventIDs receives the IDs of n events from the subscription, failing if fewer or more events are received.
+func receiveEventIDs(t *testing.T, sub *pubsub.Subscription, n int) []string {
+	var ids []string
+	timeout := time.After(10 * time.Second)
+	for len(ids) < n {
+		select {
+		case msg := <-sub.Ch:
+			ids = append(ids, msg.(*fes.Event).Id)
+		case <-timeout:
+			t.Fatalf("received %d of the %d expected events: %v", len(ids), n, ids)
+		}
+	}
+	select {
+	case msg := <-sub.Ch:
+		t.Fatalf("received unexpected event %v", msg)
+	case <-time.After(500 * time.Millisecond):
+	}
+	return ids
+}
@@ -19,9 +19,11 @@ func SetupBundle(ctx context.Context, opts ...bundle.Options) bundle.Options {
 			InternalRuntime:      true,
 			InvocationController: true,
 			WorkflowController:   true,
+			ScheduleController:   true,
 			HTTPGateway:          true,
 			InvocationAPI:        true,
 			WorkflowAPI:          true,
+			ScheduleAPI:          true,
 			AdminAPI:             true,
 		}
 	}